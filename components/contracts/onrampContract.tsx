@@ -48,6 +48,11 @@ export const ONRAMP_CONTRACT_ABI = [
             name: 'size',
             type: 'uint64',
           },
+          {
+            internalType: 'string',
+            name: 'cid',
+            type: 'string',
+          },
           {
             internalType: 'string',
             name: 'location',
@@ -227,6 +232,11 @@ export const ONRAMP_CONTRACT_ABI = [
             name: 'size',
             type: 'uint64',
           },
+          {
+            internalType: 'string',
+            name: 'cid',
+            type: 'string',
+          },
           {
             internalType: 'string',
             name: 'location',
@@ -279,6 +289,11 @@ export const ONRAMP_CONTRACT_ABI = [
         name: 'size',
         type: 'uint64',
       },
+      {
+        internalType: 'string',
+        name: 'cid',
+        type: 'string',
+      },
       {
         internalType: 'string',
         name: 'location',
@@ -79,12 +79,9 @@ function deploy-onramp
 	echo "filClientAddr: $filClientAddr"
 
 	#./lotus state wait-msg --timeout "2m" (./lotus send $filClientAddr 20)
-	cd $ONRAMP_CODE_PATH
-	jq -c '.abi' out/OnRamp.sol/OnRampContract.json > ~/.xchain/onramp-abi.json
-
     # chain id and lotus api url is hard coded and will be a source of bugs when moved away from calibnet
 	jo -a (jo -- ChainID=314159 Api="$XCHAIN_ETH_API" -s OnRampAddress="$onrampAddr" \
-		KeyPath="$XCHAIN_KEY_PATH" ClientAddr="$clientAddr" OnRampABIPath=~/.xchain/onramp-abi.json \
+		KeyPath="$XCHAIN_KEY_PATH" ClientAddr="$clientAddr" \
 		BufferPath=~/.xchain/buffer BufferPort=5077 ProviderAddr="$MINER_ADDRESS" \
 		LotusAPI="http://localhost:1234" -s ProverAddr="$proverAddr" \
 		-s PayoutAddr="0x0C0FFEEC0FFEEC0FFEEC0FFEEC0FFEEC0FEECAFE") > ~/.xchain/config.json
@@ -102,6 +102,11 @@ contract DealClientAxl is AxelarExecutable {
     event XChainProveDataStored(
         string destinationChain, 
         string destinationAddress);
+    event DealRelayed(
+        uint64 dealId,
+        bytes commP,
+        uint256 chainId
+    );
 
     constructor(
             address _gateway,
@@ -208,8 +213,9 @@ contract DealClientAxl is AxelarExecutable {
 
         require(pieceDeals[pieceCid] > 0, "Deal does not exist for piece cid");
 
-        (int256 exit_code, MarketTypes.GetDealActivationReturn memory ret) = MarketAPI
-            .getDealActivation(pieceDeals[pieceCid]);
+        (int256 exit_code, MarketTypes.GetDealActivationReturn memory ret) = getDealActivation(
+            pieceDeals[pieceCid]
+        );
         
         require(exit_code == 0, "Deal activation failed with non zero exit code");
       
@@ -220,6 +226,71 @@ contract DealClientAxl is AxelarExecutable {
         }
     }
 
+    // relayDeal sends the attestation of a published deal to its source chain
+    // again. dealNotify relays it once when the deal is published; relayers call
+    // this for deals whose attestation never reached the OnRamp. The caller pays
+    // the gas of the cross chain call with msg.value, at least AXELAR_GAS_FEE, and
+    // gets the excess refunded by Axelar.
+    function relayDeal(bytes calldata pieceCid) external payable {
+        uint64 dealId = pieceDeals[pieceCid];
+        require(dealId != 0, "Unknown piece");
+        updateDealStatus(pieceCid);
+        require(
+            pieceStatus[pieceCid] != Status.DealTerminated,
+            "Deal is terminated"
+        );
+
+        (int256 labelExitCode, CommonTypes.DealLabel memory label) = getDealLabel(dealId);
+        require(labelExitCode == 0, "Deal label lookup failed with non zero exit code");
+        (int256 termExitCode, MarketTypes.GetDealTermReturn memory term) = getDealTerm(dealId);
+        require(termExitCode == 0, "Deal term lookup failed with non zero exit code");
+
+        // Expects deal label to be chainId encoded in bytes
+        uint256 chainId = asciiBytesToUint(label.data);
+        DataAttestation memory attest = DataAttestation(
+            pieceCid,
+            CommonTypes.ChainEpoch.unwrap(term.duration),
+            dealId,
+            uint256(pieceStatus[pieceCid])
+        );
+        bytes memory payload = abi.encode(attest);
+
+        emit DealRelayed(dealId, pieceCid, chainId);
+
+        if (chainId == block.chainid) {
+            IBridgeContract(
+                chainIdToSourceChain[chainId].sourceOracleAddress
+            )._execute(
+                    chainIdToSourceChain[chainId].chainName,
+                    addressToHexString(address(this)),
+                    payload
+                );
+        } else {
+            require(msg.value >= AXELAR_GAS_FEE, "Gas fee too low");
+            call_axelar(payload, bytes(""), msg.value, chainId);
+        }
+    }
+
+    // Deals are looked up in the market actor through these, tests override
+    // them to run without the Filecoin builtin actors
+    function getDealActivation(
+        uint64 dealId
+    ) internal virtual returns (int256, MarketTypes.GetDealActivationReturn memory) {
+        return MarketAPI.getDealActivation(dealId);
+    }
+
+    function getDealLabel(
+        uint64 dealId
+    ) internal virtual returns (int256, CommonTypes.DealLabel memory) {
+        return MarketAPI.getDealLabel(dealId);
+    }
+
+    function getDealTerm(
+        uint64 dealId
+    ) internal virtual returns (int256, MarketTypes.GetDealTermReturn memory) {
+        return MarketAPI.getDealTerm(dealId);
+    }
+
     // dealNotify is the callback from the market actor into the contract at the end
     // of PublishStorageDeals. This message holds the previously approved deal proposal
     // and the associated dealID. The dealID is stored as part of the contract state
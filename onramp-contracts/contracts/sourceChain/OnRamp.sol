@@ -3,7 +3,7 @@ pragma solidity ^0.8.17;
 
 import {IERC20} from "@openzeppelin/contracts/token/ERC20/IERC20.sol";
 import {Cid} from "../Cid.sol";
-import {TRUNCATOR} from "../Const.sol";
+import {TRUNCATOR, ENTRY_SIZE} from "../Const.sol";
 import {DataAttestation} from "./Oracles.sol";
 
 // Adapted from https://github.com/lighthouse-web3/raas-starter-kit/blob/main/contracts/data-segment/Proof.sol
@@ -27,6 +27,78 @@ contract PODSIVerifier {
         return computeRoot(proof, leaf) == root;
     }
 
+    // verifyInclusion verifies a PoDSI inclusion proof like go-data-segment's
+    // InclusionProof.ComputeExpectedAuxData: the piece subtree and the data
+    // segment index entry describing it must both lead to the aggregate root,
+    // both proofs must imply the same aggregate size and the entry must lie in
+    // the index area at the end of the aggregate.
+    function verifyInclusion(
+        ProofData memory proofSubtree,
+        ProofData memory proofIndex,
+        bytes32 root,
+        bytes32 commPc,
+        uint64 sizePc
+    ) public pure returns (bool) {
+        if (computeRoot(proofSubtree, commPc) != root) {
+            return false;
+        }
+        uint256 sizePa = uint256(sizePc) << proofSubtree.path.length;
+        if (uint256(ENTRY_SIZE) << proofIndex.path.length != sizePa) {
+            return false;
+        }
+
+        uint64 offset = proofSubtree.index * sizePc;
+        bytes32 entryNode = truncate(sha256(indexEntry(commPc, offset, sizePc)));
+        if (computeRoot(proofIndex, entryNode) != root) {
+            return false;
+        }
+
+        uint256 indexSize = maxIndexEntriesInDeal(sizePa) * ENTRY_SIZE;
+        if (indexSize > sizePa) {
+            return false;
+        }
+        return uint256(proofIndex.index) * ENTRY_SIZE >= sizePa - indexSize;
+    }
+
+    // indexEntry serializes the data segment index entry of a piece, including its checksum
+    function indexEntry(
+        bytes32 commDs,
+        uint64 offset,
+        uint64 size
+    ) internal pure returns (bytes memory) {
+        bytes memory entry = abi.encodePacked(
+            commDs,
+            littleEndian(offset),
+            littleEndian(size)
+        );
+        // The checksum is the hash of the entry with a zero checksum, truncated to 126 bits
+        bytes16 checksum = bytes16(
+            sha256(abi.encodePacked(entry, bytes16(0)))
+        );
+        checksum &= ~bytes16(uint128(0xc0));
+        return abi.encodePacked(entry, checksum);
+    }
+
+    // maxIndexEntriesInDeal is the number of entries the index of an aggregate of sizePa bytes has room for
+    function maxIndexEntriesInDeal(
+        uint256 sizePa
+    ) internal pure returns (uint256) {
+        uint256 entries = 1;
+        while (entries < sizePa / 2048 / ENTRY_SIZE) {
+            entries <<= 1;
+        }
+        return entries < 4 ? 4 : entries;
+    }
+
+    function littleEndian(uint64 v) internal pure returns (bytes8) {
+        uint64 r = 0;
+        for (uint256 i = 0; i < 8; i++) {
+            r = (r << 8) | (v & 0xff);
+            v >>= 8;
+        }
+        return bytes8(r);
+    }
+
     // computeRoot computes the root of a Merkle tree given a leaf and a Merkle proof.
     function computeRoot(
         ProofData memory d,
@@ -83,6 +155,7 @@ contract OnRampContract is PODSIVerifier {
     struct Offer {
         bytes commP;
         uint64 size;
+        string cid;
         string location;
         uint256 amount;
         IERC20 token;
@@ -122,18 +195,24 @@ contract OnRampContract is PODSIVerifier {
     }
 
     function offerData(Offer calldata offer) external payable returns (uint64) {
-        // NOTE: This require is commented out for testing purposes.
-        // Make sure to uncomment before deploying!
-
-        // require(
-        //     offer.token.transferFrom(msg.sender, address(this), offer.amount),
-        //     "Payment transfer failed"
-        // );
+        // Offers with the zero token address are paid in the native currency
+        if (address(offer.token) == address(0)) {
+            require(msg.value == offer.amount, "Native payment must equal offer amount");
+        } else {
+            require(msg.value == 0, "Native payment sent for token offer");
+            // The client approves the OnRamp for the amount first, it is paid
+            // out to the aggregator once the aggregate is proven
+            require(
+                offer.token.transferFrom(msg.sender, address(this), offer.amount),
+                "Payment transfer failed"
+            );
+        }
 
         uint64 id = nextOfferId++;
         Offer memory newOffer = Offer({
             commP: offer.commP,
             size: offer.size,
+            cid: offer.cid,
             location: offer.location,
             amount: offer.amount,
             token: offer.token,
@@ -213,19 +292,27 @@ contract OnRampContract is PODSIVerifier {
         bytes calldata commP,
         uint64[] calldata claimedIDs,
         ProofData[] calldata inclusionProofs,
+        ProofData[] calldata indexProofs,
         address payoutAddr
     ) external {
+        require(
+            inclusionProofs.length == claimedIDs.length &&
+                indexProofs.length == claimedIDs.length,
+            "One inclusion and one index proof per offer"
+        );
         uint64[] memory offerIDs = new uint64[](claimedIDs.length);
         uint64 aggId = nextAggregateID++;
-        // Prove all offers are committed by aggregate commP
+        // Prove all offers are committed by aggregate commP and listed in its data segment index
         for (uint64 i = 0; i < claimedIDs.length; i++) {
             uint64 offerID = claimedIDs[i];
             offerIDs[i] = offerID;
             require(
-                verify(
+                verifyInclusion(
                     inclusionProofs[i],
+                    indexProofs[i],
                     Cid.cidToPieceCommitment(commP),
-                    Cid.cidToPieceCommitment(offers[offerID].commP)
+                    Cid.cidToPieceCommitment(offers[offerID].commP),
+                    offers[offerID].size
                 ),
                 "Proof verification failed"
             );
@@ -276,6 +363,8 @@ contract OnRampContract is PODSIVerifier {
         );
         uint64 aggID = commPToAggregateID[attestation.commP];
         require(aggID != 0, "Aggregate not found");
+        // Deals can be relayed more than once, payments must only happen once
+        require(!provenAggregations[aggID], "Aggregate already proven");
         emit ProveDataStored(attestation.commP, attestation.dealID);
 
         aggregationDealIds[aggID] = attestation.dealID;
@@ -287,11 +376,18 @@ contract OnRampContract is PODSIVerifier {
             offers[offerID].status = OfferStatus.Proven;
             
             if(offers[offerID].amount > 0){
-                require(offers[offerID].token.transfer(
-                            aggregationPayout[aggID],
-                            offers[offerID].amount),
-                "Payment transfer failed"
-                );
+                if (address(offers[offerID].token) == address(0)) {
+                    (bool sent, ) = payable(aggregationPayout[aggID]).call{
+                        value: offers[offerID].amount
+                    }("");
+                    require(sent, "Payment transfer failed");
+                } else {
+                    require(offers[offerID].token.transfer(
+                                aggregationPayout[aggID],
+                                offers[offerID].amount),
+                    "Payment transfer failed"
+                    );
+                }
             }
         }
         provenAggregations[aggID] = true;
@@ -113,6 +113,46 @@ contract ForwardingProofMockBridge is IBridgeContract {
     }
 }
 
+// This contract lets relayers trusted by its owner prove deals directly,
+// for source chains without a bridge to Filecoin. Relayers read the state
+// of deals on Filecoin and submit the DataAttestation themselves, so the
+// receiver trusts them to report deals truthfully.
+contract TrustedRelayerOracle {
+    address public owner;
+    address public receiver;
+    mapping(address => bool) public relayers;
+    event ReceivedAttestation(bytes commP, uint64 dealID, address relayer);
+    event RelayerSet(address relayer, bool trusted);
+
+    constructor() {
+        owner = msg.sender;
+    }
+
+    modifier onlyOwner() {
+        require(msg.sender == owner, "Only owner can configure oracle");
+        _;
+    }
+
+    function setReceiver(address receiver_) external onlyOwner {
+        receiver = receiver_;
+    }
+
+    function setRelayer(address relayer_, bool trusted_) external onlyOwner {
+        relayers[relayer_] = trusted_;
+        emit RelayerSet(relayer_, trusted_);
+    }
+
+    function relayAttestation(DataAttestation calldata attestation_) external {
+        require(relayers[msg.sender], "Only trusted relayers can relay");
+        emit ReceivedAttestation(
+            attestation_.commP,
+            attestation_.dealID,
+            msg.sender
+        );
+        IReceiveAttestation(receiver).proveDataStored(attestation_);
+    }
+}
+
 contract DebugMockBridge is IBridgeContract {
     event ReceivedAttestation(bytes commP, string sourceAddress);
 
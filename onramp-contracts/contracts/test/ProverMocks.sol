@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.17;
+
+import {CommonTypes} from "filecoin-solidity-api/contracts/v0.8/types/CommonTypes.sol";
+import {MarketTypes} from "filecoin-solidity-api/contracts/v0.8/types/MarketTypes.sol";
+import {DealClientAxl} from "../destChain/Prover-Axelar.sol";
+
+// These contracts stand in for the Filecoin market actor and Axelar so the
+// prover can be tested on a local network
+
+// DealClientAxlHarness serves deals from its own storage instead of the market actor
+contract DealClientAxlHarness is DealClientAxl {
+    struct MockDeal {
+        bytes label;
+        int64 duration;
+        int64 activated;
+        int64 terminated;
+    }
+
+    mapping(uint64 => MockDeal) public mockDeals;
+
+    constructor(
+        address _gateway,
+        address _gasReceiver
+    ) DealClientAxl(_gateway, _gasReceiver) {}
+
+    // setDeal records a deal published for pieceCid as dealNotify would
+    function setDeal(
+        bytes calldata pieceCid,
+        uint64 dealId,
+        MockDeal calldata deal
+    ) external {
+        pieceDeals[pieceCid] = dealId;
+        pieceStatus[pieceCid] = Status.DealPublished;
+        mockDeals[dealId] = deal;
+    }
+
+    function getDealActivation(
+        uint64 dealId
+    ) internal view override returns (int256, MarketTypes.GetDealActivationReturn memory) {
+        MockDeal storage deal = mockDeals[dealId];
+        return (
+            0,
+            MarketTypes.GetDealActivationReturn({
+                activated: CommonTypes.ChainEpoch.wrap(deal.activated),
+                terminated: CommonTypes.ChainEpoch.wrap(deal.terminated)
+            })
+        );
+    }
+
+    function getDealLabel(
+        uint64 dealId
+    ) internal view override returns (int256, CommonTypes.DealLabel memory) {
+        return (0, CommonTypes.DealLabel({data: mockDeals[dealId].label, isString: true}));
+    }
+
+    function getDealTerm(
+        uint64 dealId
+    ) internal view override returns (int256, MarketTypes.GetDealTermReturn memory) {
+        return (
+            0,
+            MarketTypes.GetDealTermReturn({
+                start: CommonTypes.ChainEpoch.wrap(0),
+                duration: CommonTypes.ChainEpoch.wrap(mockDeals[dealId].duration)
+            })
+        );
+    }
+}
+
+// MockAxelarGateway records the cross chain calls made through it
+contract MockAxelarGateway {
+    event ContractCall(
+        address indexed sender,
+        string destinationChain,
+        string destinationContractAddress,
+        bytes payload
+    );
+
+    function callContract(
+        string calldata destinationChain,
+        string calldata destinationContractAddress,
+        bytes calldata payload
+    ) external {
+        emit ContractCall(msg.sender, destinationChain, destinationContractAddress, payload);
+    }
+}
+
+// MockAxelarGasService keeps the gas paid for cross chain calls
+contract MockAxelarGasService {
+    event NativeGasPaidForContractCall(
+        address indexed sourceAddress,
+        string destinationChain,
+        string destinationAddress,
+        uint256 gasFeeAmount,
+        address refundAddress
+    );
+
+    function payNativeGasForContractCall(
+        address sender,
+        string calldata destinationChain,
+        string calldata destinationAddress,
+        bytes calldata,
+        address refundAddress
+    ) external payable {
+        emit NativeGasPaidForContractCall(
+            sender,
+            destinationChain,
+            destinationAddress,
+            msg.value,
+            refundAddress
+        );
+    }
+}
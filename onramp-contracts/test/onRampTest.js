@@ -0,0 +1,199 @@
+const { expect } = require("chai");
+const { ethers } = require("hardhat");
+
+// Proofs of a 1MiB aggregate of three pieces made by go-data-segment, kept in
+// sync with it by TestOnRampFixture in xchainClient/services/aggregator
+const podsi = require("./fixtures/podsi.json");
+
+// cidToPieceCommitment strips the CID header from a piece CID
+function cidToPieceCommitment(cid) {
+  return ethers.dataSlice(cid, 7);
+}
+
+describe("OnRampContract", function () {
+  let onRamp;
+  let payout;
+
+  const root = cidToPieceCommitment(podsi.commP);
+
+  function offer(piece) {
+    return {
+      commP: piece.commP,
+      size: piece.size,
+      cid: "bafy-test",
+      location: "https://data.example.com/piece.car",
+      amount: 0,
+      token: ethers.ZeroAddress,
+      status: 0,
+    };
+  }
+
+  beforeEach(async function () {
+    [, payout] = await ethers.getSigners();
+    const OnRampContract = await ethers.getContractFactory("OnRampContract");
+    onRamp = await OnRampContract.deploy();
+    await onRamp.waitForDeployment();
+  });
+
+  describe("offerData", function () {
+    const amount = ethers.parseEther("0.01");
+    let client;
+    let token;
+
+    beforeEach(async function () {
+      [client] = await ethers.getSigners();
+      const Nickle = await ethers.getContractFactory("Nickle");
+      token = await Nickle.deploy();
+      await token.waitForDeployment();
+    });
+
+    it("should take a native payment of the offer amount", async function () {
+      const paid = { ...offer(podsi.pieces[0]), amount };
+      await expect(onRamp.offerData(paid, { value: amount })).to.changeEtherBalances(
+        [client, onRamp],
+        [-amount, amount]
+      );
+      expect((await onRamp.getClientOffers(client.address)).map(Number)).to.deep.equal([1]);
+    });
+
+    it("should revert a native payment of the wrong value", async function () {
+      const paid = { ...offer(podsi.pieces[0]), amount };
+      await expect(onRamp.offerData(paid, { value: amount - 1n })).to.be.revertedWith(
+        "Native payment must equal offer amount"
+      );
+      await expect(onRamp.offerData(paid)).to.be.revertedWith(
+        "Native payment must equal offer amount"
+      );
+    });
+
+    it("should take an ERC-20 payment approved by the client", async function () {
+      const paid = { ...offer(podsi.pieces[0]), amount, token: token.target };
+      await (await token.approve(onRamp.target, amount)).wait();
+      await expect(onRamp.offerData(paid)).to.changeTokenBalances(
+        token,
+        [client, onRamp],
+        [-amount, amount]
+      );
+    });
+
+    it("should revert an ERC-20 offer with value attached", async function () {
+      const paid = { ...offer(podsi.pieces[0]), amount, token: token.target };
+      await (await token.approve(onRamp.target, amount)).wait();
+      await expect(onRamp.offerData(paid, { value: amount })).to.be.revertedWith(
+        "Native payment sent for token offer"
+      );
+    });
+
+    it("should revert an ERC-20 offer without allowance", async function () {
+      const paid = { ...offer(podsi.pieces[0]), amount, token: token.target };
+      await expect(onRamp.offerData(paid)).to.be.revertedWithCustomError(
+        token,
+        "ERC20InsufficientAllowance"
+      );
+      expect(await onRamp.getTotalOffers()).to.equal(0);
+    });
+  });
+
+  describe("verifyInclusion", function () {
+    it("should accept the proofs of every piece", async function () {
+      for (const piece of podsi.pieces) {
+        expect(
+          await onRamp.verifyInclusion(
+            piece.inclusionProof,
+            piece.indexProof,
+            root,
+            cidToPieceCommitment(piece.commP),
+            piece.size
+          )
+        ).to.equal(true);
+      }
+    });
+
+    it("should reject a subtree proof at the wrong index", async function () {
+      const piece = podsi.pieces[0];
+      const wrongIndex = { ...piece.inclusionProof, index: piece.inclusionProof.index + 1 };
+      expect(
+        await onRamp.verifyInclusion(
+          wrongIndex,
+          piece.indexProof,
+          root,
+          cidToPieceCommitment(piece.commP),
+          piece.size
+        )
+      ).to.equal(false);
+    });
+
+    it("should reject an index proof at the wrong entry", async function () {
+      const piece = podsi.pieces[0];
+      const wrongEntry = { ...piece.indexProof, index: piece.indexProof.index + 1 };
+      expect(
+        await onRamp.verifyInclusion(
+          piece.inclusionProof,
+          wrongEntry,
+          root,
+          cidToPieceCommitment(piece.commP),
+          piece.size
+        )
+      ).to.equal(false);
+    });
+
+    it("should reject the proofs of another subtree", async function () {
+      const piece = podsi.pieces[0];
+      const other = podsi.pieces[2];
+      expect(
+        await onRamp.verifyInclusion(
+          piece.inclusionProof,
+          piece.indexProof,
+          root,
+          cidToPieceCommitment(other.commP),
+          other.size
+        )
+      ).to.equal(false);
+    });
+  });
+
+  describe("commitAggregate", function () {
+    const ids = podsi.pieces.map((_, i) => i + 1);
+    const inclusionProofs = podsi.pieces.map((piece) => piece.inclusionProof);
+    const indexProofs = podsi.pieces.map((piece) => piece.indexProof);
+
+    beforeEach(async function () {
+      for (const piece of podsi.pieces) {
+        await (await onRamp.offerData(offer(piece))).wait();
+      }
+    });
+
+    it("should commit an aggregate with valid proofs", async function () {
+      await expect(
+        onRamp.commitAggregate(podsi.commP, ids, inclusionProofs, indexProofs, payout.address)
+      )
+        .to.emit(onRamp, "AggregationCommitted")
+        .withArgs(1, podsi.commP, ids, payout.address);
+
+      expect((await onRamp.getAggregationOffers(1)).map(Number)).to.deep.equal(ids);
+      expect(await onRamp.commPToAggregateID(podsi.commP)).to.equal(1);
+      for (const id of ids) {
+        expect(await onRamp.isOfferAggregated(id)).to.equal(true);
+      }
+    });
+
+    it("should revert when an index proof is for another entry", async function () {
+      const swapped = [indexProofs[2], indexProofs[1], indexProofs[0]];
+      await expect(
+        onRamp.commitAggregate(podsi.commP, ids, inclusionProofs, swapped, payout.address)
+      ).to.be.revertedWith("Proof verification failed");
+    });
+
+    it("should revert when an offer is claimed with the proofs of another subtree", async function () {
+      await expect(
+        onRamp.commitAggregate(podsi.commP, [3, 2, 1], inclusionProofs, indexProofs, payout.address)
+      ).to.be.revertedWith("Proof verification failed");
+    });
+
+    it("should revert without an index proof per offer", async function () {
+      await expect(
+        onRamp.commitAggregate(podsi.commP, ids, inclusionProofs, indexProofs.slice(1), payout.address)
+      ).to.be.revertedWith("One inclusion and one index proof per offer");
+    });
+  });
+});
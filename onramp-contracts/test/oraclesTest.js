@@ -0,0 +1,95 @@
+const { expect } = require("chai");
+const { ethers } = require("hardhat");
+
+const podsi = require("./fixtures/podsi.json");
+
+describe("TrustedRelayerOracle", function () {
+  let onRamp;
+  let oracle;
+  let owner;
+  let relayer;
+  let stranger;
+  let payout;
+
+  const amount = ethers.parseEther("0.01");
+  const attestation = {
+    commP: podsi.commP,
+    duration: 518400,
+    dealID: 42,
+    status: 1,
+  };
+
+  beforeEach(async function () {
+    [owner, relayer, stranger, payout] = await ethers.getSigners();
+
+    onRamp = await (await ethers.getContractFactory("OnRampContract")).deploy();
+    oracle = await (await ethers.getContractFactory("TrustedRelayerOracle")).deploy();
+    await (await onRamp.setOracle(oracle.target)).wait();
+    await (await oracle.setReceiver(onRamp.target)).wait();
+    await (await oracle.setRelayer(relayer.address, true)).wait();
+
+    // The attestation proves the aggregate of the fixture, paid for in FIL
+    for (const piece of podsi.pieces) {
+      const offer = {
+        commP: piece.commP,
+        size: piece.size,
+        cid: "bafy-test",
+        location: "https://data.example.com/piece.car",
+        amount,
+        token: ethers.ZeroAddress,
+        status: 0,
+      };
+      await (await onRamp.offerData(offer, { value: amount })).wait();
+    }
+    await (
+      await onRamp.commitAggregate(
+        podsi.commP,
+        podsi.pieces.map((_, i) => i + 1),
+        podsi.pieces.map((piece) => piece.inclusionProof),
+        podsi.pieces.map((piece) => piece.indexProof),
+        payout.address
+      )
+    ).wait();
+  });
+
+  it("should only let the owner configure relayers", async function () {
+    await expect(
+      oracle.connect(stranger).setRelayer(stranger.address, true)
+    ).to.be.revertedWith("Only owner can configure oracle");
+    await expect(
+      oracle.connect(stranger).setReceiver(stranger.address)
+    ).to.be.revertedWith("Only owner can configure oracle");
+  });
+
+  it("should revert attestations from untrusted callers", async function () {
+    await expect(
+      oracle.connect(stranger).relayAttestation(attestation)
+    ).to.be.revertedWith("Only trusted relayers can relay");
+
+    await (await oracle.connect(owner).setRelayer(relayer.address, false)).wait();
+    await expect(
+      oracle.connect(relayer).relayAttestation(attestation)
+    ).to.be.revertedWith("Only trusted relayers can relay");
+    expect(await onRamp.provenAggregations(1)).to.equal(false);
+  });
+
+  it("should prove the aggregate with an attestation from a trusted relayer", async function () {
+    const total = amount * BigInt(podsi.pieces.length);
+    const balance = await ethers.provider.getBalance(payout.address);
+    await expect(oracle.connect(relayer).relayAttestation(attestation))
+      .to.emit(oracle, "ReceivedAttestation")
+      .withArgs(attestation.commP, attestation.dealID, relayer.address)
+      .and.to.emit(onRamp, "ProveDataStored")
+      .withArgs(attestation.commP, attestation.dealID);
+
+    expect(await onRamp.provenAggregations(1)).to.equal(true);
+    expect(await onRamp.aggregationDealIds(1)).to.equal(attestation.dealID);
+    expect(await ethers.provider.getBalance(onRamp.target)).to.equal(0);
+    expect(await ethers.provider.getBalance(payout.address)).to.equal(balance + total);
+
+    // Payments are only made once
+    await expect(
+      oracle.connect(relayer).relayAttestation(attestation)
+    ).to.be.revertedWith("Aggregate already proven");
+  });
+});
@@ -0,0 +1,98 @@
+const { expect } = require("chai");
+const { ethers } = require("hardhat");
+
+describe("DealClientAxl relayDeal", function () {
+  let prover;
+  let gateway;
+  let gasService;
+  let relayer;
+  let sourceOracle;
+  let gasFee;
+  let localChainId;
+
+  const pieceCid = "0x0181e2039220200102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20";
+  const dealId = 42;
+  const duration = 518400;
+  const remoteChainId = 43113;
+
+  function deal(chainId, terminated = 0) {
+    return {
+      label: ethers.toUtf8Bytes(chainId.toString()),
+      duration,
+      activated: 100,
+      terminated,
+    };
+  }
+
+  // attestation is the payload relayed for the deal once it is active
+  function attestation() {
+    return ethers.AbiCoder.defaultAbiCoder().encode(
+      ["tuple(bytes commP, int64 duration, uint64 dealID, uint256 status)"],
+      [[pieceCid, duration, dealId, 2]]
+    );
+  }
+
+  beforeEach(async function () {
+    [relayer, sourceOracle] = await ethers.getSigners();
+    localChainId = (await ethers.provider.getNetwork()).chainId;
+
+    gateway = await (await ethers.getContractFactory("MockAxelarGateway")).deploy();
+    gasService = await (await ethers.getContractFactory("MockAxelarGasService")).deploy();
+    const DealClientAxlHarness = await ethers.getContractFactory("DealClientAxlHarness");
+    prover = await DealClientAxlHarness.deploy(gateway.target, gasService.target);
+    await prover.waitForDeployment();
+    gasFee = await prover.AXELAR_GAS_FEE();
+
+    await (
+      await prover.setSourceChains([remoteChainId], ["avalanche"], [sourceOracle.address])
+    ).wait();
+  });
+
+  it("should revert for an unknown piece", async function () {
+    await expect(prover.relayDeal(pieceCid, { value: gasFee })).to.be.revertedWith(
+      "Unknown piece"
+    );
+  });
+
+  it("should revert when too little gas is paid", async function () {
+    await (await prover.setDeal(pieceCid, dealId, deal(remoteChainId))).wait();
+    await expect(prover.relayDeal(pieceCid, { value: gasFee - 1n })).to.be.revertedWith(
+      "Gas fee too low"
+    );
+  });
+
+  it("should revert for a terminated deal", async function () {
+    await (await prover.setDeal(pieceCid, dealId, deal(remoteChainId, 200))).wait();
+    await expect(prover.relayDeal(pieceCid, { value: gasFee })).to.be.revertedWith(
+      "Deal is terminated"
+    );
+  });
+
+  it("should relay the attestation to the source chain through Axelar", async function () {
+    await (await prover.setDeal(pieceCid, dealId, deal(remoteChainId))).wait();
+    const oracleHex = sourceOracle.address.toLowerCase();
+
+    await expect(prover.connect(relayer).relayDeal(pieceCid, { value: gasFee }))
+      .to.emit(prover, "DealRelayed")
+      .withArgs(dealId, pieceCid, remoteChainId)
+      .and.to.emit(gasService, "NativeGasPaidForContractCall")
+      .withArgs(prover.target, "avalanche", oracleHex, gasFee, relayer.address)
+      .and.to.emit(gateway, "ContractCall")
+      .withArgs(prover.target, "avalanche", oracleHex, attestation());
+
+    expect(await ethers.provider.getBalance(gasService.target)).to.equal(gasFee);
+    expect(await prover.pieceStatus(pieceCid)).to.equal(2);
+  });
+
+  it("should relay the attestation to a bridge on the same chain", async function () {
+    const receiver = await (await ethers.getContractFactory("DebugReceiver")).deploy();
+    const bridge = await (await ethers.getContractFactory("ForwardingProofMockBridge")).deploy();
+    await (await bridge.setSenderReceiver(prover.target.toLowerCase(), receiver.target)).wait();
+    await (await prover.setSourceChains([localChainId], ["filecoin-2"], [bridge.target])).wait();
+    await (await prover.setDeal(pieceCid, dealId, deal(localChainId))).wait();
+
+    await expect(prover.relayDeal(pieceCid))
+      .to.emit(receiver, "ReceivedAttestation")
+      .withArgs(pieceCid);
+  });
+});
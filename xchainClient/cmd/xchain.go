@@ -2,15 +2,21 @@ package main
 
 import (
 	"github.com/FIL-Builders/xchainClient/config"
+	"github.com/FIL-Builders/xchainClient/logging"
+	"github.com/FIL-Builders/xchainClient/metrics"
 	"github.com/FIL-Builders/xchainClient/services/aggregator"
 	"github.com/FIL-Builders/xchainClient/services/buffer"
 	"github.com/FIL-Builders/xchainClient/services/client"
 	"github.com/FIL-Builders/xchainClient/services/deal"
 
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 
@@ -22,6 +28,23 @@ func main() {
 		Name:        "xchain",
 		Description: "Filecoin Xchain Data Services",
 		Usage:       "Export filecoin data storage to any blockchain",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "log-level",
+				Usage:   "Lowest level of log messages written: debug, info, warn or error",
+				Value:   "info",
+				EnvVars: []string{"XCHAIN_LOG_LEVEL"},
+			},
+			&cli.StringFlag{
+				Name:    "log-format",
+				Usage:   "Format of log messages: text or json",
+				Value:   "text",
+				EnvVars: []string{"XCHAIN_LOG_FORMAT"},
+			},
+		},
+		Before: func(cctx *cli.Context) error {
+			return logging.Setup(os.Stderr, cctx.String("log-level"), cctx.String("log-format"))
+		},
 		Commands: []*cli.Command{
 			{
 				Name:  "daemon",
@@ -47,6 +70,10 @@ func main() {
 						Usage: "Run an aggregation server",
 						Value: false,
 					},
+					&cli.BoolFlag{
+						Name:  "trusted-relayer",
+						Usage: "Prove active deals on the source chain through its trusted relayer oracle instead of the Prover's bridge",
+					},
 				},
 				Action: func(cctx *cli.Context) error {
 					isBuffer := cctx.Bool("buffer-service")
@@ -54,19 +81,19 @@ func main() {
 
 					cfg, err := config.LoadConfig(cctx.String("config"))
 					if err != nil {
-						log.Fatal(err)
+						return err
 					}
 
 					// Get source chain name
 					chainName := cctx.String("chain")
 					srcCfg, err := config.GetSourceConfig(cfg, chainName)
 					if err != nil {
-						log.Fatalf("Invalid chain name '%s': %v", chainName, err)
+						return fmt.Errorf("invalid chain name '%s': %w", chainName, err)
 					}
 
 					g, ctx := errgroup.WithContext(cctx.Context)
-					fmt.Println("buffer-service is ", isBuffer)
-					fmt.Println("aggregation-service is ", isAgg)
+					slog.Info("Starting daemon", logging.Chain, chainName, "bufferService", isBuffer, "aggregationService", isAgg)
+					slog.Debug("Loaded configuration", "config", cfg)
 
 					g.Go(func() error {
 						if isBuffer {
@@ -81,8 +108,17 @@ func main() {
 						}
 						return nil
 					})
+					g.Go(func() error {
+						if cfg.MetricsPort != 0 {
+							return metrics.Serve(ctx, fmt.Sprintf("0.0.0.0:%d", cfg.MetricsPort))
+						}
+						return nil
+					})
 					g.Go(func() error {
 						if !isAgg && !isBuffer {
+							if cctx.Bool("trusted-relayer") {
+								return deal.TrustedRelayerDeal(ctx, cfg, srcCfg)
+							}
 							return deal.SmartContractDeal(ctx, cfg, srcCfg)
 						}
 						return nil
@@ -96,8 +132,8 @@ func main() {
 				Subcommands: []*cli.Command{
 					{
 						Name:      "offer-file",
-						Usage:     "Offer data by providing a file and payment parameters (file is pre-processed automatically)",
-						ArgsUsage: "<file_path> <payment-addr> <payment-amount>",
+						Usage:     "Offer data by providing a file, directory or glob and payment parameters (data is pre-processed automatically)",
+						ArgsUsage: "<path> <payment-token> <payment-amount>",
 						Flags: []cli.Flag{
 							&cli.StringFlag{
 								Name:  "config",
@@ -109,6 +145,63 @@ func main() {
 								Usage:    "Name of the source blockchain (e.g., ethereum, polygon)",
 								Required: true,
 							},
+							&cli.StringFlag{
+								Name:  "buffer",
+								Usage: "Buffer backend to upload the CAR file to (local, lighthouse, s3)",
+								Value: "local",
+							},
+							&cli.StringFlag{
+								Name:  "buffer-url",
+								Usage: "Public url of the buffer service, overrides BufferURL from the configuration",
+							},
+							&cli.IntFlag{
+								Name:  "chunk-size",
+								Usage: "Size in bytes of each chunk sent to the buffer service",
+								Value: buffer.DefaultChunkSize,
+							},
+							&cli.StringFlag{
+								Name:  "upload-id",
+								Usage: "Resume an interrupted upload session instead of starting a new one",
+							},
+							&cli.IntFlag{
+								Name:  "part",
+								Usage: "Resume a split file at this part, counted from 1, the earlier parts are read from the manifest",
+							},
+							&cli.StringFlag{
+								Name:  "symlinks",
+								Usage: "How to store symlinks inside directories (preserve, follow, skip)",
+								Value: client.SymlinksPreserve,
+							},
+							&cli.BoolFlag{
+								Name:  "encrypt",
+								Usage: "Encrypt files before they are offered, with the default key of the keyring",
+							},
+							&cli.StringFlag{
+								Name:  "keyring",
+								Usage: "Path to the keyring holding the encryption keys",
+								Value: client.DefaultKeyringPath,
+							},
+							&cli.Uint64Flag{
+								Name:  "max-piece-size",
+								Usage: "Largest padded piece size of a single offer, larger files are split (default TargetAggSize/2)",
+							},
+							&cli.StringFlag{
+								Name:  "manifest",
+								Usage: "Where to write the manifest of a split file (default <name>.manifest.json)",
+							},
+							&cli.BoolFlag{
+								Name:  "base-units",
+								Usage: "Payment amounts are in the smallest unit of the token instead of whole tokens",
+							},
+							&cli.BoolFlag{
+								Name:  "wait",
+								Usage: "Wait until the offer is aggregated and proven",
+							},
+							&cli.DurationFlag{
+								Name:  "poll-interval",
+								Usage: "How often to check the offer status with --wait",
+								Value: 30 * time.Second,
+							},
 						},
 						Action: client.OfferFileAction,
 					},
@@ -127,10 +220,259 @@ func main() {
 								Usage:    "Name of the source blockchain (e.g., ethereum, polygon)",
 								Required: true,
 							},
+							&cli.BoolFlag{
+								Name:  "base-units",
+								Usage: "Payment amounts are in the smallest unit of the token instead of whole tokens",
+							},
+							&cli.BoolFlag{
+								Name:  "wait",
+								Usage: "Wait until the offer is aggregated and proven",
+							},
+							&cli.DurationFlag{
+								Name:  "poll-interval",
+								Usage: "How often to check the offer status with --wait",
+								Value: 30 * time.Second,
+							},
 						},
 						Action: client.OfferCarAction,
 					},
+					{
+						Name:      "offer-batch",
+						Usage:     "Offer many CARs at once from a CSV or JSON manifest of offer-car parameters",
+						ArgsUsage: "<manifest.csv|manifest.json>",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "config",
+								Usage: "Path to the configuration file",
+								Value: "./config/config.json",
+							},
+							&cli.StringFlag{
+								Name:     "chain",
+								Usage:    "Name of the source blockchain (e.g., ethereum, polygon)",
+								Required: true,
+							},
+							&cli.IntFlag{
+								Name:  "parallel",
+								Usage: "Number of offer transactions awaited at the same time",
+								Value: 8,
+							},
+							&cli.StringFlag{
+								Name:  "results",
+								Usage: "Where to write the tx hash and offer ID of every row (default <manifest>.results.<ext>)",
+							},
+							&cli.BoolFlag{
+								Name:  "base-units",
+								Usage: "Payment amounts are in the smallest unit of the token instead of whole tokens",
+							},
+						},
+						Action: client.OfferBatchAction,
+					},
+					{
+						Name:   "list-offers",
+						Usage:  "List the offers made by a client address",
+						Flags:  append(queryFlags(), &cli.StringFlag{Name: "address", Usage: "Client address to list offers of (default ClientAddr from the configuration)"}),
+						Action: client.ListOffersAction,
+					},
+					{
+						Name:   "pending",
+						Usage:  "List the offers that are not aggregated yet",
+						Flags:  queryFlags(),
+						Action: client.PendingOffersAction,
+					},
+					{
+						Name:      "offer-status",
+						Usage:     "Show an offer, its status and deal ID",
+						ArgsUsage: "<offer-id>",
+						Flags:     queryFlags(),
+						Action:    client.OfferStatusAction,
+					},
+					{
+						Name:      "aggregation",
+						Usage:     "Show an aggregation, its offers and deal ID",
+						ArgsUsage: "<aggregation-id>",
+						Flags:     queryFlags(),
+						Action:    client.AggregationAction,
+					},
+					{
+						Name:      "retrieve",
+						Usage:     "Fetch the CAR of an offer back from its buffer, the storage provider or an IPFS gateway",
+						ArgsUsage: "<offer-id>",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "config",
+								Usage: "Path to the configuration file",
+								Value: "./config/config.json",
+							},
+							&cli.StringFlag{
+								Name:     "chain",
+								Usage:    "Name of the source blockchain (e.g., ethereum, polygon)",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:    "output",
+								Aliases: []string{"o"},
+								Usage:   "Where to write the CAR (default <cid>.car)",
+							},
+							&cli.StringFlag{
+								Name:  "sources",
+								Usage: "Comma separated sources to try in order (buffer, provider, gateway)",
+								Value: strings.Join([]string{client.SourceBuffer, client.SourceProvider, client.SourceGateway}, ","),
+							},
+							&cli.StringFlag{
+								Name:  "provider-url",
+								Usage: "Boost HTTP retrieval url of the storage provider holding the deal",
+							},
+							&cli.StringFlag{
+								Name:  "gateway",
+								Usage: "Trustless IPFS gateway url, e.g. https://trustless-gateway.link",
+							},
+							&cli.StringFlag{
+								Name:  "extract",
+								Usage: "Also unpack the files of the CAR into this directory, decrypting encrypted files",
+							},
+							&cli.StringFlag{
+								Name:  "keyring",
+								Usage: "Path to the keyring holding the encryption keys",
+								Value: client.DefaultKeyringPath,
+							},
+						},
+						Action: client.RetrieveAction,
+					},
+				},
+			},
+			{
+				Name:  "verify-proof",
+				Usage: "Verify that a piece is included in an aggregate using its PoDSI inclusion proof",
+				Description: "The proof is given with --piece, --aggregate, --index, --path and optionally --entry-index and\n" +
+					"--entry-path, or read from the commitAggregate transaction given by --tx or found for --aggregation\n" +
+					"on the source chain.",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "config",
+						Usage: "Path to the configuration file",
+						Value: "./config/config.json",
+					},
+					&cli.StringFlag{
+						Name:  "chain",
+						Usage: "Name of the source blockchain to read proofs from (e.g., ethereum, polygon)",
+					},
+					&cli.StringFlag{
+						Name:  "tx",
+						Usage: "Hash of the commitAggregate transaction holding the proofs",
+					},
+					&cli.Uint64Flag{
+						Name:  "aggregation",
+						Usage: "Aggregation id to look up the commitAggregate transaction of",
+					},
+					&cli.Uint64Flag{
+						Name:  "from-block",
+						Usage: "First block to search for the AggregationCommitted event of --aggregation",
+					},
+					&cli.Uint64Flag{
+						Name:  "offer",
+						Usage: "Only verify the proof of this offer",
+					},
+					&cli.StringFlag{
+						Name:  "piece",
+						Usage: "CommP of the piece",
+					},
+					&cli.Uint64Flag{
+						Name:  "piece-size",
+						Usage: "Padded size of the piece, to check the aggregate size and print the piece offset",
+					},
+					&cli.StringFlag{
+						Name:  "aggregate",
+						Usage: "CommP of the aggregate",
+					},
+					&cli.Uint64Flag{
+						Name:  "aggregate-size",
+						Usage: "Padded size of the aggregate, checked against the proof depth",
+					},
+					&cli.Uint64Flag{
+						Name:  "index",
+						Usage: "Index of the piece's subtree within its level of the aggregate tree",
+					},
+					&cli.StringFlag{
+						Name:  "path",
+						Usage: "Comma separated hex encoded proof nodes, from the piece up to the root",
+					},
+					&cli.Uint64Flag{
+						Name:  "entry-index",
+						Usage: "Index of the piece's data segment index entry within its level of the aggregate tree",
+					},
+					&cli.StringFlag{
+						Name:  "entry-path",
+						Usage: "Comma separated hex encoded proof nodes, from the index entry up to the root",
+					},
+				},
+				Action: client.VerifyProofAction,
+			},
+			{
+				Name:  "track-attestations",
+				Usage: "Follow the attestations the Prover sends to the source chain through Axelar and report stuck or failed ones",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "config",
+						Usage: "Path to the configuration file",
+						Value: "./config/config.json",
+					},
+					&cli.StringFlag{
+						Name:     "chain",
+						Usage:    "Name of the source blockchain the attestations are sent to (e.g., ethereum, polygon)",
+						Required: true,
+					},
+					&cli.Uint64Flag{
+						Name:  "from-block",
+						Usage: "First Filecoin block to look for attestations in (default one day back)",
+					},
+					&cli.Uint64Flag{
+						Name:  "source-from-block",
+						Usage: "First source chain block to look for deliveries in (default 50000 blocks back)",
+					},
+					&cli.DurationFlag{
+						Name:  "stuck-after",
+						Usage: "Report attestations not proven this long after they were sent as stuck",
+						Value: 30 * time.Minute,
+					},
+					&cli.DurationFlag{
+						Name:  "watch",
+						Usage: "Keep tracking and report again at this interval",
+					},
+					&cli.BoolFlag{
+						Name:  "all",
+						Usage: "Report proven attestations as well",
+					},
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Print the report as JSON",
+					},
 				},
+				Action: deal.TrackAttestationsAction,
+			},
+			{
+				Name:      "timeline",
+				Usage:     "Show when offers were offered, aggregated, proposed in a deal, activated and proven",
+				ArgsUsage: "[offer-id...]",
+				Description: "Reads the timeline the aggregation service records for the chain, all offers are shown when\n" +
+					"no offer IDs are given. A running aggregator serves the same timeline at /timeline on its\n" +
+					"transfer address.",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "config",
+						Usage: "Path to the configuration file",
+						Value: "./config/config.json",
+					},
+					&cli.StringFlag{
+						Name:     "chain",
+						Usage:    "Name of the source blockchain (e.g., ethereum, polygon)",
+						Required: true,
+					},
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Print JSON instead of a table",
+					},
+				},
+				Action: aggregator.TimelineAction,
 			},
 			{
 				Name:  "generate-account",
@@ -154,7 +496,7 @@ func main() {
 					// Validate and create keystore
 					accountAddress, err := client.GenerateEthereumAccount(keystoreFile, password)
 					if err != nil {
-						log.Fatalf("Error generating account: %v", err)
+						return fmt.Errorf("error generating account: %w", err)
 					}
 
 					// Output generated account info
@@ -167,16 +509,48 @@ func main() {
 			},
 		},
 	}
-	signalChan := make(chan os.Signal, 1)
-	signal.Notify(signalChan, os.Interrupt)
+	// The first SIGINT or SIGTERM cancels the command context, services stop
+	// taking new work and get their grace period to finish what is in flight.
+	// A second signal exits right away.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	signalChan := make(chan os.Signal, 2)
+	signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
 	go func() {
+		sig := <-signalChan
+		slog.Info("Shutting down, signal again to exit now", "signal", sig)
+		cancel()
 		<-signalChan
-		fmt.Println("Ctrl-c received. Shutting down...")
-		os.Exit(0)
+		os.Exit(1)
 	}()
 
-	err := app.Run(os.Args)
+	err := app.RunContext(ctx, os.Args)
 	if err != nil {
-		log.Fatal(err)
+		slog.Error("Command failed", "err", err)
+		os.Exit(1)
+	}
+}
+
+// queryFlags are the flags of the client commands reading OnRamp state
+func queryFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "config",
+			Usage: "Path to the configuration file",
+			Value: "./config/config.json",
+		},
+		&cli.StringFlag{
+			Name:     "chain",
+			Usage:    "Name of the source blockchain (e.g., ethereum, polygon)",
+			Required: true,
+		},
+		&cli.BoolFlag{
+			Name:  "json",
+			Usage: "Print JSON instead of a table",
+		},
+		&cli.BoolFlag{
+			Name:  "base-units",
+			Usage: "Print payment amounts in the smallest unit of the token instead of whole tokens",
+		},
 	}
 }
@@ -3,9 +3,17 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"os"
+	"time"
+
+	"github.com/FIL-Builders/xchainClient/logging"
 )
 
+// DefaultShutdownGrace is how long in-flight work has to finish on shutdown
+// when ShutdownGrace is not set
+const DefaultShutdownGrace = 30 * time.Second
+
 // DestinationChainConfig represents the Filecoin destination.
 type DestinationChainConfig struct {
 	ChainID    int    `json:"ChainID"`
@@ -18,6 +26,18 @@ type SourceChainConfig struct {
 	ChainID       int    `json:"ChainID"`
 	Api           string `json:"Api"`
 	OnRampAddress string `json:"OnRampAddress"`
+	BufferURL     string `json:"BufferURL"`
+	OracleAddress string `json:"OracleAddress"`
+}
+
+// S3Config represents an S3 compatible bucket used as a client buffer.
+type S3Config struct {
+	Endpoint  string `json:"Endpoint"`
+	Region    string `json:"Region"`
+	Bucket    string `json:"Bucket"`
+	AccessKey string `json:"AccessKey"`
+	SecretKey string `json:"SecretKey"`
+	PublicURL string `json:"PublicURL"`
 }
 
 // Config holds all configuration parameters.
@@ -27,9 +47,11 @@ type Config struct {
 	KeyPath          string                       `json:"KeyPath"`
 	ClientAddr       string                       `json:"ClientAddr"`
 	PayoutAddr       string                       `json:"PayoutAddr"`
-	OnRampABIPath    string                       `json:"OnRampABIPath"`
 	BufferPath       string                       `json:"BufferPath"`
 	BufferPort       int                          `json:"BufferPort"`
+	BufferURL        string                       `json:"BufferURL"`
+	BufferPeers      []string                     `json:"BufferPeers"`
+	S3               S3Config                     `json:"S3"`
 	ProviderAddr     string                       `json:"ProviderAddr"`
 	LighthouseApiKey string                       `json:"LighthouseApiKey"`
 	LighthouseAuth   string                       `json:"LighthouseAuth"`
@@ -39,6 +61,22 @@ type Config struct {
 	MinDealSize      int                          `json:"MinDealSize"`
 	DealDelayEpochs  int                          `json:"DealDelayEpochs"`
 	DealDuration     int                          `json:"DealDuration"`
+	TimelineDir      string                       `json:"TimelineDir"`
+	StateDir         string                       `json:"StateDir"`
+	AdminIP          string                       `json:"AdminIP"`
+	AdminPort        int                          `json:"AdminPort"`
+	AdminToken       string                       `json:"AdminToken"`
+	MetricsPort      int                          `json:"MetricsPort"`
+	ShutdownGrace    int                          `json:"ShutdownGrace"`
+}
+
+// GracePeriod returns ShutdownGrace, in seconds, as a duration. It is the
+// default when not set.
+func (c *Config) GracePeriod() time.Duration {
+	if c.ShutdownGrace <= 0 {
+		return DefaultShutdownGrace
+	}
+	return time.Duration(c.ShutdownGrace) * time.Second
 }
 
 // LoadConfig reads the configuration from a JSON file.
@@ -63,3 +101,21 @@ func GetSourceConfig(cfg *Config, network string) (*SourceChainConfig, error) {
 	}
 	return nil, fmt.Errorf("source chain configuration for '%s' not found", network)
 }
+
+// plainConfig has the fields of Config without its LogValue method
+type plainConfig Config
+
+// LogValue logs the configuration with its secrets redacted
+func (c Config) LogValue() slog.Value {
+	redact := func(s *string) {
+		if *s != "" {
+			*s = logging.Redacted
+		}
+	}
+	redact(&c.LighthouseApiKey)
+	redact(&c.LighthouseAuth)
+	redact(&c.AdminToken)
+	redact(&c.S3.AccessKey)
+	redact(&c.S3.SecretKey)
+	return slog.AnyValue(plainConfig(c))
+}
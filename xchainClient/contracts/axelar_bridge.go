@@ -0,0 +1,494 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// AxelarBridgeMetaData contains all meta data concerning the AxelarBridge contract.
+var AxelarBridgeMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_gateway\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[],\"name\":\"InvalidAddress\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"NotApprovedByGateway\",\"type\":\"error\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"commandId\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"sourceChain\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"sourceAddress\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"commP\",\"type\":\"bytes\"}],\"name\":\"ReceivedAttestation\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"commandId\",\"type\":\"bytes32\"},{\"internalType\":\"string\",\"name\":\"sourceChain\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"sourceAddress\",\"type\":\"string\"},{\"internalType\":\"bytes\",\"name\":\"payload\",\"type\":\"bytes\"}],\"name\":\"execute\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"name\":\"executedCommands\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"gateway\",\"outputs\":[{\"internalType\":\"contractIAxelarGateway\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"receiver\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"sender\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"sender_\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"receiver_\",\"type\":\"address\"}],\"name\":\"setSenderReceiver\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+}
+
+// AxelarBridgeABI is the input ABI used to generate the binding from.
+// Deprecated: Use AxelarBridgeMetaData.ABI instead.
+var AxelarBridgeABI = AxelarBridgeMetaData.ABI
+
+// AxelarBridge is an auto generated Go binding around an Ethereum contract.
+type AxelarBridge struct {
+	AxelarBridgeCaller     // Read-only binding to the contract
+	AxelarBridgeTransactor // Write-only binding to the contract
+	AxelarBridgeFilterer   // Log filterer for contract events
+}
+
+// AxelarBridgeCaller is an auto generated read-only Go binding around an Ethereum contract.
+type AxelarBridgeCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// AxelarBridgeTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type AxelarBridgeTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// AxelarBridgeFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type AxelarBridgeFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// AxelarBridgeSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type AxelarBridgeSession struct {
+	Contract     *AxelarBridge     // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// AxelarBridgeCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type AxelarBridgeCallerSession struct {
+	Contract *AxelarBridgeCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts       // Call options to use throughout this session
+}
+
+// AxelarBridgeTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type AxelarBridgeTransactorSession struct {
+	Contract     *AxelarBridgeTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts       // Transaction auth options to use throughout this session
+}
+
+// AxelarBridgeRaw is an auto generated low-level Go binding around an Ethereum contract.
+type AxelarBridgeRaw struct {
+	Contract *AxelarBridge // Generic contract binding to access the raw methods on
+}
+
+// AxelarBridgeCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type AxelarBridgeCallerRaw struct {
+	Contract *AxelarBridgeCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// AxelarBridgeTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type AxelarBridgeTransactorRaw struct {
+	Contract *AxelarBridgeTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewAxelarBridge creates a new instance of AxelarBridge, bound to a specific deployed contract.
+func NewAxelarBridge(address common.Address, backend bind.ContractBackend) (*AxelarBridge, error) {
+	contract, err := bindAxelarBridge(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &AxelarBridge{AxelarBridgeCaller: AxelarBridgeCaller{contract: contract}, AxelarBridgeTransactor: AxelarBridgeTransactor{contract: contract}, AxelarBridgeFilterer: AxelarBridgeFilterer{contract: contract}}, nil
+}
+
+// NewAxelarBridgeCaller creates a new read-only instance of AxelarBridge, bound to a specific deployed contract.
+func NewAxelarBridgeCaller(address common.Address, caller bind.ContractCaller) (*AxelarBridgeCaller, error) {
+	contract, err := bindAxelarBridge(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &AxelarBridgeCaller{contract: contract}, nil
+}
+
+// NewAxelarBridgeTransactor creates a new write-only instance of AxelarBridge, bound to a specific deployed contract.
+func NewAxelarBridgeTransactor(address common.Address, transactor bind.ContractTransactor) (*AxelarBridgeTransactor, error) {
+	contract, err := bindAxelarBridge(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &AxelarBridgeTransactor{contract: contract}, nil
+}
+
+// NewAxelarBridgeFilterer creates a new log filterer instance of AxelarBridge, bound to a specific deployed contract.
+func NewAxelarBridgeFilterer(address common.Address, filterer bind.ContractFilterer) (*AxelarBridgeFilterer, error) {
+	contract, err := bindAxelarBridge(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &AxelarBridgeFilterer{contract: contract}, nil
+}
+
+// bindAxelarBridge binds a generic wrapper to an already deployed contract.
+func bindAxelarBridge(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := AxelarBridgeMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_AxelarBridge *AxelarBridgeRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _AxelarBridge.Contract.AxelarBridgeCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_AxelarBridge *AxelarBridgeRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _AxelarBridge.Contract.AxelarBridgeTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_AxelarBridge *AxelarBridgeRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _AxelarBridge.Contract.AxelarBridgeTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_AxelarBridge *AxelarBridgeCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _AxelarBridge.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_AxelarBridge *AxelarBridgeTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _AxelarBridge.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_AxelarBridge *AxelarBridgeTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _AxelarBridge.Contract.contract.Transact(opts, method, params...)
+}
+
+// ExecutedCommands is a free data retrieval call binding the contract method 0xd16ff394.
+//
+// Solidity: function executedCommands(bytes32 ) view returns(bool)
+func (_AxelarBridge *AxelarBridgeCaller) ExecutedCommands(opts *bind.CallOpts, arg0 [32]byte) (bool, error) {
+	var out []interface{}
+	err := _AxelarBridge.contract.Call(opts, &out, "executedCommands", arg0)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// ExecutedCommands is a free data retrieval call binding the contract method 0xd16ff394.
+//
+// Solidity: function executedCommands(bytes32 ) view returns(bool)
+func (_AxelarBridge *AxelarBridgeSession) ExecutedCommands(arg0 [32]byte) (bool, error) {
+	return _AxelarBridge.Contract.ExecutedCommands(&_AxelarBridge.CallOpts, arg0)
+}
+
+// ExecutedCommands is a free data retrieval call binding the contract method 0xd16ff394.
+//
+// Solidity: function executedCommands(bytes32 ) view returns(bool)
+func (_AxelarBridge *AxelarBridgeCallerSession) ExecutedCommands(arg0 [32]byte) (bool, error) {
+	return _AxelarBridge.Contract.ExecutedCommands(&_AxelarBridge.CallOpts, arg0)
+}
+
+// Gateway is a free data retrieval call binding the contract method 0x116191b6.
+//
+// Solidity: function gateway() view returns(address)
+func (_AxelarBridge *AxelarBridgeCaller) Gateway(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _AxelarBridge.contract.Call(opts, &out, "gateway")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Gateway is a free data retrieval call binding the contract method 0x116191b6.
+//
+// Solidity: function gateway() view returns(address)
+func (_AxelarBridge *AxelarBridgeSession) Gateway() (common.Address, error) {
+	return _AxelarBridge.Contract.Gateway(&_AxelarBridge.CallOpts)
+}
+
+// Gateway is a free data retrieval call binding the contract method 0x116191b6.
+//
+// Solidity: function gateway() view returns(address)
+func (_AxelarBridge *AxelarBridgeCallerSession) Gateway() (common.Address, error) {
+	return _AxelarBridge.Contract.Gateway(&_AxelarBridge.CallOpts)
+}
+
+// Receiver is a free data retrieval call binding the contract method 0xf7260d3e.
+//
+// Solidity: function receiver() view returns(address)
+func (_AxelarBridge *AxelarBridgeCaller) Receiver(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _AxelarBridge.contract.Call(opts, &out, "receiver")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Receiver is a free data retrieval call binding the contract method 0xf7260d3e.
+//
+// Solidity: function receiver() view returns(address)
+func (_AxelarBridge *AxelarBridgeSession) Receiver() (common.Address, error) {
+	return _AxelarBridge.Contract.Receiver(&_AxelarBridge.CallOpts)
+}
+
+// Receiver is a free data retrieval call binding the contract method 0xf7260d3e.
+//
+// Solidity: function receiver() view returns(address)
+func (_AxelarBridge *AxelarBridgeCallerSession) Receiver() (common.Address, error) {
+	return _AxelarBridge.Contract.Receiver(&_AxelarBridge.CallOpts)
+}
+
+// Sender is a free data retrieval call binding the contract method 0x67e404ce.
+//
+// Solidity: function sender() view returns(address)
+func (_AxelarBridge *AxelarBridgeCaller) Sender(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _AxelarBridge.contract.Call(opts, &out, "sender")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Sender is a free data retrieval call binding the contract method 0x67e404ce.
+//
+// Solidity: function sender() view returns(address)
+func (_AxelarBridge *AxelarBridgeSession) Sender() (common.Address, error) {
+	return _AxelarBridge.Contract.Sender(&_AxelarBridge.CallOpts)
+}
+
+// Sender is a free data retrieval call binding the contract method 0x67e404ce.
+//
+// Solidity: function sender() view returns(address)
+func (_AxelarBridge *AxelarBridgeCallerSession) Sender() (common.Address, error) {
+	return _AxelarBridge.Contract.Sender(&_AxelarBridge.CallOpts)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0x49160658.
+//
+// Solidity: function execute(bytes32 commandId, string sourceChain, string sourceAddress, bytes payload) returns()
+func (_AxelarBridge *AxelarBridgeTransactor) Execute(opts *bind.TransactOpts, commandId [32]byte, sourceChain string, sourceAddress string, payload []byte) (*types.Transaction, error) {
+	return _AxelarBridge.contract.Transact(opts, "execute", commandId, sourceChain, sourceAddress, payload)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0x49160658.
+//
+// Solidity: function execute(bytes32 commandId, string sourceChain, string sourceAddress, bytes payload) returns()
+func (_AxelarBridge *AxelarBridgeSession) Execute(commandId [32]byte, sourceChain string, sourceAddress string, payload []byte) (*types.Transaction, error) {
+	return _AxelarBridge.Contract.Execute(&_AxelarBridge.TransactOpts, commandId, sourceChain, sourceAddress, payload)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0x49160658.
+//
+// Solidity: function execute(bytes32 commandId, string sourceChain, string sourceAddress, bytes payload) returns()
+func (_AxelarBridge *AxelarBridgeTransactorSession) Execute(commandId [32]byte, sourceChain string, sourceAddress string, payload []byte) (*types.Transaction, error) {
+	return _AxelarBridge.Contract.Execute(&_AxelarBridge.TransactOpts, commandId, sourceChain, sourceAddress, payload)
+}
+
+// SetSenderReceiver is a paid mutator transaction binding the contract method 0x25255fc5.
+//
+// Solidity: function setSenderReceiver(address sender_, address receiver_) returns()
+func (_AxelarBridge *AxelarBridgeTransactor) SetSenderReceiver(opts *bind.TransactOpts, sender_ common.Address, receiver_ common.Address) (*types.Transaction, error) {
+	return _AxelarBridge.contract.Transact(opts, "setSenderReceiver", sender_, receiver_)
+}
+
+// SetSenderReceiver is a paid mutator transaction binding the contract method 0x25255fc5.
+//
+// Solidity: function setSenderReceiver(address sender_, address receiver_) returns()
+func (_AxelarBridge *AxelarBridgeSession) SetSenderReceiver(sender_ common.Address, receiver_ common.Address) (*types.Transaction, error) {
+	return _AxelarBridge.Contract.SetSenderReceiver(&_AxelarBridge.TransactOpts, sender_, receiver_)
+}
+
+// SetSenderReceiver is a paid mutator transaction binding the contract method 0x25255fc5.
+//
+// Solidity: function setSenderReceiver(address sender_, address receiver_) returns()
+func (_AxelarBridge *AxelarBridgeTransactorSession) SetSenderReceiver(sender_ common.Address, receiver_ common.Address) (*types.Transaction, error) {
+	return _AxelarBridge.Contract.SetSenderReceiver(&_AxelarBridge.TransactOpts, sender_, receiver_)
+}
+
+// AxelarBridgeReceivedAttestationIterator is returned from FilterReceivedAttestation and is used to iterate over the raw logs and unpacked data for ReceivedAttestation events raised by the AxelarBridge contract.
+type AxelarBridgeReceivedAttestationIterator struct {
+	Event *AxelarBridgeReceivedAttestation // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AxelarBridgeReceivedAttestationIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AxelarBridgeReceivedAttestation)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AxelarBridgeReceivedAttestation)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AxelarBridgeReceivedAttestationIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AxelarBridgeReceivedAttestationIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AxelarBridgeReceivedAttestation represents a ReceivedAttestation event raised by the AxelarBridge contract.
+type AxelarBridgeReceivedAttestation struct {
+	CommandId     [32]byte
+	SourceChain   string
+	SourceAddress string
+	CommP         []byte
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterReceivedAttestation is a free log retrieval operation binding the contract event 0xbc3fd4b7090f8242bc0a6c6bb6f430194ba2e340b45cc63832cfd23f3effcde8.
+//
+// Solidity: event ReceivedAttestation(bytes32 indexed commandId, string sourceChain, string sourceAddress, bytes commP)
+func (_AxelarBridge *AxelarBridgeFilterer) FilterReceivedAttestation(opts *bind.FilterOpts, commandId [][32]byte) (*AxelarBridgeReceivedAttestationIterator, error) {
+
+	var commandIdRule []interface{}
+	for _, commandIdItem := range commandId {
+		commandIdRule = append(commandIdRule, commandIdItem)
+	}
+
+	logs, sub, err := _AxelarBridge.contract.FilterLogs(opts, "ReceivedAttestation", commandIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &AxelarBridgeReceivedAttestationIterator{contract: _AxelarBridge.contract, event: "ReceivedAttestation", logs: logs, sub: sub}, nil
+}
+
+// WatchReceivedAttestation is a free log subscription operation binding the contract event 0xbc3fd4b7090f8242bc0a6c6bb6f430194ba2e340b45cc63832cfd23f3effcde8.
+//
+// Solidity: event ReceivedAttestation(bytes32 indexed commandId, string sourceChain, string sourceAddress, bytes commP)
+func (_AxelarBridge *AxelarBridgeFilterer) WatchReceivedAttestation(opts *bind.WatchOpts, sink chan<- *AxelarBridgeReceivedAttestation, commandId [][32]byte) (event.Subscription, error) {
+
+	var commandIdRule []interface{}
+	for _, commandIdItem := range commandId {
+		commandIdRule = append(commandIdRule, commandIdItem)
+	}
+
+	logs, sub, err := _AxelarBridge.contract.WatchLogs(opts, "ReceivedAttestation", commandIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AxelarBridgeReceivedAttestation)
+				if err := _AxelarBridge.contract.UnpackLog(event, "ReceivedAttestation", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseReceivedAttestation is a log parse operation binding the contract event 0xbc3fd4b7090f8242bc0a6c6bb6f430194ba2e340b45cc63832cfd23f3effcde8.
+//
+// Solidity: event ReceivedAttestation(bytes32 indexed commandId, string sourceChain, string sourceAddress, bytes commP)
+func (_AxelarBridge *AxelarBridgeFilterer) ParseReceivedAttestation(log types.Log) (*AxelarBridgeReceivedAttestation, error) {
+	event := new(AxelarBridgeReceivedAttestation)
+	if err := _AxelarBridge.contract.UnpackLog(event, "ReceivedAttestation", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,399 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// AxelarBridgeDebugMetaData contains all meta data concerning the AxelarBridgeDebug contract.
+var AxelarBridgeDebugMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_gateway\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[],\"name\":\"InvalidAddress\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"NotApprovedByGateway\",\"type\":\"error\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"commP\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"sourceAddress\",\"type\":\"string\"}],\"name\":\"ReceivedAttestation\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"commandId\",\"type\":\"bytes32\"},{\"internalType\":\"string\",\"name\":\"sourceChain\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"sourceAddress\",\"type\":\"string\"},{\"internalType\":\"bytes\",\"name\":\"payload\",\"type\":\"bytes\"}],\"name\":\"execute\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"name\":\"executedCommands\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"gateway\",\"outputs\":[{\"internalType\":\"contractIAxelarGateway\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+}
+
+// AxelarBridgeDebugABI is the input ABI used to generate the binding from.
+// Deprecated: Use AxelarBridgeDebugMetaData.ABI instead.
+var AxelarBridgeDebugABI = AxelarBridgeDebugMetaData.ABI
+
+// AxelarBridgeDebug is an auto generated Go binding around an Ethereum contract.
+type AxelarBridgeDebug struct {
+	AxelarBridgeDebugCaller     // Read-only binding to the contract
+	AxelarBridgeDebugTransactor // Write-only binding to the contract
+	AxelarBridgeDebugFilterer   // Log filterer for contract events
+}
+
+// AxelarBridgeDebugCaller is an auto generated read-only Go binding around an Ethereum contract.
+type AxelarBridgeDebugCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// AxelarBridgeDebugTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type AxelarBridgeDebugTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// AxelarBridgeDebugFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type AxelarBridgeDebugFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// AxelarBridgeDebugSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type AxelarBridgeDebugSession struct {
+	Contract     *AxelarBridgeDebug // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts      // Call options to use throughout this session
+	TransactOpts bind.TransactOpts  // Transaction auth options to use throughout this session
+}
+
+// AxelarBridgeDebugCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type AxelarBridgeDebugCallerSession struct {
+	Contract *AxelarBridgeDebugCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts            // Call options to use throughout this session
+}
+
+// AxelarBridgeDebugTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type AxelarBridgeDebugTransactorSession struct {
+	Contract     *AxelarBridgeDebugTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts            // Transaction auth options to use throughout this session
+}
+
+// AxelarBridgeDebugRaw is an auto generated low-level Go binding around an Ethereum contract.
+type AxelarBridgeDebugRaw struct {
+	Contract *AxelarBridgeDebug // Generic contract binding to access the raw methods on
+}
+
+// AxelarBridgeDebugCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type AxelarBridgeDebugCallerRaw struct {
+	Contract *AxelarBridgeDebugCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// AxelarBridgeDebugTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type AxelarBridgeDebugTransactorRaw struct {
+	Contract *AxelarBridgeDebugTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewAxelarBridgeDebug creates a new instance of AxelarBridgeDebug, bound to a specific deployed contract.
+func NewAxelarBridgeDebug(address common.Address, backend bind.ContractBackend) (*AxelarBridgeDebug, error) {
+	contract, err := bindAxelarBridgeDebug(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &AxelarBridgeDebug{AxelarBridgeDebugCaller: AxelarBridgeDebugCaller{contract: contract}, AxelarBridgeDebugTransactor: AxelarBridgeDebugTransactor{contract: contract}, AxelarBridgeDebugFilterer: AxelarBridgeDebugFilterer{contract: contract}}, nil
+}
+
+// NewAxelarBridgeDebugCaller creates a new read-only instance of AxelarBridgeDebug, bound to a specific deployed contract.
+func NewAxelarBridgeDebugCaller(address common.Address, caller bind.ContractCaller) (*AxelarBridgeDebugCaller, error) {
+	contract, err := bindAxelarBridgeDebug(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &AxelarBridgeDebugCaller{contract: contract}, nil
+}
+
+// NewAxelarBridgeDebugTransactor creates a new write-only instance of AxelarBridgeDebug, bound to a specific deployed contract.
+func NewAxelarBridgeDebugTransactor(address common.Address, transactor bind.ContractTransactor) (*AxelarBridgeDebugTransactor, error) {
+	contract, err := bindAxelarBridgeDebug(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &AxelarBridgeDebugTransactor{contract: contract}, nil
+}
+
+// NewAxelarBridgeDebugFilterer creates a new log filterer instance of AxelarBridgeDebug, bound to a specific deployed contract.
+func NewAxelarBridgeDebugFilterer(address common.Address, filterer bind.ContractFilterer) (*AxelarBridgeDebugFilterer, error) {
+	contract, err := bindAxelarBridgeDebug(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &AxelarBridgeDebugFilterer{contract: contract}, nil
+}
+
+// bindAxelarBridgeDebug binds a generic wrapper to an already deployed contract.
+func bindAxelarBridgeDebug(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := AxelarBridgeDebugMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_AxelarBridgeDebug *AxelarBridgeDebugRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _AxelarBridgeDebug.Contract.AxelarBridgeDebugCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_AxelarBridgeDebug *AxelarBridgeDebugRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _AxelarBridgeDebug.Contract.AxelarBridgeDebugTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_AxelarBridgeDebug *AxelarBridgeDebugRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _AxelarBridgeDebug.Contract.AxelarBridgeDebugTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_AxelarBridgeDebug *AxelarBridgeDebugCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _AxelarBridgeDebug.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_AxelarBridgeDebug *AxelarBridgeDebugTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _AxelarBridgeDebug.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_AxelarBridgeDebug *AxelarBridgeDebugTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _AxelarBridgeDebug.Contract.contract.Transact(opts, method, params...)
+}
+
+// ExecutedCommands is a free data retrieval call binding the contract method 0xd16ff394.
+//
+// Solidity: function executedCommands(bytes32 ) view returns(bool)
+func (_AxelarBridgeDebug *AxelarBridgeDebugCaller) ExecutedCommands(opts *bind.CallOpts, arg0 [32]byte) (bool, error) {
+	var out []interface{}
+	err := _AxelarBridgeDebug.contract.Call(opts, &out, "executedCommands", arg0)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// ExecutedCommands is a free data retrieval call binding the contract method 0xd16ff394.
+//
+// Solidity: function executedCommands(bytes32 ) view returns(bool)
+func (_AxelarBridgeDebug *AxelarBridgeDebugSession) ExecutedCommands(arg0 [32]byte) (bool, error) {
+	return _AxelarBridgeDebug.Contract.ExecutedCommands(&_AxelarBridgeDebug.CallOpts, arg0)
+}
+
+// ExecutedCommands is a free data retrieval call binding the contract method 0xd16ff394.
+//
+// Solidity: function executedCommands(bytes32 ) view returns(bool)
+func (_AxelarBridgeDebug *AxelarBridgeDebugCallerSession) ExecutedCommands(arg0 [32]byte) (bool, error) {
+	return _AxelarBridgeDebug.Contract.ExecutedCommands(&_AxelarBridgeDebug.CallOpts, arg0)
+}
+
+// Gateway is a free data retrieval call binding the contract method 0x116191b6.
+//
+// Solidity: function gateway() view returns(address)
+func (_AxelarBridgeDebug *AxelarBridgeDebugCaller) Gateway(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _AxelarBridgeDebug.contract.Call(opts, &out, "gateway")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Gateway is a free data retrieval call binding the contract method 0x116191b6.
+//
+// Solidity: function gateway() view returns(address)
+func (_AxelarBridgeDebug *AxelarBridgeDebugSession) Gateway() (common.Address, error) {
+	return _AxelarBridgeDebug.Contract.Gateway(&_AxelarBridgeDebug.CallOpts)
+}
+
+// Gateway is a free data retrieval call binding the contract method 0x116191b6.
+//
+// Solidity: function gateway() view returns(address)
+func (_AxelarBridgeDebug *AxelarBridgeDebugCallerSession) Gateway() (common.Address, error) {
+	return _AxelarBridgeDebug.Contract.Gateway(&_AxelarBridgeDebug.CallOpts)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0x49160658.
+//
+// Solidity: function execute(bytes32 commandId, string sourceChain, string sourceAddress, bytes payload) returns()
+func (_AxelarBridgeDebug *AxelarBridgeDebugTransactor) Execute(opts *bind.TransactOpts, commandId [32]byte, sourceChain string, sourceAddress string, payload []byte) (*types.Transaction, error) {
+	return _AxelarBridgeDebug.contract.Transact(opts, "execute", commandId, sourceChain, sourceAddress, payload)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0x49160658.
+//
+// Solidity: function execute(bytes32 commandId, string sourceChain, string sourceAddress, bytes payload) returns()
+func (_AxelarBridgeDebug *AxelarBridgeDebugSession) Execute(commandId [32]byte, sourceChain string, sourceAddress string, payload []byte) (*types.Transaction, error) {
+	return _AxelarBridgeDebug.Contract.Execute(&_AxelarBridgeDebug.TransactOpts, commandId, sourceChain, sourceAddress, payload)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0x49160658.
+//
+// Solidity: function execute(bytes32 commandId, string sourceChain, string sourceAddress, bytes payload) returns()
+func (_AxelarBridgeDebug *AxelarBridgeDebugTransactorSession) Execute(commandId [32]byte, sourceChain string, sourceAddress string, payload []byte) (*types.Transaction, error) {
+	return _AxelarBridgeDebug.Contract.Execute(&_AxelarBridgeDebug.TransactOpts, commandId, sourceChain, sourceAddress, payload)
+}
+
+// AxelarBridgeDebugReceivedAttestationIterator is returned from FilterReceivedAttestation and is used to iterate over the raw logs and unpacked data for ReceivedAttestation events raised by the AxelarBridgeDebug contract.
+type AxelarBridgeDebugReceivedAttestationIterator struct {
+	Event *AxelarBridgeDebugReceivedAttestation // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AxelarBridgeDebugReceivedAttestationIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AxelarBridgeDebugReceivedAttestation)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AxelarBridgeDebugReceivedAttestation)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AxelarBridgeDebugReceivedAttestationIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AxelarBridgeDebugReceivedAttestationIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AxelarBridgeDebugReceivedAttestation represents a ReceivedAttestation event raised by the AxelarBridgeDebug contract.
+type AxelarBridgeDebugReceivedAttestation struct {
+	CommP         []byte
+	SourceAddress string
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterReceivedAttestation is a free log retrieval operation binding the contract event 0x028a5319479b447a7d071b2c89255c4e3eae10e6b7fde8a68d68da844e616629.
+//
+// Solidity: event ReceivedAttestation(bytes commP, string sourceAddress)
+func (_AxelarBridgeDebug *AxelarBridgeDebugFilterer) FilterReceivedAttestation(opts *bind.FilterOpts) (*AxelarBridgeDebugReceivedAttestationIterator, error) {
+
+	logs, sub, err := _AxelarBridgeDebug.contract.FilterLogs(opts, "ReceivedAttestation")
+	if err != nil {
+		return nil, err
+	}
+	return &AxelarBridgeDebugReceivedAttestationIterator{contract: _AxelarBridgeDebug.contract, event: "ReceivedAttestation", logs: logs, sub: sub}, nil
+}
+
+// WatchReceivedAttestation is a free log subscription operation binding the contract event 0x028a5319479b447a7d071b2c89255c4e3eae10e6b7fde8a68d68da844e616629.
+//
+// Solidity: event ReceivedAttestation(bytes commP, string sourceAddress)
+func (_AxelarBridgeDebug *AxelarBridgeDebugFilterer) WatchReceivedAttestation(opts *bind.WatchOpts, sink chan<- *AxelarBridgeDebugReceivedAttestation) (event.Subscription, error) {
+
+	logs, sub, err := _AxelarBridgeDebug.contract.WatchLogs(opts, "ReceivedAttestation")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AxelarBridgeDebugReceivedAttestation)
+				if err := _AxelarBridgeDebug.contract.UnpackLog(event, "ReceivedAttestation", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseReceivedAttestation is a log parse operation binding the contract event 0x028a5319479b447a7d071b2c89255c4e3eae10e6b7fde8a68d68da844e616629.
+//
+// Solidity: event ReceivedAttestation(bytes commP, string sourceAddress)
+func (_AxelarBridgeDebug *AxelarBridgeDebugFilterer) ParseReceivedAttestation(log types.Log) (*AxelarBridgeDebugReceivedAttestation, error) {
+	event := new(AxelarBridgeDebugReceivedAttestation)
+	if err := _AxelarBridgeDebug.contract.UnpackLog(event, "ReceivedAttestation", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
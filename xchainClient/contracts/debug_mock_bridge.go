@@ -0,0 +1,337 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// DebugMockBridgeMetaData contains all meta data concerning the DebugMockBridge contract.
+var DebugMockBridgeMetaData = &bind.MetaData{
+	ABI: "[{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"commP\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"sourceAddress\",\"type\":\"string\"}],\"name\":\"ReceivedAttestation\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"_sourceChain_\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"sourceAddress_\",\"type\":\"string\"},{\"internalType\":\"bytes\",\"name\":\"payload_\",\"type\":\"bytes\"}],\"name\":\"_execute\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+}
+
+// DebugMockBridgeABI is the input ABI used to generate the binding from.
+// Deprecated: Use DebugMockBridgeMetaData.ABI instead.
+var DebugMockBridgeABI = DebugMockBridgeMetaData.ABI
+
+// DebugMockBridge is an auto generated Go binding around an Ethereum contract.
+type DebugMockBridge struct {
+	DebugMockBridgeCaller     // Read-only binding to the contract
+	DebugMockBridgeTransactor // Write-only binding to the contract
+	DebugMockBridgeFilterer   // Log filterer for contract events
+}
+
+// DebugMockBridgeCaller is an auto generated read-only Go binding around an Ethereum contract.
+type DebugMockBridgeCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// DebugMockBridgeTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type DebugMockBridgeTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// DebugMockBridgeFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type DebugMockBridgeFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// DebugMockBridgeSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type DebugMockBridgeSession struct {
+	Contract     *DebugMockBridge  // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// DebugMockBridgeCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type DebugMockBridgeCallerSession struct {
+	Contract *DebugMockBridgeCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts          // Call options to use throughout this session
+}
+
+// DebugMockBridgeTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type DebugMockBridgeTransactorSession struct {
+	Contract     *DebugMockBridgeTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts          // Transaction auth options to use throughout this session
+}
+
+// DebugMockBridgeRaw is an auto generated low-level Go binding around an Ethereum contract.
+type DebugMockBridgeRaw struct {
+	Contract *DebugMockBridge // Generic contract binding to access the raw methods on
+}
+
+// DebugMockBridgeCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type DebugMockBridgeCallerRaw struct {
+	Contract *DebugMockBridgeCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// DebugMockBridgeTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type DebugMockBridgeTransactorRaw struct {
+	Contract *DebugMockBridgeTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewDebugMockBridge creates a new instance of DebugMockBridge, bound to a specific deployed contract.
+func NewDebugMockBridge(address common.Address, backend bind.ContractBackend) (*DebugMockBridge, error) {
+	contract, err := bindDebugMockBridge(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &DebugMockBridge{DebugMockBridgeCaller: DebugMockBridgeCaller{contract: contract}, DebugMockBridgeTransactor: DebugMockBridgeTransactor{contract: contract}, DebugMockBridgeFilterer: DebugMockBridgeFilterer{contract: contract}}, nil
+}
+
+// NewDebugMockBridgeCaller creates a new read-only instance of DebugMockBridge, bound to a specific deployed contract.
+func NewDebugMockBridgeCaller(address common.Address, caller bind.ContractCaller) (*DebugMockBridgeCaller, error) {
+	contract, err := bindDebugMockBridge(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &DebugMockBridgeCaller{contract: contract}, nil
+}
+
+// NewDebugMockBridgeTransactor creates a new write-only instance of DebugMockBridge, bound to a specific deployed contract.
+func NewDebugMockBridgeTransactor(address common.Address, transactor bind.ContractTransactor) (*DebugMockBridgeTransactor, error) {
+	contract, err := bindDebugMockBridge(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &DebugMockBridgeTransactor{contract: contract}, nil
+}
+
+// NewDebugMockBridgeFilterer creates a new log filterer instance of DebugMockBridge, bound to a specific deployed contract.
+func NewDebugMockBridgeFilterer(address common.Address, filterer bind.ContractFilterer) (*DebugMockBridgeFilterer, error) {
+	contract, err := bindDebugMockBridge(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &DebugMockBridgeFilterer{contract: contract}, nil
+}
+
+// bindDebugMockBridge binds a generic wrapper to an already deployed contract.
+func bindDebugMockBridge(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := DebugMockBridgeMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_DebugMockBridge *DebugMockBridgeRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _DebugMockBridge.Contract.DebugMockBridgeCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_DebugMockBridge *DebugMockBridgeRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _DebugMockBridge.Contract.DebugMockBridgeTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_DebugMockBridge *DebugMockBridgeRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _DebugMockBridge.Contract.DebugMockBridgeTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_DebugMockBridge *DebugMockBridgeCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _DebugMockBridge.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_DebugMockBridge *DebugMockBridgeTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _DebugMockBridge.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_DebugMockBridge *DebugMockBridgeTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _DebugMockBridge.Contract.contract.Transact(opts, method, params...)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0xa69399ed.
+//
+// Solidity: function _execute(string _sourceChain_, string sourceAddress_, bytes payload_) returns()
+func (_DebugMockBridge *DebugMockBridgeTransactor) Execute(opts *bind.TransactOpts, _sourceChain_ string, sourceAddress_ string, payload_ []byte) (*types.Transaction, error) {
+	return _DebugMockBridge.contract.Transact(opts, "_execute", _sourceChain_, sourceAddress_, payload_)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0xa69399ed.
+//
+// Solidity: function _execute(string _sourceChain_, string sourceAddress_, bytes payload_) returns()
+func (_DebugMockBridge *DebugMockBridgeSession) Execute(_sourceChain_ string, sourceAddress_ string, payload_ []byte) (*types.Transaction, error) {
+	return _DebugMockBridge.Contract.Execute(&_DebugMockBridge.TransactOpts, _sourceChain_, sourceAddress_, payload_)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0xa69399ed.
+//
+// Solidity: function _execute(string _sourceChain_, string sourceAddress_, bytes payload_) returns()
+func (_DebugMockBridge *DebugMockBridgeTransactorSession) Execute(_sourceChain_ string, sourceAddress_ string, payload_ []byte) (*types.Transaction, error) {
+	return _DebugMockBridge.Contract.Execute(&_DebugMockBridge.TransactOpts, _sourceChain_, sourceAddress_, payload_)
+}
+
+// DebugMockBridgeReceivedAttestationIterator is returned from FilterReceivedAttestation and is used to iterate over the raw logs and unpacked data for ReceivedAttestation events raised by the DebugMockBridge contract.
+type DebugMockBridgeReceivedAttestationIterator struct {
+	Event *DebugMockBridgeReceivedAttestation // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *DebugMockBridgeReceivedAttestationIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(DebugMockBridgeReceivedAttestation)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(DebugMockBridgeReceivedAttestation)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *DebugMockBridgeReceivedAttestationIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *DebugMockBridgeReceivedAttestationIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// DebugMockBridgeReceivedAttestation represents a ReceivedAttestation event raised by the DebugMockBridge contract.
+type DebugMockBridgeReceivedAttestation struct {
+	CommP         []byte
+	SourceAddress string
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterReceivedAttestation is a free log retrieval operation binding the contract event 0x028a5319479b447a7d071b2c89255c4e3eae10e6b7fde8a68d68da844e616629.
+//
+// Solidity: event ReceivedAttestation(bytes commP, string sourceAddress)
+func (_DebugMockBridge *DebugMockBridgeFilterer) FilterReceivedAttestation(opts *bind.FilterOpts) (*DebugMockBridgeReceivedAttestationIterator, error) {
+
+	logs, sub, err := _DebugMockBridge.contract.FilterLogs(opts, "ReceivedAttestation")
+	if err != nil {
+		return nil, err
+	}
+	return &DebugMockBridgeReceivedAttestationIterator{contract: _DebugMockBridge.contract, event: "ReceivedAttestation", logs: logs, sub: sub}, nil
+}
+
+// WatchReceivedAttestation is a free log subscription operation binding the contract event 0x028a5319479b447a7d071b2c89255c4e3eae10e6b7fde8a68d68da844e616629.
+//
+// Solidity: event ReceivedAttestation(bytes commP, string sourceAddress)
+func (_DebugMockBridge *DebugMockBridgeFilterer) WatchReceivedAttestation(opts *bind.WatchOpts, sink chan<- *DebugMockBridgeReceivedAttestation) (event.Subscription, error) {
+
+	logs, sub, err := _DebugMockBridge.contract.WatchLogs(opts, "ReceivedAttestation")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(DebugMockBridgeReceivedAttestation)
+				if err := _DebugMockBridge.contract.UnpackLog(event, "ReceivedAttestation", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseReceivedAttestation is a log parse operation binding the contract event 0x028a5319479b447a7d071b2c89255c4e3eae10e6b7fde8a68d68da844e616629.
+//
+// Solidity: event ReceivedAttestation(bytes commP, string sourceAddress)
+func (_DebugMockBridge *DebugMockBridgeFilterer) ParseReceivedAttestation(log types.Log) (*DebugMockBridgeReceivedAttestation, error) {
+	event := new(DebugMockBridgeReceivedAttestation)
+	if err := _DebugMockBridge.contract.UnpackLog(event, "ReceivedAttestation", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,285 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// ForwardingProofMockBridgeMetaData contains all meta data concerning the ForwardingProofMockBridge contract.
+var ForwardingProofMockBridgeMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"string\",\"name\":\"_sourceChain_\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"sourceAddress_\",\"type\":\"string\"},{\"internalType\":\"bytes\",\"name\":\"payload_\",\"type\":\"bytes\"}],\"name\":\"_execute\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"receiver\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"senderHex\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"senderHex_\",\"type\":\"string\"},{\"internalType\":\"address\",\"name\":\"receiver_\",\"type\":\"address\"}],\"name\":\"setSenderReceiver\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+}
+
+// ForwardingProofMockBridgeABI is the input ABI used to generate the binding from.
+// Deprecated: Use ForwardingProofMockBridgeMetaData.ABI instead.
+var ForwardingProofMockBridgeABI = ForwardingProofMockBridgeMetaData.ABI
+
+// ForwardingProofMockBridge is an auto generated Go binding around an Ethereum contract.
+type ForwardingProofMockBridge struct {
+	ForwardingProofMockBridgeCaller     // Read-only binding to the contract
+	ForwardingProofMockBridgeTransactor // Write-only binding to the contract
+	ForwardingProofMockBridgeFilterer   // Log filterer for contract events
+}
+
+// ForwardingProofMockBridgeCaller is an auto generated read-only Go binding around an Ethereum contract.
+type ForwardingProofMockBridgeCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ForwardingProofMockBridgeTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type ForwardingProofMockBridgeTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ForwardingProofMockBridgeFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type ForwardingProofMockBridgeFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ForwardingProofMockBridgeSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type ForwardingProofMockBridgeSession struct {
+	Contract     *ForwardingProofMockBridge // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts              // Call options to use throughout this session
+	TransactOpts bind.TransactOpts          // Transaction auth options to use throughout this session
+}
+
+// ForwardingProofMockBridgeCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type ForwardingProofMockBridgeCallerSession struct {
+	Contract *ForwardingProofMockBridgeCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts                    // Call options to use throughout this session
+}
+
+// ForwardingProofMockBridgeTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type ForwardingProofMockBridgeTransactorSession struct {
+	Contract     *ForwardingProofMockBridgeTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts                    // Transaction auth options to use throughout this session
+}
+
+// ForwardingProofMockBridgeRaw is an auto generated low-level Go binding around an Ethereum contract.
+type ForwardingProofMockBridgeRaw struct {
+	Contract *ForwardingProofMockBridge // Generic contract binding to access the raw methods on
+}
+
+// ForwardingProofMockBridgeCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type ForwardingProofMockBridgeCallerRaw struct {
+	Contract *ForwardingProofMockBridgeCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// ForwardingProofMockBridgeTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type ForwardingProofMockBridgeTransactorRaw struct {
+	Contract *ForwardingProofMockBridgeTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewForwardingProofMockBridge creates a new instance of ForwardingProofMockBridge, bound to a specific deployed contract.
+func NewForwardingProofMockBridge(address common.Address, backend bind.ContractBackend) (*ForwardingProofMockBridge, error) {
+	contract, err := bindForwardingProofMockBridge(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &ForwardingProofMockBridge{ForwardingProofMockBridgeCaller: ForwardingProofMockBridgeCaller{contract: contract}, ForwardingProofMockBridgeTransactor: ForwardingProofMockBridgeTransactor{contract: contract}, ForwardingProofMockBridgeFilterer: ForwardingProofMockBridgeFilterer{contract: contract}}, nil
+}
+
+// NewForwardingProofMockBridgeCaller creates a new read-only instance of ForwardingProofMockBridge, bound to a specific deployed contract.
+func NewForwardingProofMockBridgeCaller(address common.Address, caller bind.ContractCaller) (*ForwardingProofMockBridgeCaller, error) {
+	contract, err := bindForwardingProofMockBridge(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ForwardingProofMockBridgeCaller{contract: contract}, nil
+}
+
+// NewForwardingProofMockBridgeTransactor creates a new write-only instance of ForwardingProofMockBridge, bound to a specific deployed contract.
+func NewForwardingProofMockBridgeTransactor(address common.Address, transactor bind.ContractTransactor) (*ForwardingProofMockBridgeTransactor, error) {
+	contract, err := bindForwardingProofMockBridge(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ForwardingProofMockBridgeTransactor{contract: contract}, nil
+}
+
+// NewForwardingProofMockBridgeFilterer creates a new log filterer instance of ForwardingProofMockBridge, bound to a specific deployed contract.
+func NewForwardingProofMockBridgeFilterer(address common.Address, filterer bind.ContractFilterer) (*ForwardingProofMockBridgeFilterer, error) {
+	contract, err := bindForwardingProofMockBridge(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ForwardingProofMockBridgeFilterer{contract: contract}, nil
+}
+
+// bindForwardingProofMockBridge binds a generic wrapper to an already deployed contract.
+func bindForwardingProofMockBridge(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := ForwardingProofMockBridgeMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ForwardingProofMockBridge *ForwardingProofMockBridgeRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ForwardingProofMockBridge.Contract.ForwardingProofMockBridgeCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ForwardingProofMockBridge *ForwardingProofMockBridgeRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ForwardingProofMockBridge.Contract.ForwardingProofMockBridgeTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ForwardingProofMockBridge *ForwardingProofMockBridgeRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ForwardingProofMockBridge.Contract.ForwardingProofMockBridgeTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ForwardingProofMockBridge *ForwardingProofMockBridgeCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ForwardingProofMockBridge.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ForwardingProofMockBridge *ForwardingProofMockBridgeTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ForwardingProofMockBridge.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ForwardingProofMockBridge *ForwardingProofMockBridgeTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ForwardingProofMockBridge.Contract.contract.Transact(opts, method, params...)
+}
+
+// Receiver is a free data retrieval call binding the contract method 0xf7260d3e.
+//
+// Solidity: function receiver() view returns(address)
+func (_ForwardingProofMockBridge *ForwardingProofMockBridgeCaller) Receiver(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _ForwardingProofMockBridge.contract.Call(opts, &out, "receiver")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Receiver is a free data retrieval call binding the contract method 0xf7260d3e.
+//
+// Solidity: function receiver() view returns(address)
+func (_ForwardingProofMockBridge *ForwardingProofMockBridgeSession) Receiver() (common.Address, error) {
+	return _ForwardingProofMockBridge.Contract.Receiver(&_ForwardingProofMockBridge.CallOpts)
+}
+
+// Receiver is a free data retrieval call binding the contract method 0xf7260d3e.
+//
+// Solidity: function receiver() view returns(address)
+func (_ForwardingProofMockBridge *ForwardingProofMockBridgeCallerSession) Receiver() (common.Address, error) {
+	return _ForwardingProofMockBridge.Contract.Receiver(&_ForwardingProofMockBridge.CallOpts)
+}
+
+// SenderHex is a free data retrieval call binding the contract method 0x4ffadbe0.
+//
+// Solidity: function senderHex() view returns(string)
+func (_ForwardingProofMockBridge *ForwardingProofMockBridgeCaller) SenderHex(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _ForwardingProofMockBridge.contract.Call(opts, &out, "senderHex")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// SenderHex is a free data retrieval call binding the contract method 0x4ffadbe0.
+//
+// Solidity: function senderHex() view returns(string)
+func (_ForwardingProofMockBridge *ForwardingProofMockBridgeSession) SenderHex() (string, error) {
+	return _ForwardingProofMockBridge.Contract.SenderHex(&_ForwardingProofMockBridge.CallOpts)
+}
+
+// SenderHex is a free data retrieval call binding the contract method 0x4ffadbe0.
+//
+// Solidity: function senderHex() view returns(string)
+func (_ForwardingProofMockBridge *ForwardingProofMockBridgeCallerSession) SenderHex() (string, error) {
+	return _ForwardingProofMockBridge.Contract.SenderHex(&_ForwardingProofMockBridge.CallOpts)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0xa69399ed.
+//
+// Solidity: function _execute(string _sourceChain_, string sourceAddress_, bytes payload_) returns()
+func (_ForwardingProofMockBridge *ForwardingProofMockBridgeTransactor) Execute(opts *bind.TransactOpts, _sourceChain_ string, sourceAddress_ string, payload_ []byte) (*types.Transaction, error) {
+	return _ForwardingProofMockBridge.contract.Transact(opts, "_execute", _sourceChain_, sourceAddress_, payload_)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0xa69399ed.
+//
+// Solidity: function _execute(string _sourceChain_, string sourceAddress_, bytes payload_) returns()
+func (_ForwardingProofMockBridge *ForwardingProofMockBridgeSession) Execute(_sourceChain_ string, sourceAddress_ string, payload_ []byte) (*types.Transaction, error) {
+	return _ForwardingProofMockBridge.Contract.Execute(&_ForwardingProofMockBridge.TransactOpts, _sourceChain_, sourceAddress_, payload_)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0xa69399ed.
+//
+// Solidity: function _execute(string _sourceChain_, string sourceAddress_, bytes payload_) returns()
+func (_ForwardingProofMockBridge *ForwardingProofMockBridgeTransactorSession) Execute(_sourceChain_ string, sourceAddress_ string, payload_ []byte) (*types.Transaction, error) {
+	return _ForwardingProofMockBridge.Contract.Execute(&_ForwardingProofMockBridge.TransactOpts, _sourceChain_, sourceAddress_, payload_)
+}
+
+// SetSenderReceiver is a paid mutator transaction binding the contract method 0x6c3c831d.
+//
+// Solidity: function setSenderReceiver(string senderHex_, address receiver_) returns()
+func (_ForwardingProofMockBridge *ForwardingProofMockBridgeTransactor) SetSenderReceiver(opts *bind.TransactOpts, senderHex_ string, receiver_ common.Address) (*types.Transaction, error) {
+	return _ForwardingProofMockBridge.contract.Transact(opts, "setSenderReceiver", senderHex_, receiver_)
+}
+
+// SetSenderReceiver is a paid mutator transaction binding the contract method 0x6c3c831d.
+//
+// Solidity: function setSenderReceiver(string senderHex_, address receiver_) returns()
+func (_ForwardingProofMockBridge *ForwardingProofMockBridgeSession) SetSenderReceiver(senderHex_ string, receiver_ common.Address) (*types.Transaction, error) {
+	return _ForwardingProofMockBridge.Contract.SetSenderReceiver(&_ForwardingProofMockBridge.TransactOpts, senderHex_, receiver_)
+}
+
+// SetSenderReceiver is a paid mutator transaction binding the contract method 0x6c3c831d.
+//
+// Solidity: function setSenderReceiver(string senderHex_, address receiver_) returns()
+func (_ForwardingProofMockBridge *ForwardingProofMockBridgeTransactorSession) SetSenderReceiver(senderHex_ string, receiver_ common.Address) (*types.Transaction, error) {
+	return _ForwardingProofMockBridge.Contract.SetSenderReceiver(&_ForwardingProofMockBridge.TransactOpts, senderHex_, receiver_)
+}
@@ -0,0 +1,20 @@
+// Package contracts holds Go bindings for the OnRamp, Prover and oracle
+// contracts of onramp-contracts, and for the parts of ERC-20 used to pay for
+// offers, generated by abigen from the ABIs in abi/.
+// Regenerate them with `go generate ./contracts` after changing a contract.
+// DataAttestation is declared by both the OnRamp and TrustedRelayerOracle
+// bindings, the second declaration is removed after generating them.
+package contracts
+
+//go:generate go run github.com/ethereum/go-ethereum/cmd/abigen --abi abi/OnRamp.json --pkg contracts --type OnRamp --out onramp.go
+//go:generate go run github.com/ethereum/go-ethereum/cmd/abigen --abi abi/Prover.json --pkg contracts --type Prover --out prover.go
+//go:generate go run github.com/ethereum/go-ethereum/cmd/abigen --abi abi/ProverAxelar.json --pkg contracts --type ProverAxelar --out prover_axelar.go
+//go:generate go run github.com/ethereum/go-ethereum/cmd/abigen --abi abi/AxelarBridge.json --pkg contracts --type AxelarBridge --out axelar_bridge.go
+//go:generate go run github.com/ethereum/go-ethereum/cmd/abigen --abi abi/AxelarBridgeDebug.json --pkg contracts --type AxelarBridgeDebug --out axelar_bridge_debug.go
+//go:generate go run github.com/ethereum/go-ethereum/cmd/abigen --abi abi/ForwardingProofMockBridge.json --pkg contracts --type ForwardingProofMockBridge --out forwarding_proof_mock_bridge.go
+//go:generate go run github.com/ethereum/go-ethereum/cmd/abigen --abi abi/DebugMockBridge.json --pkg contracts --type DebugMockBridge --out debug_mock_bridge.go
+//go:generate go run github.com/ethereum/go-ethereum/cmd/abigen --abi abi/ERC20.json --pkg contracts --type ERC20 --out erc20.go
+//go:generate go run github.com/ethereum/go-ethereum/cmd/abigen --abi abi/TrustedRelayerOracle.json --pkg contracts --type TrustedRelayerOracle --out trusted_relayer_oracle.go
+//go:generate sed -i.bak -e "/^.. DataAttestation is an auto generated/,/^}$/d" trusted_relayer_oracle.go
+//go:generate rm trusted_relayer_oracle.go.bak
+//go:generate gofmt -w trusted_relayer_oracle.go
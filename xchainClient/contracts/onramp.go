@@ -0,0 +1,1411 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// DataAttestation is an auto generated low-level Go binding around an user-defined struct.
+type DataAttestation struct {
+	CommP    []byte
+	Duration int64
+	DealID   uint64
+	Status   *big.Int
+}
+
+// OnRampContractOffer is an auto generated low-level Go binding around an user-defined struct.
+type OnRampContractOffer struct {
+	CommP    []byte
+	Size     uint64
+	Cid      string
+	Location string
+	Amount   *big.Int
+	Token    common.Address
+	Status   uint8
+}
+
+// PODSIVerifierProofData is an auto generated low-level Go binding around an user-defined struct.
+type PODSIVerifierProofData struct {
+	Index uint64
+	Path  [][32]byte
+}
+
+// OnRampMetaData contains all meta data concerning the OnRamp contract.
+var OnRampMetaData = &bind.MetaData{
+	ABI: "[{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"aggId\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"commP\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"uint64[]\",\"name\":\"offerIDs\",\"type\":\"uint64[]\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"payoutAddr\",\"type\":\"address\"}],\"name\":\"AggregationCommitted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"components\":[{\"internalType\":\"bytes\",\"name\":\"commP\",\"type\":\"bytes\"},{\"internalType\":\"uint64\",\"name\":\"size\",\"type\":\"uint64\"},{\"internalType\":\"string\",\"name\":\"cid\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"location\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"contractIERC20\",\"name\":\"token\",\"type\":\"address\"},{\"internalType\":\"enumOnRampContract.OfferStatus\",\"name\":\"status\",\"type\":\"uint8\"}],\"indexed\":false,\"internalType\":\"structOnRampContract.Offer\",\"name\":\"offer\",\"type\":\"tuple\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"id\",\"type\":\"uint64\"}],\"name\":\"DataReady\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"commP\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"dealID\",\"type\":\"uint64\"}],\"name\":\"ProveDataStored\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"name\":\"aggregationDealIds\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"name\":\"aggregationPayout\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"aggregations\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"name\":\"commPToAggregateID\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"commP\",\"type\":\"bytes\"},{\"internalType\":\"uint64[]\",\"name\":\"claimedIDs\",\"type\":\"uint64[]\"},{\"components\":[{\"internalType\":\"uint64\",\"name\":\"index\",\"type\":\"uint64\"},{\"internalType\":\"bytes32[]\",\"name\":\"path\",\"type\":\"bytes32[]\"}],\"internalType\":\"structPODSIVerifier.ProofData[]\",\"name\":\"inclusionProofs\",\"type\":\"tuple[]\"},{\"components\":[{\"internalType\":\"uint64\",\"name\":\"index\",\"type\":\"uint64\"},{\"internalType\":\"bytes32[]\",\"name\":\"path\",\"type\":\"bytes32[]\"}],\"internalType\":\"structPODSIVerifier.ProofData[]\",\"name\":\"indexProofs\",\"type\":\"tuple[]\"},{\"internalType\":\"address\",\"name\":\"payoutAddr\",\"type\":\"address\"}],\"name\":\"commitAggregate\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"dataProofOracle\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"aggId\",\"type\":\"uint64\"}],\"name\":\"getAggregationDetails\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"payoutAddress\",\"type\":\"address\"},{\"internalType\":\"bool\",\"name\":\"isProven\",\"type\":\"bool\"},{\"internalType\":\"uint64\",\"name\":\"offerCount\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"aggId\",\"type\":\"uint64\"}],\"name\":\"getAggregationOffers\",\"outputs\":[{\"internalType\":\"uint64[]\",\"name\":\"\",\"type\":\"uint64[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"client\",\"type\":\"address\"}],\"name\":\"getClientOffers\",\"outputs\":[{\"internalType\":\"uint64[]\",\"name\":\"\",\"type\":\"uint64[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"offerId\",\"type\":\"uint64\"}],\"name\":\"getOfferDealId\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"dealId\",\"type\":\"uint64\"},{\"internalType\":\"bool\",\"name\":\"exists\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"offerId\",\"type\":\"uint64\"}],\"name\":\"getOfferDetails\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"commP\",\"type\":\"bytes\"},{\"internalType\":\"uint64\",\"name\":\"size\",\"type\":\"uint64\"},{\"internalType\":\"string\",\"name\":\"location\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"contractIERC20\",\"name\":\"token\",\"type\":\"address\"},{\"internalType\":\"bool\",\"name\":\"exists\",\"type\":\"bool\"},{\"internalType\":\"enumOnRampContract.OfferStatus\",\"name\":\"status\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"offerId\",\"type\":\"uint64\"}],\"name\":\"getOfferStatus\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"exists\",\"type\":\"bool\"},{\"internalType\":\"enumOnRampContract.OfferStatus\",\"name\":\"status\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getPendingOffers\",\"outputs\":[{\"internalType\":\"uint64[]\",\"name\":\"\",\"type\":\"uint64[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getTotalOffers\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"name\":\"isOfferAggregated\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"bytes\",\"name\":\"commP\",\"type\":\"bytes\"},{\"internalType\":\"uint64\",\"name\":\"size\",\"type\":\"uint64\"},{\"internalType\":\"string\",\"name\":\"cid\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"location\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"contractIERC20\",\"name\":\"token\",\"type\":\"address\"},{\"internalType\":\"enumOnRampContract.OfferStatus\",\"name\":\"status\",\"type\":\"uint8\"}],\"internalType\":\"structOnRampContract.Offer\",\"name\":\"offer\",\"type\":\"tuple\"}],\"name\":\"offerData\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"name\":\"offers\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"commP\",\"type\":\"bytes\"},{\"internalType\":\"uint64\",\"name\":\"size\",\"type\":\"uint64\"},{\"internalType\":\"string\",\"name\":\"cid\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"location\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"contractIERC20\",\"name\":\"token\",\"type\":\"address\"},{\"internalType\":\"enumOnRampContract.OfferStatus\",\"name\":\"status\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"bytes\",\"name\":\"commP\",\"type\":\"bytes\"},{\"internalType\":\"int64\",\"name\":\"duration\",\"type\":\"int64\"},{\"internalType\":\"uint64\",\"name\":\"dealID\",\"type\":\"uint64\"},{\"internalType\":\"uint256\",\"name\":\"status\",\"type\":\"uint256\"}],\"internalType\":\"structDataAttestation\",\"name\":\"attestation\",\"type\":\"tuple\"}],\"name\":\"proveDataStored\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"name\":\"provenAggregations\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"oracle_\",\"type\":\"address\"}],\"name\":\"setOracle\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint64\",\"name\":\"index\",\"type\":\"uint64\"},{\"internalType\":\"bytes32[]\",\"name\":\"path\",\"type\":\"bytes32[]\"}],\"internalType\":\"structPODSIVerifier.ProofData\",\"name\":\"proof\",\"type\":\"tuple\"},{\"internalType\":\"bytes32\",\"name\":\"root\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"leaf\",\"type\":\"bytes32\"}],\"name\":\"verify\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"pure\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"aggID\",\"type\":\"uint64\"},{\"internalType\":\"uint256\",\"name\":\"idx\",\"type\":\"uint256\"},{\"internalType\":\"uint64\",\"name\":\"offerID\",\"type\":\"uint64\"}],\"name\":\"verifyDataStored\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"uint64\",\"name\":\"index\",\"type\":\"uint64\"},{\"internalType\":\"bytes32[]\",\"name\":\"path\",\"type\":\"bytes32[]\"}],\"internalType\":\"structPODSIVerifier.ProofData\",\"name\":\"proofSubtree\",\"type\":\"tuple\"},{\"components\":[{\"internalType\":\"uint64\",\"name\":\"index\",\"type\":\"uint64\"},{\"internalType\":\"bytes32[]\",\"name\":\"path\",\"type\":\"bytes32[]\"}],\"internalType\":\"structPODSIVerifier.ProofData\",\"name\":\"proofIndex\",\"type\":\"tuple\"},{\"internalType\":\"bytes32\",\"name\":\"root\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"commPc\",\"type\":\"bytes32\"},{\"internalType\":\"uint64\",\"name\":\"sizePc\",\"type\":\"uint64\"}],\"name\":\"verifyInclusion\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"pure\",\"type\":\"function\"}]",
+}
+
+// OnRampABI is the input ABI used to generate the binding from.
+// Deprecated: Use OnRampMetaData.ABI instead.
+var OnRampABI = OnRampMetaData.ABI
+
+// OnRamp is an auto generated Go binding around an Ethereum contract.
+type OnRamp struct {
+	OnRampCaller     // Read-only binding to the contract
+	OnRampTransactor // Write-only binding to the contract
+	OnRampFilterer   // Log filterer for contract events
+}
+
+// OnRampCaller is an auto generated read-only Go binding around an Ethereum contract.
+type OnRampCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// OnRampTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type OnRampTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// OnRampFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type OnRampFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// OnRampSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type OnRampSession struct {
+	Contract     *OnRamp           // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// OnRampCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type OnRampCallerSession struct {
+	Contract *OnRampCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts // Call options to use throughout this session
+}
+
+// OnRampTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type OnRampTransactorSession struct {
+	Contract     *OnRampTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// OnRampRaw is an auto generated low-level Go binding around an Ethereum contract.
+type OnRampRaw struct {
+	Contract *OnRamp // Generic contract binding to access the raw methods on
+}
+
+// OnRampCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type OnRampCallerRaw struct {
+	Contract *OnRampCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// OnRampTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type OnRampTransactorRaw struct {
+	Contract *OnRampTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewOnRamp creates a new instance of OnRamp, bound to a specific deployed contract.
+func NewOnRamp(address common.Address, backend bind.ContractBackend) (*OnRamp, error) {
+	contract, err := bindOnRamp(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &OnRamp{OnRampCaller: OnRampCaller{contract: contract}, OnRampTransactor: OnRampTransactor{contract: contract}, OnRampFilterer: OnRampFilterer{contract: contract}}, nil
+}
+
+// NewOnRampCaller creates a new read-only instance of OnRamp, bound to a specific deployed contract.
+func NewOnRampCaller(address common.Address, caller bind.ContractCaller) (*OnRampCaller, error) {
+	contract, err := bindOnRamp(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &OnRampCaller{contract: contract}, nil
+}
+
+// NewOnRampTransactor creates a new write-only instance of OnRamp, bound to a specific deployed contract.
+func NewOnRampTransactor(address common.Address, transactor bind.ContractTransactor) (*OnRampTransactor, error) {
+	contract, err := bindOnRamp(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &OnRampTransactor{contract: contract}, nil
+}
+
+// NewOnRampFilterer creates a new log filterer instance of OnRamp, bound to a specific deployed contract.
+func NewOnRampFilterer(address common.Address, filterer bind.ContractFilterer) (*OnRampFilterer, error) {
+	contract, err := bindOnRamp(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &OnRampFilterer{contract: contract}, nil
+}
+
+// bindOnRamp binds a generic wrapper to an already deployed contract.
+func bindOnRamp(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := OnRampMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_OnRamp *OnRampRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _OnRamp.Contract.OnRampCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_OnRamp *OnRampRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _OnRamp.Contract.OnRampTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_OnRamp *OnRampRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _OnRamp.Contract.OnRampTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_OnRamp *OnRampCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _OnRamp.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_OnRamp *OnRampTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _OnRamp.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_OnRamp *OnRampTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _OnRamp.Contract.contract.Transact(opts, method, params...)
+}
+
+// AggregationDealIds is a free data retrieval call binding the contract method 0x65f9f670.
+//
+// Solidity: function aggregationDealIds(uint64 ) view returns(uint64)
+func (_OnRamp *OnRampCaller) AggregationDealIds(opts *bind.CallOpts, arg0 uint64) (uint64, error) {
+	var out []interface{}
+	err := _OnRamp.contract.Call(opts, &out, "aggregationDealIds", arg0)
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// AggregationDealIds is a free data retrieval call binding the contract method 0x65f9f670.
+//
+// Solidity: function aggregationDealIds(uint64 ) view returns(uint64)
+func (_OnRamp *OnRampSession) AggregationDealIds(arg0 uint64) (uint64, error) {
+	return _OnRamp.Contract.AggregationDealIds(&_OnRamp.CallOpts, arg0)
+}
+
+// AggregationDealIds is a free data retrieval call binding the contract method 0x65f9f670.
+//
+// Solidity: function aggregationDealIds(uint64 ) view returns(uint64)
+func (_OnRamp *OnRampCallerSession) AggregationDealIds(arg0 uint64) (uint64, error) {
+	return _OnRamp.Contract.AggregationDealIds(&_OnRamp.CallOpts, arg0)
+}
+
+// AggregationPayout is a free data retrieval call binding the contract method 0x2353b420.
+//
+// Solidity: function aggregationPayout(uint64 ) view returns(address)
+func (_OnRamp *OnRampCaller) AggregationPayout(opts *bind.CallOpts, arg0 uint64) (common.Address, error) {
+	var out []interface{}
+	err := _OnRamp.contract.Call(opts, &out, "aggregationPayout", arg0)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// AggregationPayout is a free data retrieval call binding the contract method 0x2353b420.
+//
+// Solidity: function aggregationPayout(uint64 ) view returns(address)
+func (_OnRamp *OnRampSession) AggregationPayout(arg0 uint64) (common.Address, error) {
+	return _OnRamp.Contract.AggregationPayout(&_OnRamp.CallOpts, arg0)
+}
+
+// AggregationPayout is a free data retrieval call binding the contract method 0x2353b420.
+//
+// Solidity: function aggregationPayout(uint64 ) view returns(address)
+func (_OnRamp *OnRampCallerSession) AggregationPayout(arg0 uint64) (common.Address, error) {
+	return _OnRamp.Contract.AggregationPayout(&_OnRamp.CallOpts, arg0)
+}
+
+// Aggregations is a free data retrieval call binding the contract method 0xe6706e1d.
+//
+// Solidity: function aggregations(uint64 , uint256 ) view returns(uint64)
+func (_OnRamp *OnRampCaller) Aggregations(opts *bind.CallOpts, arg0 uint64, arg1 *big.Int) (uint64, error) {
+	var out []interface{}
+	err := _OnRamp.contract.Call(opts, &out, "aggregations", arg0, arg1)
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// Aggregations is a free data retrieval call binding the contract method 0xe6706e1d.
+//
+// Solidity: function aggregations(uint64 , uint256 ) view returns(uint64)
+func (_OnRamp *OnRampSession) Aggregations(arg0 uint64, arg1 *big.Int) (uint64, error) {
+	return _OnRamp.Contract.Aggregations(&_OnRamp.CallOpts, arg0, arg1)
+}
+
+// Aggregations is a free data retrieval call binding the contract method 0xe6706e1d.
+//
+// Solidity: function aggregations(uint64 , uint256 ) view returns(uint64)
+func (_OnRamp *OnRampCallerSession) Aggregations(arg0 uint64, arg1 *big.Int) (uint64, error) {
+	return _OnRamp.Contract.Aggregations(&_OnRamp.CallOpts, arg0, arg1)
+}
+
+// CommPToAggregateID is a free data retrieval call binding the contract method 0x999a81cf.
+//
+// Solidity: function commPToAggregateID(bytes ) view returns(uint64)
+func (_OnRamp *OnRampCaller) CommPToAggregateID(opts *bind.CallOpts, arg0 []byte) (uint64, error) {
+	var out []interface{}
+	err := _OnRamp.contract.Call(opts, &out, "commPToAggregateID", arg0)
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// CommPToAggregateID is a free data retrieval call binding the contract method 0x999a81cf.
+//
+// Solidity: function commPToAggregateID(bytes ) view returns(uint64)
+func (_OnRamp *OnRampSession) CommPToAggregateID(arg0 []byte) (uint64, error) {
+	return _OnRamp.Contract.CommPToAggregateID(&_OnRamp.CallOpts, arg0)
+}
+
+// CommPToAggregateID is a free data retrieval call binding the contract method 0x999a81cf.
+//
+// Solidity: function commPToAggregateID(bytes ) view returns(uint64)
+func (_OnRamp *OnRampCallerSession) CommPToAggregateID(arg0 []byte) (uint64, error) {
+	return _OnRamp.Contract.CommPToAggregateID(&_OnRamp.CallOpts, arg0)
+}
+
+// DataProofOracle is a free data retrieval call binding the contract method 0xafb55ab5.
+//
+// Solidity: function dataProofOracle() view returns(address)
+func (_OnRamp *OnRampCaller) DataProofOracle(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _OnRamp.contract.Call(opts, &out, "dataProofOracle")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// DataProofOracle is a free data retrieval call binding the contract method 0xafb55ab5.
+//
+// Solidity: function dataProofOracle() view returns(address)
+func (_OnRamp *OnRampSession) DataProofOracle() (common.Address, error) {
+	return _OnRamp.Contract.DataProofOracle(&_OnRamp.CallOpts)
+}
+
+// DataProofOracle is a free data retrieval call binding the contract method 0xafb55ab5.
+//
+// Solidity: function dataProofOracle() view returns(address)
+func (_OnRamp *OnRampCallerSession) DataProofOracle() (common.Address, error) {
+	return _OnRamp.Contract.DataProofOracle(&_OnRamp.CallOpts)
+}
+
+// GetAggregationDetails is a free data retrieval call binding the contract method 0xd35ef00b.
+//
+// Solidity: function getAggregationDetails(uint64 aggId) view returns(address payoutAddress, bool isProven, uint64 offerCount)
+func (_OnRamp *OnRampCaller) GetAggregationDetails(opts *bind.CallOpts, aggId uint64) (struct {
+	PayoutAddress common.Address
+	IsProven      bool
+	OfferCount    uint64
+}, error) {
+	var out []interface{}
+	err := _OnRamp.contract.Call(opts, &out, "getAggregationDetails", aggId)
+
+	outstruct := new(struct {
+		PayoutAddress common.Address
+		IsProven      bool
+		OfferCount    uint64
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.PayoutAddress = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	outstruct.IsProven = *abi.ConvertType(out[1], new(bool)).(*bool)
+	outstruct.OfferCount = *abi.ConvertType(out[2], new(uint64)).(*uint64)
+
+	return *outstruct, err
+
+}
+
+// GetAggregationDetails is a free data retrieval call binding the contract method 0xd35ef00b.
+//
+// Solidity: function getAggregationDetails(uint64 aggId) view returns(address payoutAddress, bool isProven, uint64 offerCount)
+func (_OnRamp *OnRampSession) GetAggregationDetails(aggId uint64) (struct {
+	PayoutAddress common.Address
+	IsProven      bool
+	OfferCount    uint64
+}, error) {
+	return _OnRamp.Contract.GetAggregationDetails(&_OnRamp.CallOpts, aggId)
+}
+
+// GetAggregationDetails is a free data retrieval call binding the contract method 0xd35ef00b.
+//
+// Solidity: function getAggregationDetails(uint64 aggId) view returns(address payoutAddress, bool isProven, uint64 offerCount)
+func (_OnRamp *OnRampCallerSession) GetAggregationDetails(aggId uint64) (struct {
+	PayoutAddress common.Address
+	IsProven      bool
+	OfferCount    uint64
+}, error) {
+	return _OnRamp.Contract.GetAggregationDetails(&_OnRamp.CallOpts, aggId)
+}
+
+// GetAggregationOffers is a free data retrieval call binding the contract method 0x29099f0c.
+//
+// Solidity: function getAggregationOffers(uint64 aggId) view returns(uint64[])
+func (_OnRamp *OnRampCaller) GetAggregationOffers(opts *bind.CallOpts, aggId uint64) ([]uint64, error) {
+	var out []interface{}
+	err := _OnRamp.contract.Call(opts, &out, "getAggregationOffers", aggId)
+
+	if err != nil {
+		return *new([]uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]uint64)).(*[]uint64)
+
+	return out0, err
+
+}
+
+// GetAggregationOffers is a free data retrieval call binding the contract method 0x29099f0c.
+//
+// Solidity: function getAggregationOffers(uint64 aggId) view returns(uint64[])
+func (_OnRamp *OnRampSession) GetAggregationOffers(aggId uint64) ([]uint64, error) {
+	return _OnRamp.Contract.GetAggregationOffers(&_OnRamp.CallOpts, aggId)
+}
+
+// GetAggregationOffers is a free data retrieval call binding the contract method 0x29099f0c.
+//
+// Solidity: function getAggregationOffers(uint64 aggId) view returns(uint64[])
+func (_OnRamp *OnRampCallerSession) GetAggregationOffers(aggId uint64) ([]uint64, error) {
+	return _OnRamp.Contract.GetAggregationOffers(&_OnRamp.CallOpts, aggId)
+}
+
+// GetClientOffers is a free data retrieval call binding the contract method 0x5a31c274.
+//
+// Solidity: function getClientOffers(address client) view returns(uint64[])
+func (_OnRamp *OnRampCaller) GetClientOffers(opts *bind.CallOpts, client common.Address) ([]uint64, error) {
+	var out []interface{}
+	err := _OnRamp.contract.Call(opts, &out, "getClientOffers", client)
+
+	if err != nil {
+		return *new([]uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]uint64)).(*[]uint64)
+
+	return out0, err
+
+}
+
+// GetClientOffers is a free data retrieval call binding the contract method 0x5a31c274.
+//
+// Solidity: function getClientOffers(address client) view returns(uint64[])
+func (_OnRamp *OnRampSession) GetClientOffers(client common.Address) ([]uint64, error) {
+	return _OnRamp.Contract.GetClientOffers(&_OnRamp.CallOpts, client)
+}
+
+// GetClientOffers is a free data retrieval call binding the contract method 0x5a31c274.
+//
+// Solidity: function getClientOffers(address client) view returns(uint64[])
+func (_OnRamp *OnRampCallerSession) GetClientOffers(client common.Address) ([]uint64, error) {
+	return _OnRamp.Contract.GetClientOffers(&_OnRamp.CallOpts, client)
+}
+
+// GetOfferDealId is a free data retrieval call binding the contract method 0xaa7b73d9.
+//
+// Solidity: function getOfferDealId(uint64 offerId) view returns(uint64 dealId, bool exists)
+func (_OnRamp *OnRampCaller) GetOfferDealId(opts *bind.CallOpts, offerId uint64) (struct {
+	DealId uint64
+	Exists bool
+}, error) {
+	var out []interface{}
+	err := _OnRamp.contract.Call(opts, &out, "getOfferDealId", offerId)
+
+	outstruct := new(struct {
+		DealId uint64
+		Exists bool
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.DealId = *abi.ConvertType(out[0], new(uint64)).(*uint64)
+	outstruct.Exists = *abi.ConvertType(out[1], new(bool)).(*bool)
+
+	return *outstruct, err
+
+}
+
+// GetOfferDealId is a free data retrieval call binding the contract method 0xaa7b73d9.
+//
+// Solidity: function getOfferDealId(uint64 offerId) view returns(uint64 dealId, bool exists)
+func (_OnRamp *OnRampSession) GetOfferDealId(offerId uint64) (struct {
+	DealId uint64
+	Exists bool
+}, error) {
+	return _OnRamp.Contract.GetOfferDealId(&_OnRamp.CallOpts, offerId)
+}
+
+// GetOfferDealId is a free data retrieval call binding the contract method 0xaa7b73d9.
+//
+// Solidity: function getOfferDealId(uint64 offerId) view returns(uint64 dealId, bool exists)
+func (_OnRamp *OnRampCallerSession) GetOfferDealId(offerId uint64) (struct {
+	DealId uint64
+	Exists bool
+}, error) {
+	return _OnRamp.Contract.GetOfferDealId(&_OnRamp.CallOpts, offerId)
+}
+
+// GetOfferDetails is a free data retrieval call binding the contract method 0x0babbe02.
+//
+// Solidity: function getOfferDetails(uint64 offerId) view returns(bytes commP, uint64 size, string location, uint256 amount, address token, bool exists, uint8 status)
+func (_OnRamp *OnRampCaller) GetOfferDetails(opts *bind.CallOpts, offerId uint64) (struct {
+	CommP    []byte
+	Size     uint64
+	Location string
+	Amount   *big.Int
+	Token    common.Address
+	Exists   bool
+	Status   uint8
+}, error) {
+	var out []interface{}
+	err := _OnRamp.contract.Call(opts, &out, "getOfferDetails", offerId)
+
+	outstruct := new(struct {
+		CommP    []byte
+		Size     uint64
+		Location string
+		Amount   *big.Int
+		Token    common.Address
+		Exists   bool
+		Status   uint8
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.CommP = *abi.ConvertType(out[0], new([]byte)).(*[]byte)
+	outstruct.Size = *abi.ConvertType(out[1], new(uint64)).(*uint64)
+	outstruct.Location = *abi.ConvertType(out[2], new(string)).(*string)
+	outstruct.Amount = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.Token = *abi.ConvertType(out[4], new(common.Address)).(*common.Address)
+	outstruct.Exists = *abi.ConvertType(out[5], new(bool)).(*bool)
+	outstruct.Status = *abi.ConvertType(out[6], new(uint8)).(*uint8)
+
+	return *outstruct, err
+
+}
+
+// GetOfferDetails is a free data retrieval call binding the contract method 0x0babbe02.
+//
+// Solidity: function getOfferDetails(uint64 offerId) view returns(bytes commP, uint64 size, string location, uint256 amount, address token, bool exists, uint8 status)
+func (_OnRamp *OnRampSession) GetOfferDetails(offerId uint64) (struct {
+	CommP    []byte
+	Size     uint64
+	Location string
+	Amount   *big.Int
+	Token    common.Address
+	Exists   bool
+	Status   uint8
+}, error) {
+	return _OnRamp.Contract.GetOfferDetails(&_OnRamp.CallOpts, offerId)
+}
+
+// GetOfferDetails is a free data retrieval call binding the contract method 0x0babbe02.
+//
+// Solidity: function getOfferDetails(uint64 offerId) view returns(bytes commP, uint64 size, string location, uint256 amount, address token, bool exists, uint8 status)
+func (_OnRamp *OnRampCallerSession) GetOfferDetails(offerId uint64) (struct {
+	CommP    []byte
+	Size     uint64
+	Location string
+	Amount   *big.Int
+	Token    common.Address
+	Exists   bool
+	Status   uint8
+}, error) {
+	return _OnRamp.Contract.GetOfferDetails(&_OnRamp.CallOpts, offerId)
+}
+
+// GetOfferStatus is a free data retrieval call binding the contract method 0x91d86371.
+//
+// Solidity: function getOfferStatus(uint64 offerId) view returns(bool exists, uint8 status)
+func (_OnRamp *OnRampCaller) GetOfferStatus(opts *bind.CallOpts, offerId uint64) (struct {
+	Exists bool
+	Status uint8
+}, error) {
+	var out []interface{}
+	err := _OnRamp.contract.Call(opts, &out, "getOfferStatus", offerId)
+
+	outstruct := new(struct {
+		Exists bool
+		Status uint8
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Exists = *abi.ConvertType(out[0], new(bool)).(*bool)
+	outstruct.Status = *abi.ConvertType(out[1], new(uint8)).(*uint8)
+
+	return *outstruct, err
+
+}
+
+// GetOfferStatus is a free data retrieval call binding the contract method 0x91d86371.
+//
+// Solidity: function getOfferStatus(uint64 offerId) view returns(bool exists, uint8 status)
+func (_OnRamp *OnRampSession) GetOfferStatus(offerId uint64) (struct {
+	Exists bool
+	Status uint8
+}, error) {
+	return _OnRamp.Contract.GetOfferStatus(&_OnRamp.CallOpts, offerId)
+}
+
+// GetOfferStatus is a free data retrieval call binding the contract method 0x91d86371.
+//
+// Solidity: function getOfferStatus(uint64 offerId) view returns(bool exists, uint8 status)
+func (_OnRamp *OnRampCallerSession) GetOfferStatus(offerId uint64) (struct {
+	Exists bool
+	Status uint8
+}, error) {
+	return _OnRamp.Contract.GetOfferStatus(&_OnRamp.CallOpts, offerId)
+}
+
+// GetPendingOffers is a free data retrieval call binding the contract method 0x0753c9e3.
+//
+// Solidity: function getPendingOffers() view returns(uint64[])
+func (_OnRamp *OnRampCaller) GetPendingOffers(opts *bind.CallOpts) ([]uint64, error) {
+	var out []interface{}
+	err := _OnRamp.contract.Call(opts, &out, "getPendingOffers")
+
+	if err != nil {
+		return *new([]uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]uint64)).(*[]uint64)
+
+	return out0, err
+
+}
+
+// GetPendingOffers is a free data retrieval call binding the contract method 0x0753c9e3.
+//
+// Solidity: function getPendingOffers() view returns(uint64[])
+func (_OnRamp *OnRampSession) GetPendingOffers() ([]uint64, error) {
+	return _OnRamp.Contract.GetPendingOffers(&_OnRamp.CallOpts)
+}
+
+// GetPendingOffers is a free data retrieval call binding the contract method 0x0753c9e3.
+//
+// Solidity: function getPendingOffers() view returns(uint64[])
+func (_OnRamp *OnRampCallerSession) GetPendingOffers() ([]uint64, error) {
+	return _OnRamp.Contract.GetPendingOffers(&_OnRamp.CallOpts)
+}
+
+// GetTotalOffers is a free data retrieval call binding the contract method 0xfbf6c2c7.
+//
+// Solidity: function getTotalOffers() view returns(uint64)
+func (_OnRamp *OnRampCaller) GetTotalOffers(opts *bind.CallOpts) (uint64, error) {
+	var out []interface{}
+	err := _OnRamp.contract.Call(opts, &out, "getTotalOffers")
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// GetTotalOffers is a free data retrieval call binding the contract method 0xfbf6c2c7.
+//
+// Solidity: function getTotalOffers() view returns(uint64)
+func (_OnRamp *OnRampSession) GetTotalOffers() (uint64, error) {
+	return _OnRamp.Contract.GetTotalOffers(&_OnRamp.CallOpts)
+}
+
+// GetTotalOffers is a free data retrieval call binding the contract method 0xfbf6c2c7.
+//
+// Solidity: function getTotalOffers() view returns(uint64)
+func (_OnRamp *OnRampCallerSession) GetTotalOffers() (uint64, error) {
+	return _OnRamp.Contract.GetTotalOffers(&_OnRamp.CallOpts)
+}
+
+// IsOfferAggregated is a free data retrieval call binding the contract method 0xb3b10ed2.
+//
+// Solidity: function isOfferAggregated(uint64 ) view returns(bool)
+func (_OnRamp *OnRampCaller) IsOfferAggregated(opts *bind.CallOpts, arg0 uint64) (bool, error) {
+	var out []interface{}
+	err := _OnRamp.contract.Call(opts, &out, "isOfferAggregated", arg0)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsOfferAggregated is a free data retrieval call binding the contract method 0xb3b10ed2.
+//
+// Solidity: function isOfferAggregated(uint64 ) view returns(bool)
+func (_OnRamp *OnRampSession) IsOfferAggregated(arg0 uint64) (bool, error) {
+	return _OnRamp.Contract.IsOfferAggregated(&_OnRamp.CallOpts, arg0)
+}
+
+// IsOfferAggregated is a free data retrieval call binding the contract method 0xb3b10ed2.
+//
+// Solidity: function isOfferAggregated(uint64 ) view returns(bool)
+func (_OnRamp *OnRampCallerSession) IsOfferAggregated(arg0 uint64) (bool, error) {
+	return _OnRamp.Contract.IsOfferAggregated(&_OnRamp.CallOpts, arg0)
+}
+
+// Offers is a free data retrieval call binding the contract method 0x2ebb620c.
+//
+// Solidity: function offers(uint64 ) view returns(bytes commP, uint64 size, string cid, string location, uint256 amount, address token, uint8 status)
+func (_OnRamp *OnRampCaller) Offers(opts *bind.CallOpts, arg0 uint64) (struct {
+	CommP    []byte
+	Size     uint64
+	Cid      string
+	Location string
+	Amount   *big.Int
+	Token    common.Address
+	Status   uint8
+}, error) {
+	var out []interface{}
+	err := _OnRamp.contract.Call(opts, &out, "offers", arg0)
+
+	outstruct := new(struct {
+		CommP    []byte
+		Size     uint64
+		Cid      string
+		Location string
+		Amount   *big.Int
+		Token    common.Address
+		Status   uint8
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.CommP = *abi.ConvertType(out[0], new([]byte)).(*[]byte)
+	outstruct.Size = *abi.ConvertType(out[1], new(uint64)).(*uint64)
+	outstruct.Cid = *abi.ConvertType(out[2], new(string)).(*string)
+	outstruct.Location = *abi.ConvertType(out[3], new(string)).(*string)
+	outstruct.Amount = *abi.ConvertType(out[4], new(*big.Int)).(**big.Int)
+	outstruct.Token = *abi.ConvertType(out[5], new(common.Address)).(*common.Address)
+	outstruct.Status = *abi.ConvertType(out[6], new(uint8)).(*uint8)
+
+	return *outstruct, err
+
+}
+
+// Offers is a free data retrieval call binding the contract method 0x2ebb620c.
+//
+// Solidity: function offers(uint64 ) view returns(bytes commP, uint64 size, string cid, string location, uint256 amount, address token, uint8 status)
+func (_OnRamp *OnRampSession) Offers(arg0 uint64) (struct {
+	CommP    []byte
+	Size     uint64
+	Cid      string
+	Location string
+	Amount   *big.Int
+	Token    common.Address
+	Status   uint8
+}, error) {
+	return _OnRamp.Contract.Offers(&_OnRamp.CallOpts, arg0)
+}
+
+// Offers is a free data retrieval call binding the contract method 0x2ebb620c.
+//
+// Solidity: function offers(uint64 ) view returns(bytes commP, uint64 size, string cid, string location, uint256 amount, address token, uint8 status)
+func (_OnRamp *OnRampCallerSession) Offers(arg0 uint64) (struct {
+	CommP    []byte
+	Size     uint64
+	Cid      string
+	Location string
+	Amount   *big.Int
+	Token    common.Address
+	Status   uint8
+}, error) {
+	return _OnRamp.Contract.Offers(&_OnRamp.CallOpts, arg0)
+}
+
+// ProvenAggregations is a free data retrieval call binding the contract method 0x66549638.
+//
+// Solidity: function provenAggregations(uint64 ) view returns(bool)
+func (_OnRamp *OnRampCaller) ProvenAggregations(opts *bind.CallOpts, arg0 uint64) (bool, error) {
+	var out []interface{}
+	err := _OnRamp.contract.Call(opts, &out, "provenAggregations", arg0)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// ProvenAggregations is a free data retrieval call binding the contract method 0x66549638.
+//
+// Solidity: function provenAggregations(uint64 ) view returns(bool)
+func (_OnRamp *OnRampSession) ProvenAggregations(arg0 uint64) (bool, error) {
+	return _OnRamp.Contract.ProvenAggregations(&_OnRamp.CallOpts, arg0)
+}
+
+// ProvenAggregations is a free data retrieval call binding the contract method 0x66549638.
+//
+// Solidity: function provenAggregations(uint64 ) view returns(bool)
+func (_OnRamp *OnRampCallerSession) ProvenAggregations(arg0 uint64) (bool, error) {
+	return _OnRamp.Contract.ProvenAggregations(&_OnRamp.CallOpts, arg0)
+}
+
+// Verify is a free data retrieval call binding the contract method 0x51362bfe.
+//
+// Solidity: function verify((uint64,bytes32[]) proof, bytes32 root, bytes32 leaf) pure returns(bool)
+func (_OnRamp *OnRampCaller) Verify(opts *bind.CallOpts, proof PODSIVerifierProofData, root [32]byte, leaf [32]byte) (bool, error) {
+	var out []interface{}
+	err := _OnRamp.contract.Call(opts, &out, "verify", proof, root, leaf)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// Verify is a free data retrieval call binding the contract method 0x51362bfe.
+//
+// Solidity: function verify((uint64,bytes32[]) proof, bytes32 root, bytes32 leaf) pure returns(bool)
+func (_OnRamp *OnRampSession) Verify(proof PODSIVerifierProofData, root [32]byte, leaf [32]byte) (bool, error) {
+	return _OnRamp.Contract.Verify(&_OnRamp.CallOpts, proof, root, leaf)
+}
+
+// Verify is a free data retrieval call binding the contract method 0x51362bfe.
+//
+// Solidity: function verify((uint64,bytes32[]) proof, bytes32 root, bytes32 leaf) pure returns(bool)
+func (_OnRamp *OnRampCallerSession) Verify(proof PODSIVerifierProofData, root [32]byte, leaf [32]byte) (bool, error) {
+	return _OnRamp.Contract.Verify(&_OnRamp.CallOpts, proof, root, leaf)
+}
+
+// VerifyDataStored is a free data retrieval call binding the contract method 0x95431222.
+//
+// Solidity: function verifyDataStored(uint64 aggID, uint256 idx, uint64 offerID) view returns(bool)
+func (_OnRamp *OnRampCaller) VerifyDataStored(opts *bind.CallOpts, aggID uint64, idx *big.Int, offerID uint64) (bool, error) {
+	var out []interface{}
+	err := _OnRamp.contract.Call(opts, &out, "verifyDataStored", aggID, idx, offerID)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// VerifyDataStored is a free data retrieval call binding the contract method 0x95431222.
+//
+// Solidity: function verifyDataStored(uint64 aggID, uint256 idx, uint64 offerID) view returns(bool)
+func (_OnRamp *OnRampSession) VerifyDataStored(aggID uint64, idx *big.Int, offerID uint64) (bool, error) {
+	return _OnRamp.Contract.VerifyDataStored(&_OnRamp.CallOpts, aggID, idx, offerID)
+}
+
+// VerifyDataStored is a free data retrieval call binding the contract method 0x95431222.
+//
+// Solidity: function verifyDataStored(uint64 aggID, uint256 idx, uint64 offerID) view returns(bool)
+func (_OnRamp *OnRampCallerSession) VerifyDataStored(aggID uint64, idx *big.Int, offerID uint64) (bool, error) {
+	return _OnRamp.Contract.VerifyDataStored(&_OnRamp.CallOpts, aggID, idx, offerID)
+}
+
+// VerifyInclusion is a free data retrieval call binding the contract method 0x533212f6.
+//
+// Solidity: function verifyInclusion((uint64,bytes32[]) proofSubtree, (uint64,bytes32[]) proofIndex, bytes32 root, bytes32 commPc, uint64 sizePc) pure returns(bool)
+func (_OnRamp *OnRampCaller) VerifyInclusion(opts *bind.CallOpts, proofSubtree PODSIVerifierProofData, proofIndex PODSIVerifierProofData, root [32]byte, commPc [32]byte, sizePc uint64) (bool, error) {
+	var out []interface{}
+	err := _OnRamp.contract.Call(opts, &out, "verifyInclusion", proofSubtree, proofIndex, root, commPc, sizePc)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// VerifyInclusion is a free data retrieval call binding the contract method 0x533212f6.
+//
+// Solidity: function verifyInclusion((uint64,bytes32[]) proofSubtree, (uint64,bytes32[]) proofIndex, bytes32 root, bytes32 commPc, uint64 sizePc) pure returns(bool)
+func (_OnRamp *OnRampSession) VerifyInclusion(proofSubtree PODSIVerifierProofData, proofIndex PODSIVerifierProofData, root [32]byte, commPc [32]byte, sizePc uint64) (bool, error) {
+	return _OnRamp.Contract.VerifyInclusion(&_OnRamp.CallOpts, proofSubtree, proofIndex, root, commPc, sizePc)
+}
+
+// VerifyInclusion is a free data retrieval call binding the contract method 0x533212f6.
+//
+// Solidity: function verifyInclusion((uint64,bytes32[]) proofSubtree, (uint64,bytes32[]) proofIndex, bytes32 root, bytes32 commPc, uint64 sizePc) pure returns(bool)
+func (_OnRamp *OnRampCallerSession) VerifyInclusion(proofSubtree PODSIVerifierProofData, proofIndex PODSIVerifierProofData, root [32]byte, commPc [32]byte, sizePc uint64) (bool, error) {
+	return _OnRamp.Contract.VerifyInclusion(&_OnRamp.CallOpts, proofSubtree, proofIndex, root, commPc, sizePc)
+}
+
+// CommitAggregate is a paid mutator transaction binding the contract method 0x44662828.
+//
+// Solidity: function commitAggregate(bytes commP, uint64[] claimedIDs, (uint64,bytes32[])[] inclusionProofs, (uint64,bytes32[])[] indexProofs, address payoutAddr) returns()
+func (_OnRamp *OnRampTransactor) CommitAggregate(opts *bind.TransactOpts, commP []byte, claimedIDs []uint64, inclusionProofs []PODSIVerifierProofData, indexProofs []PODSIVerifierProofData, payoutAddr common.Address) (*types.Transaction, error) {
+	return _OnRamp.contract.Transact(opts, "commitAggregate", commP, claimedIDs, inclusionProofs, indexProofs, payoutAddr)
+}
+
+// CommitAggregate is a paid mutator transaction binding the contract method 0x44662828.
+//
+// Solidity: function commitAggregate(bytes commP, uint64[] claimedIDs, (uint64,bytes32[])[] inclusionProofs, (uint64,bytes32[])[] indexProofs, address payoutAddr) returns()
+func (_OnRamp *OnRampSession) CommitAggregate(commP []byte, claimedIDs []uint64, inclusionProofs []PODSIVerifierProofData, indexProofs []PODSIVerifierProofData, payoutAddr common.Address) (*types.Transaction, error) {
+	return _OnRamp.Contract.CommitAggregate(&_OnRamp.TransactOpts, commP, claimedIDs, inclusionProofs, indexProofs, payoutAddr)
+}
+
+// CommitAggregate is a paid mutator transaction binding the contract method 0x44662828.
+//
+// Solidity: function commitAggregate(bytes commP, uint64[] claimedIDs, (uint64,bytes32[])[] inclusionProofs, (uint64,bytes32[])[] indexProofs, address payoutAddr) returns()
+func (_OnRamp *OnRampTransactorSession) CommitAggregate(commP []byte, claimedIDs []uint64, inclusionProofs []PODSIVerifierProofData, indexProofs []PODSIVerifierProofData, payoutAddr common.Address) (*types.Transaction, error) {
+	return _OnRamp.Contract.CommitAggregate(&_OnRamp.TransactOpts, commP, claimedIDs, inclusionProofs, indexProofs, payoutAddr)
+}
+
+// OfferData is a paid mutator transaction binding the contract method 0x1c3c18c7.
+//
+// Solidity: function offerData((bytes,uint64,string,string,uint256,address,uint8) offer) payable returns(uint64)
+func (_OnRamp *OnRampTransactor) OfferData(opts *bind.TransactOpts, offer OnRampContractOffer) (*types.Transaction, error) {
+	return _OnRamp.contract.Transact(opts, "offerData", offer)
+}
+
+// OfferData is a paid mutator transaction binding the contract method 0x1c3c18c7.
+//
+// Solidity: function offerData((bytes,uint64,string,string,uint256,address,uint8) offer) payable returns(uint64)
+func (_OnRamp *OnRampSession) OfferData(offer OnRampContractOffer) (*types.Transaction, error) {
+	return _OnRamp.Contract.OfferData(&_OnRamp.TransactOpts, offer)
+}
+
+// OfferData is a paid mutator transaction binding the contract method 0x1c3c18c7.
+//
+// Solidity: function offerData((bytes,uint64,string,string,uint256,address,uint8) offer) payable returns(uint64)
+func (_OnRamp *OnRampTransactorSession) OfferData(offer OnRampContractOffer) (*types.Transaction, error) {
+	return _OnRamp.Contract.OfferData(&_OnRamp.TransactOpts, offer)
+}
+
+// ProveDataStored is a paid mutator transaction binding the contract method 0xa874bab7.
+//
+// Solidity: function proveDataStored((bytes,int64,uint64,uint256) attestation) returns()
+func (_OnRamp *OnRampTransactor) ProveDataStored(opts *bind.TransactOpts, attestation DataAttestation) (*types.Transaction, error) {
+	return _OnRamp.contract.Transact(opts, "proveDataStored", attestation)
+}
+
+// ProveDataStored is a paid mutator transaction binding the contract method 0xa874bab7.
+//
+// Solidity: function proveDataStored((bytes,int64,uint64,uint256) attestation) returns()
+func (_OnRamp *OnRampSession) ProveDataStored(attestation DataAttestation) (*types.Transaction, error) {
+	return _OnRamp.Contract.ProveDataStored(&_OnRamp.TransactOpts, attestation)
+}
+
+// ProveDataStored is a paid mutator transaction binding the contract method 0xa874bab7.
+//
+// Solidity: function proveDataStored((bytes,int64,uint64,uint256) attestation) returns()
+func (_OnRamp *OnRampTransactorSession) ProveDataStored(attestation DataAttestation) (*types.Transaction, error) {
+	return _OnRamp.Contract.ProveDataStored(&_OnRamp.TransactOpts, attestation)
+}
+
+// SetOracle is a paid mutator transaction binding the contract method 0x7adbf973.
+//
+// Solidity: function setOracle(address oracle_) returns()
+func (_OnRamp *OnRampTransactor) SetOracle(opts *bind.TransactOpts, oracle_ common.Address) (*types.Transaction, error) {
+	return _OnRamp.contract.Transact(opts, "setOracle", oracle_)
+}
+
+// SetOracle is a paid mutator transaction binding the contract method 0x7adbf973.
+//
+// Solidity: function setOracle(address oracle_) returns()
+func (_OnRamp *OnRampSession) SetOracle(oracle_ common.Address) (*types.Transaction, error) {
+	return _OnRamp.Contract.SetOracle(&_OnRamp.TransactOpts, oracle_)
+}
+
+// SetOracle is a paid mutator transaction binding the contract method 0x7adbf973.
+//
+// Solidity: function setOracle(address oracle_) returns()
+func (_OnRamp *OnRampTransactorSession) SetOracle(oracle_ common.Address) (*types.Transaction, error) {
+	return _OnRamp.Contract.SetOracle(&_OnRamp.TransactOpts, oracle_)
+}
+
+// OnRampAggregationCommittedIterator is returned from FilterAggregationCommitted and is used to iterate over the raw logs and unpacked data for AggregationCommitted events raised by the OnRamp contract.
+type OnRampAggregationCommittedIterator struct {
+	Event *OnRampAggregationCommitted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *OnRampAggregationCommittedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(OnRampAggregationCommitted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(OnRampAggregationCommitted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *OnRampAggregationCommittedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *OnRampAggregationCommittedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// OnRampAggregationCommitted represents a AggregationCommitted event raised by the OnRamp contract.
+type OnRampAggregationCommitted struct {
+	AggId      uint64
+	CommP      []byte
+	OfferIDs   []uint64
+	PayoutAddr common.Address
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterAggregationCommitted is a free log retrieval operation binding the contract event 0x729ba8915d400646810af1b7dda6ff46085db74141c648c571e2fe873bbe73c5.
+//
+// Solidity: event AggregationCommitted(uint64 aggId, bytes commP, uint64[] offerIDs, address payoutAddr)
+func (_OnRamp *OnRampFilterer) FilterAggregationCommitted(opts *bind.FilterOpts) (*OnRampAggregationCommittedIterator, error) {
+
+	logs, sub, err := _OnRamp.contract.FilterLogs(opts, "AggregationCommitted")
+	if err != nil {
+		return nil, err
+	}
+	return &OnRampAggregationCommittedIterator{contract: _OnRamp.contract, event: "AggregationCommitted", logs: logs, sub: sub}, nil
+}
+
+// WatchAggregationCommitted is a free log subscription operation binding the contract event 0x729ba8915d400646810af1b7dda6ff46085db74141c648c571e2fe873bbe73c5.
+//
+// Solidity: event AggregationCommitted(uint64 aggId, bytes commP, uint64[] offerIDs, address payoutAddr)
+func (_OnRamp *OnRampFilterer) WatchAggregationCommitted(opts *bind.WatchOpts, sink chan<- *OnRampAggregationCommitted) (event.Subscription, error) {
+
+	logs, sub, err := _OnRamp.contract.WatchLogs(opts, "AggregationCommitted")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(OnRampAggregationCommitted)
+				if err := _OnRamp.contract.UnpackLog(event, "AggregationCommitted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAggregationCommitted is a log parse operation binding the contract event 0x729ba8915d400646810af1b7dda6ff46085db74141c648c571e2fe873bbe73c5.
+//
+// Solidity: event AggregationCommitted(uint64 aggId, bytes commP, uint64[] offerIDs, address payoutAddr)
+func (_OnRamp *OnRampFilterer) ParseAggregationCommitted(log types.Log) (*OnRampAggregationCommitted, error) {
+	event := new(OnRampAggregationCommitted)
+	if err := _OnRamp.contract.UnpackLog(event, "AggregationCommitted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// OnRampDataReadyIterator is returned from FilterDataReady and is used to iterate over the raw logs and unpacked data for DataReady events raised by the OnRamp contract.
+type OnRampDataReadyIterator struct {
+	Event *OnRampDataReady // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *OnRampDataReadyIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(OnRampDataReady)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(OnRampDataReady)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *OnRampDataReadyIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *OnRampDataReadyIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// OnRampDataReady represents a DataReady event raised by the OnRamp contract.
+type OnRampDataReady struct {
+	Offer OnRampContractOffer
+	Id    uint64
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterDataReady is a free log retrieval operation binding the contract event 0x21035c70966e0172f63054a5f24a0a4838bf1f2bd1faedeb942fce40f1c72ef6.
+//
+// Solidity: event DataReady((bytes,uint64,string,string,uint256,address,uint8) offer, uint64 id)
+func (_OnRamp *OnRampFilterer) FilterDataReady(opts *bind.FilterOpts) (*OnRampDataReadyIterator, error) {
+
+	logs, sub, err := _OnRamp.contract.FilterLogs(opts, "DataReady")
+	if err != nil {
+		return nil, err
+	}
+	return &OnRampDataReadyIterator{contract: _OnRamp.contract, event: "DataReady", logs: logs, sub: sub}, nil
+}
+
+// WatchDataReady is a free log subscription operation binding the contract event 0x21035c70966e0172f63054a5f24a0a4838bf1f2bd1faedeb942fce40f1c72ef6.
+//
+// Solidity: event DataReady((bytes,uint64,string,string,uint256,address,uint8) offer, uint64 id)
+func (_OnRamp *OnRampFilterer) WatchDataReady(opts *bind.WatchOpts, sink chan<- *OnRampDataReady) (event.Subscription, error) {
+
+	logs, sub, err := _OnRamp.contract.WatchLogs(opts, "DataReady")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(OnRampDataReady)
+				if err := _OnRamp.contract.UnpackLog(event, "DataReady", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDataReady is a log parse operation binding the contract event 0x21035c70966e0172f63054a5f24a0a4838bf1f2bd1faedeb942fce40f1c72ef6.
+//
+// Solidity: event DataReady((bytes,uint64,string,string,uint256,address,uint8) offer, uint64 id)
+func (_OnRamp *OnRampFilterer) ParseDataReady(log types.Log) (*OnRampDataReady, error) {
+	event := new(OnRampDataReady)
+	if err := _OnRamp.contract.UnpackLog(event, "DataReady", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// OnRampProveDataStoredIterator is returned from FilterProveDataStored and is used to iterate over the raw logs and unpacked data for ProveDataStored events raised by the OnRamp contract.
+type OnRampProveDataStoredIterator struct {
+	Event *OnRampProveDataStored // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *OnRampProveDataStoredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(OnRampProveDataStored)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(OnRampProveDataStored)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *OnRampProveDataStoredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *OnRampProveDataStoredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// OnRampProveDataStored represents a ProveDataStored event raised by the OnRamp contract.
+type OnRampProveDataStored struct {
+	CommP  []byte
+	DealID uint64
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterProveDataStored is a free log retrieval operation binding the contract event 0x375e56e5e8ad6d7121c3900b618f3b453d961dd232f5db2fcca2de523beb86fe.
+//
+// Solidity: event ProveDataStored(bytes commP, uint64 dealID)
+func (_OnRamp *OnRampFilterer) FilterProveDataStored(opts *bind.FilterOpts) (*OnRampProveDataStoredIterator, error) {
+
+	logs, sub, err := _OnRamp.contract.FilterLogs(opts, "ProveDataStored")
+	if err != nil {
+		return nil, err
+	}
+	return &OnRampProveDataStoredIterator{contract: _OnRamp.contract, event: "ProveDataStored", logs: logs, sub: sub}, nil
+}
+
+// WatchProveDataStored is a free log subscription operation binding the contract event 0x375e56e5e8ad6d7121c3900b618f3b453d961dd232f5db2fcca2de523beb86fe.
+//
+// Solidity: event ProveDataStored(bytes commP, uint64 dealID)
+func (_OnRamp *OnRampFilterer) WatchProveDataStored(opts *bind.WatchOpts, sink chan<- *OnRampProveDataStored) (event.Subscription, error) {
+
+	logs, sub, err := _OnRamp.contract.WatchLogs(opts, "ProveDataStored")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(OnRampProveDataStored)
+				if err := _OnRamp.contract.UnpackLog(event, "ProveDataStored", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseProveDataStored is a log parse operation binding the contract event 0x375e56e5e8ad6d7121c3900b618f3b453d961dd232f5db2fcca2de523beb86fe.
+//
+// Solidity: event ProveDataStored(bytes commP, uint64 dealID)
+func (_OnRamp *OnRampFilterer) ParseProveDataStored(log types.Log) (*OnRampProveDataStored, error) {
+	event := new(OnRampProveDataStored)
+	if err := _OnRamp.contract.UnpackLog(event, "ProveDataStored", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
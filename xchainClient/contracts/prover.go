@@ -0,0 +1,471 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// ProverMetaData contains all meta data concerning the Prover contract.
+var ProverMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"name\":\"AUTHENTICATE_MESSAGE_METHOD_NUM\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"DATACAP_ACTOR_ETH_ADDRESS\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"DATACAP_RECEIVER_HOOK_METHOD_NUM\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MARKET_ACTOR_ETH_ADDRESS\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MARKET_NOTIFY_DEAL_METHOD_NUM\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"bridgeContract\",\"outputs\":[{\"internalType\":\"contractIBridgeContract\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"method\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"params\",\"type\":\"bytes\"}],\"name\":\"handle_filecoin_method\",\"outputs\":[{\"internalType\":\"uint32\",\"name\":\"\",\"type\":\"uint32\"},{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"name\":\"pieceDeals\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"name\":\"pieceStatus\",\"outputs\":[{\"internalType\":\"enumDealClient.Status\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_bridgeContract\",\"type\":\"address\"}],\"name\":\"setBridgeContract\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+}
+
+// ProverABI is the input ABI used to generate the binding from.
+// Deprecated: Use ProverMetaData.ABI instead.
+var ProverABI = ProverMetaData.ABI
+
+// Prover is an auto generated Go binding around an Ethereum contract.
+type Prover struct {
+	ProverCaller     // Read-only binding to the contract
+	ProverTransactor // Write-only binding to the contract
+	ProverFilterer   // Log filterer for contract events
+}
+
+// ProverCaller is an auto generated read-only Go binding around an Ethereum contract.
+type ProverCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ProverTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type ProverTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ProverFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type ProverFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ProverSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type ProverSession struct {
+	Contract     *Prover           // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// ProverCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type ProverCallerSession struct {
+	Contract *ProverCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts // Call options to use throughout this session
+}
+
+// ProverTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type ProverTransactorSession struct {
+	Contract     *ProverTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// ProverRaw is an auto generated low-level Go binding around an Ethereum contract.
+type ProverRaw struct {
+	Contract *Prover // Generic contract binding to access the raw methods on
+}
+
+// ProverCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type ProverCallerRaw struct {
+	Contract *ProverCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// ProverTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type ProverTransactorRaw struct {
+	Contract *ProverTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewProver creates a new instance of Prover, bound to a specific deployed contract.
+func NewProver(address common.Address, backend bind.ContractBackend) (*Prover, error) {
+	contract, err := bindProver(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Prover{ProverCaller: ProverCaller{contract: contract}, ProverTransactor: ProverTransactor{contract: contract}, ProverFilterer: ProverFilterer{contract: contract}}, nil
+}
+
+// NewProverCaller creates a new read-only instance of Prover, bound to a specific deployed contract.
+func NewProverCaller(address common.Address, caller bind.ContractCaller) (*ProverCaller, error) {
+	contract, err := bindProver(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ProverCaller{contract: contract}, nil
+}
+
+// NewProverTransactor creates a new write-only instance of Prover, bound to a specific deployed contract.
+func NewProverTransactor(address common.Address, transactor bind.ContractTransactor) (*ProverTransactor, error) {
+	contract, err := bindProver(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ProverTransactor{contract: contract}, nil
+}
+
+// NewProverFilterer creates a new log filterer instance of Prover, bound to a specific deployed contract.
+func NewProverFilterer(address common.Address, filterer bind.ContractFilterer) (*ProverFilterer, error) {
+	contract, err := bindProver(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ProverFilterer{contract: contract}, nil
+}
+
+// bindProver binds a generic wrapper to an already deployed contract.
+func bindProver(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := ProverMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Prover *ProverRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Prover.Contract.ProverCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Prover *ProverRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Prover.Contract.ProverTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Prover *ProverRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Prover.Contract.ProverTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Prover *ProverCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Prover.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Prover *ProverTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Prover.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Prover *ProverTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Prover.Contract.contract.Transact(opts, method, params...)
+}
+
+// AUTHENTICATEMESSAGEMETHODNUM is a free data retrieval call binding the contract method 0x00706790.
+//
+// Solidity: function AUTHENTICATE_MESSAGE_METHOD_NUM() view returns(uint64)
+func (_Prover *ProverCaller) AUTHENTICATEMESSAGEMETHODNUM(opts *bind.CallOpts) (uint64, error) {
+	var out []interface{}
+	err := _Prover.contract.Call(opts, &out, "AUTHENTICATE_MESSAGE_METHOD_NUM")
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// AUTHENTICATEMESSAGEMETHODNUM is a free data retrieval call binding the contract method 0x00706790.
+//
+// Solidity: function AUTHENTICATE_MESSAGE_METHOD_NUM() view returns(uint64)
+func (_Prover *ProverSession) AUTHENTICATEMESSAGEMETHODNUM() (uint64, error) {
+	return _Prover.Contract.AUTHENTICATEMESSAGEMETHODNUM(&_Prover.CallOpts)
+}
+
+// AUTHENTICATEMESSAGEMETHODNUM is a free data retrieval call binding the contract method 0x00706790.
+//
+// Solidity: function AUTHENTICATE_MESSAGE_METHOD_NUM() view returns(uint64)
+func (_Prover *ProverCallerSession) AUTHENTICATEMESSAGEMETHODNUM() (uint64, error) {
+	return _Prover.Contract.AUTHENTICATEMESSAGEMETHODNUM(&_Prover.CallOpts)
+}
+
+// DATACAPACTORETHADDRESS is a free data retrieval call binding the contract method 0xbe965ce7.
+//
+// Solidity: function DATACAP_ACTOR_ETH_ADDRESS() view returns(address)
+func (_Prover *ProverCaller) DATACAPACTORETHADDRESS(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Prover.contract.Call(opts, &out, "DATACAP_ACTOR_ETH_ADDRESS")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// DATACAPACTORETHADDRESS is a free data retrieval call binding the contract method 0xbe965ce7.
+//
+// Solidity: function DATACAP_ACTOR_ETH_ADDRESS() view returns(address)
+func (_Prover *ProverSession) DATACAPACTORETHADDRESS() (common.Address, error) {
+	return _Prover.Contract.DATACAPACTORETHADDRESS(&_Prover.CallOpts)
+}
+
+// DATACAPACTORETHADDRESS is a free data retrieval call binding the contract method 0xbe965ce7.
+//
+// Solidity: function DATACAP_ACTOR_ETH_ADDRESS() view returns(address)
+func (_Prover *ProverCallerSession) DATACAPACTORETHADDRESS() (common.Address, error) {
+	return _Prover.Contract.DATACAPACTORETHADDRESS(&_Prover.CallOpts)
+}
+
+// DATACAPRECEIVERHOOKMETHODNUM is a free data retrieval call binding the contract method 0xb34ba252.
+//
+// Solidity: function DATACAP_RECEIVER_HOOK_METHOD_NUM() view returns(uint64)
+func (_Prover *ProverCaller) DATACAPRECEIVERHOOKMETHODNUM(opts *bind.CallOpts) (uint64, error) {
+	var out []interface{}
+	err := _Prover.contract.Call(opts, &out, "DATACAP_RECEIVER_HOOK_METHOD_NUM")
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// DATACAPRECEIVERHOOKMETHODNUM is a free data retrieval call binding the contract method 0xb34ba252.
+//
+// Solidity: function DATACAP_RECEIVER_HOOK_METHOD_NUM() view returns(uint64)
+func (_Prover *ProverSession) DATACAPRECEIVERHOOKMETHODNUM() (uint64, error) {
+	return _Prover.Contract.DATACAPRECEIVERHOOKMETHODNUM(&_Prover.CallOpts)
+}
+
+// DATACAPRECEIVERHOOKMETHODNUM is a free data retrieval call binding the contract method 0xb34ba252.
+//
+// Solidity: function DATACAP_RECEIVER_HOOK_METHOD_NUM() view returns(uint64)
+func (_Prover *ProverCallerSession) DATACAPRECEIVERHOOKMETHODNUM() (uint64, error) {
+	return _Prover.Contract.DATACAPRECEIVERHOOKMETHODNUM(&_Prover.CallOpts)
+}
+
+// MARKETACTORETHADDRESS is a free data retrieval call binding the contract method 0x29aa3d2a.
+//
+// Solidity: function MARKET_ACTOR_ETH_ADDRESS() view returns(address)
+func (_Prover *ProverCaller) MARKETACTORETHADDRESS(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Prover.contract.Call(opts, &out, "MARKET_ACTOR_ETH_ADDRESS")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// MARKETACTORETHADDRESS is a free data retrieval call binding the contract method 0x29aa3d2a.
+//
+// Solidity: function MARKET_ACTOR_ETH_ADDRESS() view returns(address)
+func (_Prover *ProverSession) MARKETACTORETHADDRESS() (common.Address, error) {
+	return _Prover.Contract.MARKETACTORETHADDRESS(&_Prover.CallOpts)
+}
+
+// MARKETACTORETHADDRESS is a free data retrieval call binding the contract method 0x29aa3d2a.
+//
+// Solidity: function MARKET_ACTOR_ETH_ADDRESS() view returns(address)
+func (_Prover *ProverCallerSession) MARKETACTORETHADDRESS() (common.Address, error) {
+	return _Prover.Contract.MARKETACTORETHADDRESS(&_Prover.CallOpts)
+}
+
+// MARKETNOTIFYDEALMETHODNUM is a free data retrieval call binding the contract method 0x6067f454.
+//
+// Solidity: function MARKET_NOTIFY_DEAL_METHOD_NUM() view returns(uint64)
+func (_Prover *ProverCaller) MARKETNOTIFYDEALMETHODNUM(opts *bind.CallOpts) (uint64, error) {
+	var out []interface{}
+	err := _Prover.contract.Call(opts, &out, "MARKET_NOTIFY_DEAL_METHOD_NUM")
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// MARKETNOTIFYDEALMETHODNUM is a free data retrieval call binding the contract method 0x6067f454.
+//
+// Solidity: function MARKET_NOTIFY_DEAL_METHOD_NUM() view returns(uint64)
+func (_Prover *ProverSession) MARKETNOTIFYDEALMETHODNUM() (uint64, error) {
+	return _Prover.Contract.MARKETNOTIFYDEALMETHODNUM(&_Prover.CallOpts)
+}
+
+// MARKETNOTIFYDEALMETHODNUM is a free data retrieval call binding the contract method 0x6067f454.
+//
+// Solidity: function MARKET_NOTIFY_DEAL_METHOD_NUM() view returns(uint64)
+func (_Prover *ProverCallerSession) MARKETNOTIFYDEALMETHODNUM() (uint64, error) {
+	return _Prover.Contract.MARKETNOTIFYDEALMETHODNUM(&_Prover.CallOpts)
+}
+
+// BridgeContract is a free data retrieval call binding the contract method 0xcd596583.
+//
+// Solidity: function bridgeContract() view returns(address)
+func (_Prover *ProverCaller) BridgeContract(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Prover.contract.Call(opts, &out, "bridgeContract")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// BridgeContract is a free data retrieval call binding the contract method 0xcd596583.
+//
+// Solidity: function bridgeContract() view returns(address)
+func (_Prover *ProverSession) BridgeContract() (common.Address, error) {
+	return _Prover.Contract.BridgeContract(&_Prover.CallOpts)
+}
+
+// BridgeContract is a free data retrieval call binding the contract method 0xcd596583.
+//
+// Solidity: function bridgeContract() view returns(address)
+func (_Prover *ProverCallerSession) BridgeContract() (common.Address, error) {
+	return _Prover.Contract.BridgeContract(&_Prover.CallOpts)
+}
+
+// PieceDeals is a free data retrieval call binding the contract method 0x0a0e0c91.
+//
+// Solidity: function pieceDeals(bytes ) view returns(uint64)
+func (_Prover *ProverCaller) PieceDeals(opts *bind.CallOpts, arg0 []byte) (uint64, error) {
+	var out []interface{}
+	err := _Prover.contract.Call(opts, &out, "pieceDeals", arg0)
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// PieceDeals is a free data retrieval call binding the contract method 0x0a0e0c91.
+//
+// Solidity: function pieceDeals(bytes ) view returns(uint64)
+func (_Prover *ProverSession) PieceDeals(arg0 []byte) (uint64, error) {
+	return _Prover.Contract.PieceDeals(&_Prover.CallOpts, arg0)
+}
+
+// PieceDeals is a free data retrieval call binding the contract method 0x0a0e0c91.
+//
+// Solidity: function pieceDeals(bytes ) view returns(uint64)
+func (_Prover *ProverCallerSession) PieceDeals(arg0 []byte) (uint64, error) {
+	return _Prover.Contract.PieceDeals(&_Prover.CallOpts, arg0)
+}
+
+// PieceStatus is a free data retrieval call binding the contract method 0xa6d1b7b8.
+//
+// Solidity: function pieceStatus(bytes ) view returns(uint8)
+func (_Prover *ProverCaller) PieceStatus(opts *bind.CallOpts, arg0 []byte) (uint8, error) {
+	var out []interface{}
+	err := _Prover.contract.Call(opts, &out, "pieceStatus", arg0)
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// PieceStatus is a free data retrieval call binding the contract method 0xa6d1b7b8.
+//
+// Solidity: function pieceStatus(bytes ) view returns(uint8)
+func (_Prover *ProverSession) PieceStatus(arg0 []byte) (uint8, error) {
+	return _Prover.Contract.PieceStatus(&_Prover.CallOpts, arg0)
+}
+
+// PieceStatus is a free data retrieval call binding the contract method 0xa6d1b7b8.
+//
+// Solidity: function pieceStatus(bytes ) view returns(uint8)
+func (_Prover *ProverCallerSession) PieceStatus(arg0 []byte) (uint8, error) {
+	return _Prover.Contract.PieceStatus(&_Prover.CallOpts, arg0)
+}
+
+// HandleFilecoinMethod is a paid mutator transaction binding the contract method 0x868e10c4.
+//
+// Solidity: function handle_filecoin_method(uint64 method, uint64 , bytes params) returns(uint32, uint64, bytes)
+func (_Prover *ProverTransactor) HandleFilecoinMethod(opts *bind.TransactOpts, method uint64, arg1 uint64, params []byte) (*types.Transaction, error) {
+	return _Prover.contract.Transact(opts, "handle_filecoin_method", method, arg1, params)
+}
+
+// HandleFilecoinMethod is a paid mutator transaction binding the contract method 0x868e10c4.
+//
+// Solidity: function handle_filecoin_method(uint64 method, uint64 , bytes params) returns(uint32, uint64, bytes)
+func (_Prover *ProverSession) HandleFilecoinMethod(method uint64, arg1 uint64, params []byte) (*types.Transaction, error) {
+	return _Prover.Contract.HandleFilecoinMethod(&_Prover.TransactOpts, method, arg1, params)
+}
+
+// HandleFilecoinMethod is a paid mutator transaction binding the contract method 0x868e10c4.
+//
+// Solidity: function handle_filecoin_method(uint64 method, uint64 , bytes params) returns(uint32, uint64, bytes)
+func (_Prover *ProverTransactorSession) HandleFilecoinMethod(method uint64, arg1 uint64, params []byte) (*types.Transaction, error) {
+	return _Prover.Contract.HandleFilecoinMethod(&_Prover.TransactOpts, method, arg1, params)
+}
+
+// SetBridgeContract is a paid mutator transaction binding the contract method 0x0b26cf66.
+//
+// Solidity: function setBridgeContract(address _bridgeContract) returns()
+func (_Prover *ProverTransactor) SetBridgeContract(opts *bind.TransactOpts, _bridgeContract common.Address) (*types.Transaction, error) {
+	return _Prover.contract.Transact(opts, "setBridgeContract", _bridgeContract)
+}
+
+// SetBridgeContract is a paid mutator transaction binding the contract method 0x0b26cf66.
+//
+// Solidity: function setBridgeContract(address _bridgeContract) returns()
+func (_Prover *ProverSession) SetBridgeContract(_bridgeContract common.Address) (*types.Transaction, error) {
+	return _Prover.Contract.SetBridgeContract(&_Prover.TransactOpts, _bridgeContract)
+}
+
+// SetBridgeContract is a paid mutator transaction binding the contract method 0x0b26cf66.
+//
+// Solidity: function setBridgeContract(address _bridgeContract) returns()
+func (_Prover *ProverTransactorSession) SetBridgeContract(_bridgeContract common.Address) (*types.Transaction, error) {
+	return _Prover.Contract.SetBridgeContract(&_Prover.TransactOpts, _bridgeContract)
+}
@@ -0,0 +1,1792 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// DealClientAxlDealRequest is an auto generated low-level Go binding around an user-defined struct.
+type DealClientAxlDealRequest struct {
+	PieceCid             []byte
+	PieceSize            uint64
+	VerifiedDeal         bool
+	Label                string
+	StartEpoch           int64
+	EndEpoch             int64
+	StoragePricePerEpoch *big.Int
+	ProviderCollateral   *big.Int
+	ClientCollateral     *big.Int
+	ExtraParamsVersion   uint64
+	ExtraParams          DealClientAxlExtraParams
+}
+
+// DealClientAxlExtraParams is an auto generated low-level Go binding around an user-defined struct.
+type DealClientAxlExtraParams struct {
+	LocationRef        string
+	CarSize            uint64
+	SkipIpniAnnounce   bool
+	RemoveUnsealedCopy bool
+}
+
+// ProverAxelarMetaData contains all meta data concerning the ProverAxelar contract.
+var ProverAxelarMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_gateway\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_gasReceiver\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[],\"name\":\"InvalidAddress\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"NotApprovedByGateway\",\"type\":\"error\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"dealId\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"commP\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"chainId\",\"type\":\"bytes\"}],\"name\":\"DealNotify\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"id\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"size\",\"type\":\"uint64\"},{\"indexed\":true,\"internalType\":\"bool\",\"name\":\"verified\",\"type\":\"bool\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"price\",\"type\":\"uint256\"}],\"name\":\"DealProposalCreate\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"dealId\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"commP\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"chainId\",\"type\":\"uint256\"}],\"name\":\"DealRelayed\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"string\",\"name\":\"received\",\"type\":\"string\"}],\"name\":\"ReceivedDataCap\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"string\",\"name\":\"destinationChain\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"destinationAddress\",\"type\":\"string\"}],\"name\":\"XChainProveDataStored\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"AUTHENTICATE_MESSAGE_METHOD_NUM\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"AXELAR_GAS_FEE\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"DATACAP_ACTOR_ETH_ADDRESS\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"DATACAP_RECEIVER_HOOK_METHOD_NUM\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MARKET_ACTOR_ETH_ADDRESS\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MARKET_NOTIFY_DEAL_METHOD_NUM\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"providerAddrData\",\"type\":\"bytes\"}],\"name\":\"addGasFunds\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"asciiBytes\",\"type\":\"bytes\"}],\"name\":\"asciiBytesToUint\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"pure\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"chainIdToSourceChain\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"chainName\",\"type\":\"string\"},{\"internalType\":\"address\",\"name\":\"sourceOracleAddress\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"name\":\"dealIdToIndex\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"idx\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"valid\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"dealRequests\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"piece_cid\",\"type\":\"bytes\"},{\"internalType\":\"uint64\",\"name\":\"piece_size\",\"type\":\"uint64\"},{\"internalType\":\"bool\",\"name\":\"verified_deal\",\"type\":\"bool\"},{\"internalType\":\"string\",\"name\":\"label\",\"type\":\"string\"},{\"internalType\":\"int64\",\"name\":\"start_epoch\",\"type\":\"int64\"},{\"internalType\":\"int64\",\"name\":\"end_epoch\",\"type\":\"int64\"},{\"internalType\":\"uint256\",\"name\":\"storage_price_per_epoch\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"provider_collateral\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"client_collateral\",\"type\":\"uint256\"},{\"internalType\":\"uint64\",\"name\":\"extra_params_version\",\"type\":\"uint64\"},{\"components\":[{\"internalType\":\"string\",\"name\":\"location_ref\",\"type\":\"string\"},{\"internalType\":\"uint64\",\"name\":\"car_size\",\"type\":\"uint64\"},{\"internalType\":\"bool\",\"name\":\"skip_ipni_announce\",\"type\":\"bool\"},{\"internalType\":\"bool\",\"name\":\"remove_unsealed_copy\",\"type\":\"bool\"}],\"internalType\":\"structDealClientAxl.ExtraParams\",\"name\":\"extra_params\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"commp\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"providerAddrData\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"gasFunds\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"chainId\",\"type\":\"uint256\"}],\"name\":\"debug_call\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"commandId\",\"type\":\"bytes32\"},{\"internalType\":\"string\",\"name\":\"sourceChain\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"sourceAddress\",\"type\":\"string\"},{\"internalType\":\"bytes\",\"name\":\"payload\",\"type\":\"bytes\"}],\"name\":\"execute\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"gasService\",\"outputs\":[{\"internalType\":\"contractIAxelarGasService\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"gateway\",\"outputs\":[{\"internalType\":\"contractIAxelarGateway\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"proposalId\",\"type\":\"bytes32\"}],\"name\":\"getDealProposal\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"proposalId\",\"type\":\"bytes32\"}],\"name\":\"getDealRequest\",\"outputs\":[{\"components\":[{\"internalType\":\"bytes\",\"name\":\"piece_cid\",\"type\":\"bytes\"},{\"internalType\":\"uint64\",\"name\":\"piece_size\",\"type\":\"uint64\"},{\"internalType\":\"bool\",\"name\":\"verified_deal\",\"type\":\"bool\"},{\"internalType\":\"string\",\"name\":\"label\",\"type\":\"string\"},{\"internalType\":\"int64\",\"name\":\"start_epoch\",\"type\":\"int64\"},{\"internalType\":\"int64\",\"name\":\"end_epoch\",\"type\":\"int64\"},{\"internalType\":\"uint256\",\"name\":\"storage_price_per_epoch\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"provider_collateral\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"client_collateral\",\"type\":\"uint256\"},{\"internalType\":\"uint64\",\"name\":\"extra_params_version\",\"type\":\"uint64\"},{\"components\":[{\"internalType\":\"string\",\"name\":\"location_ref\",\"type\":\"string\"},{\"internalType\":\"uint64\",\"name\":\"car_size\",\"type\":\"uint64\"},{\"internalType\":\"bool\",\"name\":\"skip_ipni_announce\",\"type\":\"bool\"},{\"internalType\":\"bool\",\"name\":\"remove_unsealed_copy\",\"type\":\"bool\"}],\"internalType\":\"structDealClientAxl.ExtraParams\",\"name\":\"extra_params\",\"type\":\"tuple\"}],\"internalType\":\"structDealClientAxl.DealRequest\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"proposalId\",\"type\":\"bytes32\"}],\"name\":\"getExtraParams\",\"outputs\":[{\"internalType\":\"bytes\",\"name\":\"extra_params\",\"type\":\"bytes\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"chainId\",\"type\":\"uint256\"}],\"name\":\"getSourceChain\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"},{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"method\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"params\",\"type\":\"bytes\"}],\"name\":\"handle_filecoin_method\",\"outputs\":[{\"internalType\":\"uint32\",\"name\":\"\",\"type\":\"uint32\"},{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"},{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"bytes\",\"name\":\"piece_cid\",\"type\":\"bytes\"},{\"internalType\":\"uint64\",\"name\":\"piece_size\",\"type\":\"uint64\"},{\"internalType\":\"bool\",\"name\":\"verified_deal\",\"type\":\"bool\"},{\"internalType\":\"string\",\"name\":\"label\",\"type\":\"string\"},{\"internalType\":\"int64\",\"name\":\"start_epoch\",\"type\":\"int64\"},{\"internalType\":\"int64\",\"name\":\"end_epoch\",\"type\":\"int64\"},{\"internalType\":\"uint256\",\"name\":\"storage_price_per_epoch\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"provider_collateral\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"client_collateral\",\"type\":\"uint256\"},{\"internalType\":\"uint64\",\"name\":\"extra_params_version\",\"type\":\"uint64\"},{\"components\":[{\"internalType\":\"string\",\"name\":\"location_ref\",\"type\":\"string\"},{\"internalType\":\"uint64\",\"name\":\"car_size\",\"type\":\"uint64\"},{\"internalType\":\"bool\",\"name\":\"skip_ipni_announce\",\"type\":\"bool\"},{\"internalType\":\"bool\",\"name\":\"remove_unsealed_copy\",\"type\":\"bool\"}],\"internalType\":\"structDealClientAxl.ExtraParams\",\"name\":\"extra_params\",\"type\":\"tuple\"}],\"internalType\":\"structDealClientAxl.DealRequest\",\"name\":\"deal\",\"type\":\"tuple\"}],\"name\":\"makeDealProposal\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"name\":\"pieceDeals\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"name\":\"pieceRequests\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"requestId\",\"type\":\"bytes32\"},{\"internalType\":\"bool\",\"name\":\"valid\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"name\":\"pieceStatus\",\"outputs\":[{\"internalType\":\"enumDealClientAxl.Status\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"\",\"type\":\"bytes\"}],\"name\":\"providerGasFunds\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"pieceCid\",\"type\":\"bytes\"}],\"name\":\"relayDeal\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256[]\",\"name\":\"chainIds\",\"type\":\"uint256[]\"},{\"internalType\":\"string[]\",\"name\":\"sourceChains\",\"type\":\"string[]\"},{\"internalType\":\"address[]\",\"name\":\"sourceOracleAddresses\",\"type\":\"address[]\"}],\"name\":\"setSourceChains\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"pieceCid\",\"type\":\"bytes\"}],\"name\":\"updateDealStatus\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+}
+
+// ProverAxelarABI is the input ABI used to generate the binding from.
+// Deprecated: Use ProverAxelarMetaData.ABI instead.
+var ProverAxelarABI = ProverAxelarMetaData.ABI
+
+// ProverAxelar is an auto generated Go binding around an Ethereum contract.
+type ProverAxelar struct {
+	ProverAxelarCaller     // Read-only binding to the contract
+	ProverAxelarTransactor // Write-only binding to the contract
+	ProverAxelarFilterer   // Log filterer for contract events
+}
+
+// ProverAxelarCaller is an auto generated read-only Go binding around an Ethereum contract.
+type ProverAxelarCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ProverAxelarTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type ProverAxelarTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ProverAxelarFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type ProverAxelarFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ProverAxelarSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type ProverAxelarSession struct {
+	Contract     *ProverAxelar     // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// ProverAxelarCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type ProverAxelarCallerSession struct {
+	Contract *ProverAxelarCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts       // Call options to use throughout this session
+}
+
+// ProverAxelarTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type ProverAxelarTransactorSession struct {
+	Contract     *ProverAxelarTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts       // Transaction auth options to use throughout this session
+}
+
+// ProverAxelarRaw is an auto generated low-level Go binding around an Ethereum contract.
+type ProverAxelarRaw struct {
+	Contract *ProverAxelar // Generic contract binding to access the raw methods on
+}
+
+// ProverAxelarCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type ProverAxelarCallerRaw struct {
+	Contract *ProverAxelarCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// ProverAxelarTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type ProverAxelarTransactorRaw struct {
+	Contract *ProverAxelarTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewProverAxelar creates a new instance of ProverAxelar, bound to a specific deployed contract.
+func NewProverAxelar(address common.Address, backend bind.ContractBackend) (*ProverAxelar, error) {
+	contract, err := bindProverAxelar(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &ProverAxelar{ProverAxelarCaller: ProverAxelarCaller{contract: contract}, ProverAxelarTransactor: ProverAxelarTransactor{contract: contract}, ProverAxelarFilterer: ProverAxelarFilterer{contract: contract}}, nil
+}
+
+// NewProverAxelarCaller creates a new read-only instance of ProverAxelar, bound to a specific deployed contract.
+func NewProverAxelarCaller(address common.Address, caller bind.ContractCaller) (*ProverAxelarCaller, error) {
+	contract, err := bindProverAxelar(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ProverAxelarCaller{contract: contract}, nil
+}
+
+// NewProverAxelarTransactor creates a new write-only instance of ProverAxelar, bound to a specific deployed contract.
+func NewProverAxelarTransactor(address common.Address, transactor bind.ContractTransactor) (*ProverAxelarTransactor, error) {
+	contract, err := bindProverAxelar(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ProverAxelarTransactor{contract: contract}, nil
+}
+
+// NewProverAxelarFilterer creates a new log filterer instance of ProverAxelar, bound to a specific deployed contract.
+func NewProverAxelarFilterer(address common.Address, filterer bind.ContractFilterer) (*ProverAxelarFilterer, error) {
+	contract, err := bindProverAxelar(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ProverAxelarFilterer{contract: contract}, nil
+}
+
+// bindProverAxelar binds a generic wrapper to an already deployed contract.
+func bindProverAxelar(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := ProverAxelarMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ProverAxelar *ProverAxelarRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ProverAxelar.Contract.ProverAxelarCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ProverAxelar *ProverAxelarRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ProverAxelar.Contract.ProverAxelarTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ProverAxelar *ProverAxelarRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ProverAxelar.Contract.ProverAxelarTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ProverAxelar *ProverAxelarCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ProverAxelar.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ProverAxelar *ProverAxelarTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ProverAxelar.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ProverAxelar *ProverAxelarTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ProverAxelar.Contract.contract.Transact(opts, method, params...)
+}
+
+// AUTHENTICATEMESSAGEMETHODNUM is a free data retrieval call binding the contract method 0x00706790.
+//
+// Solidity: function AUTHENTICATE_MESSAGE_METHOD_NUM() view returns(uint64)
+func (_ProverAxelar *ProverAxelarCaller) AUTHENTICATEMESSAGEMETHODNUM(opts *bind.CallOpts) (uint64, error) {
+	var out []interface{}
+	err := _ProverAxelar.contract.Call(opts, &out, "AUTHENTICATE_MESSAGE_METHOD_NUM")
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// AUTHENTICATEMESSAGEMETHODNUM is a free data retrieval call binding the contract method 0x00706790.
+//
+// Solidity: function AUTHENTICATE_MESSAGE_METHOD_NUM() view returns(uint64)
+func (_ProverAxelar *ProverAxelarSession) AUTHENTICATEMESSAGEMETHODNUM() (uint64, error) {
+	return _ProverAxelar.Contract.AUTHENTICATEMESSAGEMETHODNUM(&_ProverAxelar.CallOpts)
+}
+
+// AUTHENTICATEMESSAGEMETHODNUM is a free data retrieval call binding the contract method 0x00706790.
+//
+// Solidity: function AUTHENTICATE_MESSAGE_METHOD_NUM() view returns(uint64)
+func (_ProverAxelar *ProverAxelarCallerSession) AUTHENTICATEMESSAGEMETHODNUM() (uint64, error) {
+	return _ProverAxelar.Contract.AUTHENTICATEMESSAGEMETHODNUM(&_ProverAxelar.CallOpts)
+}
+
+// AXELARGASFEE is a free data retrieval call binding the contract method 0x38d6c5e0.
+//
+// Solidity: function AXELAR_GAS_FEE() view returns(uint256)
+func (_ProverAxelar *ProverAxelarCaller) AXELARGASFEE(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _ProverAxelar.contract.Call(opts, &out, "AXELAR_GAS_FEE")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// AXELARGASFEE is a free data retrieval call binding the contract method 0x38d6c5e0.
+//
+// Solidity: function AXELAR_GAS_FEE() view returns(uint256)
+func (_ProverAxelar *ProverAxelarSession) AXELARGASFEE() (*big.Int, error) {
+	return _ProverAxelar.Contract.AXELARGASFEE(&_ProverAxelar.CallOpts)
+}
+
+// AXELARGASFEE is a free data retrieval call binding the contract method 0x38d6c5e0.
+//
+// Solidity: function AXELAR_GAS_FEE() view returns(uint256)
+func (_ProverAxelar *ProverAxelarCallerSession) AXELARGASFEE() (*big.Int, error) {
+	return _ProverAxelar.Contract.AXELARGASFEE(&_ProverAxelar.CallOpts)
+}
+
+// DATACAPACTORETHADDRESS is a free data retrieval call binding the contract method 0xbe965ce7.
+//
+// Solidity: function DATACAP_ACTOR_ETH_ADDRESS() view returns(address)
+func (_ProverAxelar *ProverAxelarCaller) DATACAPACTORETHADDRESS(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _ProverAxelar.contract.Call(opts, &out, "DATACAP_ACTOR_ETH_ADDRESS")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// DATACAPACTORETHADDRESS is a free data retrieval call binding the contract method 0xbe965ce7.
+//
+// Solidity: function DATACAP_ACTOR_ETH_ADDRESS() view returns(address)
+func (_ProverAxelar *ProverAxelarSession) DATACAPACTORETHADDRESS() (common.Address, error) {
+	return _ProverAxelar.Contract.DATACAPACTORETHADDRESS(&_ProverAxelar.CallOpts)
+}
+
+// DATACAPACTORETHADDRESS is a free data retrieval call binding the contract method 0xbe965ce7.
+//
+// Solidity: function DATACAP_ACTOR_ETH_ADDRESS() view returns(address)
+func (_ProverAxelar *ProverAxelarCallerSession) DATACAPACTORETHADDRESS() (common.Address, error) {
+	return _ProverAxelar.Contract.DATACAPACTORETHADDRESS(&_ProverAxelar.CallOpts)
+}
+
+// DATACAPRECEIVERHOOKMETHODNUM is a free data retrieval call binding the contract method 0xb34ba252.
+//
+// Solidity: function DATACAP_RECEIVER_HOOK_METHOD_NUM() view returns(uint64)
+func (_ProverAxelar *ProverAxelarCaller) DATACAPRECEIVERHOOKMETHODNUM(opts *bind.CallOpts) (uint64, error) {
+	var out []interface{}
+	err := _ProverAxelar.contract.Call(opts, &out, "DATACAP_RECEIVER_HOOK_METHOD_NUM")
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// DATACAPRECEIVERHOOKMETHODNUM is a free data retrieval call binding the contract method 0xb34ba252.
+//
+// Solidity: function DATACAP_RECEIVER_HOOK_METHOD_NUM() view returns(uint64)
+func (_ProverAxelar *ProverAxelarSession) DATACAPRECEIVERHOOKMETHODNUM() (uint64, error) {
+	return _ProverAxelar.Contract.DATACAPRECEIVERHOOKMETHODNUM(&_ProverAxelar.CallOpts)
+}
+
+// DATACAPRECEIVERHOOKMETHODNUM is a free data retrieval call binding the contract method 0xb34ba252.
+//
+// Solidity: function DATACAP_RECEIVER_HOOK_METHOD_NUM() view returns(uint64)
+func (_ProverAxelar *ProverAxelarCallerSession) DATACAPRECEIVERHOOKMETHODNUM() (uint64, error) {
+	return _ProverAxelar.Contract.DATACAPRECEIVERHOOKMETHODNUM(&_ProverAxelar.CallOpts)
+}
+
+// MARKETACTORETHADDRESS is a free data retrieval call binding the contract method 0x29aa3d2a.
+//
+// Solidity: function MARKET_ACTOR_ETH_ADDRESS() view returns(address)
+func (_ProverAxelar *ProverAxelarCaller) MARKETACTORETHADDRESS(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _ProverAxelar.contract.Call(opts, &out, "MARKET_ACTOR_ETH_ADDRESS")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// MARKETACTORETHADDRESS is a free data retrieval call binding the contract method 0x29aa3d2a.
+//
+// Solidity: function MARKET_ACTOR_ETH_ADDRESS() view returns(address)
+func (_ProverAxelar *ProverAxelarSession) MARKETACTORETHADDRESS() (common.Address, error) {
+	return _ProverAxelar.Contract.MARKETACTORETHADDRESS(&_ProverAxelar.CallOpts)
+}
+
+// MARKETACTORETHADDRESS is a free data retrieval call binding the contract method 0x29aa3d2a.
+//
+// Solidity: function MARKET_ACTOR_ETH_ADDRESS() view returns(address)
+func (_ProverAxelar *ProverAxelarCallerSession) MARKETACTORETHADDRESS() (common.Address, error) {
+	return _ProverAxelar.Contract.MARKETACTORETHADDRESS(&_ProverAxelar.CallOpts)
+}
+
+// MARKETNOTIFYDEALMETHODNUM is a free data retrieval call binding the contract method 0x6067f454.
+//
+// Solidity: function MARKET_NOTIFY_DEAL_METHOD_NUM() view returns(uint64)
+func (_ProverAxelar *ProverAxelarCaller) MARKETNOTIFYDEALMETHODNUM(opts *bind.CallOpts) (uint64, error) {
+	var out []interface{}
+	err := _ProverAxelar.contract.Call(opts, &out, "MARKET_NOTIFY_DEAL_METHOD_NUM")
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// MARKETNOTIFYDEALMETHODNUM is a free data retrieval call binding the contract method 0x6067f454.
+//
+// Solidity: function MARKET_NOTIFY_DEAL_METHOD_NUM() view returns(uint64)
+func (_ProverAxelar *ProverAxelarSession) MARKETNOTIFYDEALMETHODNUM() (uint64, error) {
+	return _ProverAxelar.Contract.MARKETNOTIFYDEALMETHODNUM(&_ProverAxelar.CallOpts)
+}
+
+// MARKETNOTIFYDEALMETHODNUM is a free data retrieval call binding the contract method 0x6067f454.
+//
+// Solidity: function MARKET_NOTIFY_DEAL_METHOD_NUM() view returns(uint64)
+func (_ProverAxelar *ProverAxelarCallerSession) MARKETNOTIFYDEALMETHODNUM() (uint64, error) {
+	return _ProverAxelar.Contract.MARKETNOTIFYDEALMETHODNUM(&_ProverAxelar.CallOpts)
+}
+
+// AsciiBytesToUint is a free data retrieval call binding the contract method 0x2c4247c5.
+//
+// Solidity: function asciiBytesToUint(bytes asciiBytes) pure returns(uint256)
+func (_ProverAxelar *ProverAxelarCaller) AsciiBytesToUint(opts *bind.CallOpts, asciiBytes []byte) (*big.Int, error) {
+	var out []interface{}
+	err := _ProverAxelar.contract.Call(opts, &out, "asciiBytesToUint", asciiBytes)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// AsciiBytesToUint is a free data retrieval call binding the contract method 0x2c4247c5.
+//
+// Solidity: function asciiBytesToUint(bytes asciiBytes) pure returns(uint256)
+func (_ProverAxelar *ProverAxelarSession) AsciiBytesToUint(asciiBytes []byte) (*big.Int, error) {
+	return _ProverAxelar.Contract.AsciiBytesToUint(&_ProverAxelar.CallOpts, asciiBytes)
+}
+
+// AsciiBytesToUint is a free data retrieval call binding the contract method 0x2c4247c5.
+//
+// Solidity: function asciiBytesToUint(bytes asciiBytes) pure returns(uint256)
+func (_ProverAxelar *ProverAxelarCallerSession) AsciiBytesToUint(asciiBytes []byte) (*big.Int, error) {
+	return _ProverAxelar.Contract.AsciiBytesToUint(&_ProverAxelar.CallOpts, asciiBytes)
+}
+
+// ChainIdToSourceChain is a free data retrieval call binding the contract method 0xfa85ea9a.
+//
+// Solidity: function chainIdToSourceChain(uint256 ) view returns(string chainName, address sourceOracleAddress)
+func (_ProverAxelar *ProverAxelarCaller) ChainIdToSourceChain(opts *bind.CallOpts, arg0 *big.Int) (struct {
+	ChainName           string
+	SourceOracleAddress common.Address
+}, error) {
+	var out []interface{}
+	err := _ProverAxelar.contract.Call(opts, &out, "chainIdToSourceChain", arg0)
+
+	outstruct := new(struct {
+		ChainName           string
+		SourceOracleAddress common.Address
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.ChainName = *abi.ConvertType(out[0], new(string)).(*string)
+	outstruct.SourceOracleAddress = *abi.ConvertType(out[1], new(common.Address)).(*common.Address)
+
+	return *outstruct, err
+
+}
+
+// ChainIdToSourceChain is a free data retrieval call binding the contract method 0xfa85ea9a.
+//
+// Solidity: function chainIdToSourceChain(uint256 ) view returns(string chainName, address sourceOracleAddress)
+func (_ProverAxelar *ProverAxelarSession) ChainIdToSourceChain(arg0 *big.Int) (struct {
+	ChainName           string
+	SourceOracleAddress common.Address
+}, error) {
+	return _ProverAxelar.Contract.ChainIdToSourceChain(&_ProverAxelar.CallOpts, arg0)
+}
+
+// ChainIdToSourceChain is a free data retrieval call binding the contract method 0xfa85ea9a.
+//
+// Solidity: function chainIdToSourceChain(uint256 ) view returns(string chainName, address sourceOracleAddress)
+func (_ProverAxelar *ProverAxelarCallerSession) ChainIdToSourceChain(arg0 *big.Int) (struct {
+	ChainName           string
+	SourceOracleAddress common.Address
+}, error) {
+	return _ProverAxelar.Contract.ChainIdToSourceChain(&_ProverAxelar.CallOpts, arg0)
+}
+
+// DealIdToIndex is a free data retrieval call binding the contract method 0x3a6f05c1.
+//
+// Solidity: function dealIdToIndex(bytes32 ) view returns(uint256 idx, bool valid)
+func (_ProverAxelar *ProverAxelarCaller) DealIdToIndex(opts *bind.CallOpts, arg0 [32]byte) (struct {
+	Idx   *big.Int
+	Valid bool
+}, error) {
+	var out []interface{}
+	err := _ProverAxelar.contract.Call(opts, &out, "dealIdToIndex", arg0)
+
+	outstruct := new(struct {
+		Idx   *big.Int
+		Valid bool
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Idx = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.Valid = *abi.ConvertType(out[1], new(bool)).(*bool)
+
+	return *outstruct, err
+
+}
+
+// DealIdToIndex is a free data retrieval call binding the contract method 0x3a6f05c1.
+//
+// Solidity: function dealIdToIndex(bytes32 ) view returns(uint256 idx, bool valid)
+func (_ProverAxelar *ProverAxelarSession) DealIdToIndex(arg0 [32]byte) (struct {
+	Idx   *big.Int
+	Valid bool
+}, error) {
+	return _ProverAxelar.Contract.DealIdToIndex(&_ProverAxelar.CallOpts, arg0)
+}
+
+// DealIdToIndex is a free data retrieval call binding the contract method 0x3a6f05c1.
+//
+// Solidity: function dealIdToIndex(bytes32 ) view returns(uint256 idx, bool valid)
+func (_ProverAxelar *ProverAxelarCallerSession) DealIdToIndex(arg0 [32]byte) (struct {
+	Idx   *big.Int
+	Valid bool
+}, error) {
+	return _ProverAxelar.Contract.DealIdToIndex(&_ProverAxelar.CallOpts, arg0)
+}
+
+// DealRequests is a free data retrieval call binding the contract method 0xbad79de9.
+//
+// Solidity: function dealRequests(uint256 ) view returns(bytes piece_cid, uint64 piece_size, bool verified_deal, string label, int64 start_epoch, int64 end_epoch, uint256 storage_price_per_epoch, uint256 provider_collateral, uint256 client_collateral, uint64 extra_params_version, (string,uint64,bool,bool) extra_params)
+func (_ProverAxelar *ProverAxelarCaller) DealRequests(opts *bind.CallOpts, arg0 *big.Int) (struct {
+	PieceCid             []byte
+	PieceSize            uint64
+	VerifiedDeal         bool
+	Label                string
+	StartEpoch           int64
+	EndEpoch             int64
+	StoragePricePerEpoch *big.Int
+	ProviderCollateral   *big.Int
+	ClientCollateral     *big.Int
+	ExtraParamsVersion   uint64
+	ExtraParams          DealClientAxlExtraParams
+}, error) {
+	var out []interface{}
+	err := _ProverAxelar.contract.Call(opts, &out, "dealRequests", arg0)
+
+	outstruct := new(struct {
+		PieceCid             []byte
+		PieceSize            uint64
+		VerifiedDeal         bool
+		Label                string
+		StartEpoch           int64
+		EndEpoch             int64
+		StoragePricePerEpoch *big.Int
+		ProviderCollateral   *big.Int
+		ClientCollateral     *big.Int
+		ExtraParamsVersion   uint64
+		ExtraParams          DealClientAxlExtraParams
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.PieceCid = *abi.ConvertType(out[0], new([]byte)).(*[]byte)
+	outstruct.PieceSize = *abi.ConvertType(out[1], new(uint64)).(*uint64)
+	outstruct.VerifiedDeal = *abi.ConvertType(out[2], new(bool)).(*bool)
+	outstruct.Label = *abi.ConvertType(out[3], new(string)).(*string)
+	outstruct.StartEpoch = *abi.ConvertType(out[4], new(int64)).(*int64)
+	outstruct.EndEpoch = *abi.ConvertType(out[5], new(int64)).(*int64)
+	outstruct.StoragePricePerEpoch = *abi.ConvertType(out[6], new(*big.Int)).(**big.Int)
+	outstruct.ProviderCollateral = *abi.ConvertType(out[7], new(*big.Int)).(**big.Int)
+	outstruct.ClientCollateral = *abi.ConvertType(out[8], new(*big.Int)).(**big.Int)
+	outstruct.ExtraParamsVersion = *abi.ConvertType(out[9], new(uint64)).(*uint64)
+	outstruct.ExtraParams = *abi.ConvertType(out[10], new(DealClientAxlExtraParams)).(*DealClientAxlExtraParams)
+
+	return *outstruct, err
+
+}
+
+// DealRequests is a free data retrieval call binding the contract method 0xbad79de9.
+//
+// Solidity: function dealRequests(uint256 ) view returns(bytes piece_cid, uint64 piece_size, bool verified_deal, string label, int64 start_epoch, int64 end_epoch, uint256 storage_price_per_epoch, uint256 provider_collateral, uint256 client_collateral, uint64 extra_params_version, (string,uint64,bool,bool) extra_params)
+func (_ProverAxelar *ProverAxelarSession) DealRequests(arg0 *big.Int) (struct {
+	PieceCid             []byte
+	PieceSize            uint64
+	VerifiedDeal         bool
+	Label                string
+	StartEpoch           int64
+	EndEpoch             int64
+	StoragePricePerEpoch *big.Int
+	ProviderCollateral   *big.Int
+	ClientCollateral     *big.Int
+	ExtraParamsVersion   uint64
+	ExtraParams          DealClientAxlExtraParams
+}, error) {
+	return _ProverAxelar.Contract.DealRequests(&_ProverAxelar.CallOpts, arg0)
+}
+
+// DealRequests is a free data retrieval call binding the contract method 0xbad79de9.
+//
+// Solidity: function dealRequests(uint256 ) view returns(bytes piece_cid, uint64 piece_size, bool verified_deal, string label, int64 start_epoch, int64 end_epoch, uint256 storage_price_per_epoch, uint256 provider_collateral, uint256 client_collateral, uint64 extra_params_version, (string,uint64,bool,bool) extra_params)
+func (_ProverAxelar *ProverAxelarCallerSession) DealRequests(arg0 *big.Int) (struct {
+	PieceCid             []byte
+	PieceSize            uint64
+	VerifiedDeal         bool
+	Label                string
+	StartEpoch           int64
+	EndEpoch             int64
+	StoragePricePerEpoch *big.Int
+	ProviderCollateral   *big.Int
+	ClientCollateral     *big.Int
+	ExtraParamsVersion   uint64
+	ExtraParams          DealClientAxlExtraParams
+}, error) {
+	return _ProverAxelar.Contract.DealRequests(&_ProverAxelar.CallOpts, arg0)
+}
+
+// GasService is a free data retrieval call binding the contract method 0x6a22d8cc.
+//
+// Solidity: function gasService() view returns(address)
+func (_ProverAxelar *ProverAxelarCaller) GasService(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _ProverAxelar.contract.Call(opts, &out, "gasService")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// GasService is a free data retrieval call binding the contract method 0x6a22d8cc.
+//
+// Solidity: function gasService() view returns(address)
+func (_ProverAxelar *ProverAxelarSession) GasService() (common.Address, error) {
+	return _ProverAxelar.Contract.GasService(&_ProverAxelar.CallOpts)
+}
+
+// GasService is a free data retrieval call binding the contract method 0x6a22d8cc.
+//
+// Solidity: function gasService() view returns(address)
+func (_ProverAxelar *ProverAxelarCallerSession) GasService() (common.Address, error) {
+	return _ProverAxelar.Contract.GasService(&_ProverAxelar.CallOpts)
+}
+
+// Gateway is a free data retrieval call binding the contract method 0x116191b6.
+//
+// Solidity: function gateway() view returns(address)
+func (_ProverAxelar *ProverAxelarCaller) Gateway(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _ProverAxelar.contract.Call(opts, &out, "gateway")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Gateway is a free data retrieval call binding the contract method 0x116191b6.
+//
+// Solidity: function gateway() view returns(address)
+func (_ProverAxelar *ProverAxelarSession) Gateway() (common.Address, error) {
+	return _ProverAxelar.Contract.Gateway(&_ProverAxelar.CallOpts)
+}
+
+// Gateway is a free data retrieval call binding the contract method 0x116191b6.
+//
+// Solidity: function gateway() view returns(address)
+func (_ProverAxelar *ProverAxelarCallerSession) Gateway() (common.Address, error) {
+	return _ProverAxelar.Contract.Gateway(&_ProverAxelar.CallOpts)
+}
+
+// GetDealProposal is a free data retrieval call binding the contract method 0xf4b2e4d8.
+//
+// Solidity: function getDealProposal(bytes32 proposalId) view returns(bytes)
+func (_ProverAxelar *ProverAxelarCaller) GetDealProposal(opts *bind.CallOpts, proposalId [32]byte) ([]byte, error) {
+	var out []interface{}
+	err := _ProverAxelar.contract.Call(opts, &out, "getDealProposal", proposalId)
+
+	if err != nil {
+		return *new([]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]byte)).(*[]byte)
+
+	return out0, err
+
+}
+
+// GetDealProposal is a free data retrieval call binding the contract method 0xf4b2e4d8.
+//
+// Solidity: function getDealProposal(bytes32 proposalId) view returns(bytes)
+func (_ProverAxelar *ProverAxelarSession) GetDealProposal(proposalId [32]byte) ([]byte, error) {
+	return _ProverAxelar.Contract.GetDealProposal(&_ProverAxelar.CallOpts, proposalId)
+}
+
+// GetDealProposal is a free data retrieval call binding the contract method 0xf4b2e4d8.
+//
+// Solidity: function getDealProposal(bytes32 proposalId) view returns(bytes)
+func (_ProverAxelar *ProverAxelarCallerSession) GetDealProposal(proposalId [32]byte) ([]byte, error) {
+	return _ProverAxelar.Contract.GetDealProposal(&_ProverAxelar.CallOpts, proposalId)
+}
+
+// GetDealRequest is a free data retrieval call binding the contract method 0x342c89b4.
+//
+// Solidity: function getDealRequest(bytes32 proposalId) view returns((bytes,uint64,bool,string,int64,int64,uint256,uint256,uint256,uint64,(string,uint64,bool,bool)))
+func (_ProverAxelar *ProverAxelarCaller) GetDealRequest(opts *bind.CallOpts, proposalId [32]byte) (DealClientAxlDealRequest, error) {
+	var out []interface{}
+	err := _ProverAxelar.contract.Call(opts, &out, "getDealRequest", proposalId)
+
+	if err != nil {
+		return *new(DealClientAxlDealRequest), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(DealClientAxlDealRequest)).(*DealClientAxlDealRequest)
+
+	return out0, err
+
+}
+
+// GetDealRequest is a free data retrieval call binding the contract method 0x342c89b4.
+//
+// Solidity: function getDealRequest(bytes32 proposalId) view returns((bytes,uint64,bool,string,int64,int64,uint256,uint256,uint256,uint64,(string,uint64,bool,bool)))
+func (_ProverAxelar *ProverAxelarSession) GetDealRequest(proposalId [32]byte) (DealClientAxlDealRequest, error) {
+	return _ProverAxelar.Contract.GetDealRequest(&_ProverAxelar.CallOpts, proposalId)
+}
+
+// GetDealRequest is a free data retrieval call binding the contract method 0x342c89b4.
+//
+// Solidity: function getDealRequest(bytes32 proposalId) view returns((bytes,uint64,bool,string,int64,int64,uint256,uint256,uint256,uint64,(string,uint64,bool,bool)))
+func (_ProverAxelar *ProverAxelarCallerSession) GetDealRequest(proposalId [32]byte) (DealClientAxlDealRequest, error) {
+	return _ProverAxelar.Contract.GetDealRequest(&_ProverAxelar.CallOpts, proposalId)
+}
+
+// GetExtraParams is a free data retrieval call binding the contract method 0x4634aed5.
+//
+// Solidity: function getExtraParams(bytes32 proposalId) view returns(bytes extra_params)
+func (_ProverAxelar *ProverAxelarCaller) GetExtraParams(opts *bind.CallOpts, proposalId [32]byte) ([]byte, error) {
+	var out []interface{}
+	err := _ProverAxelar.contract.Call(opts, &out, "getExtraParams", proposalId)
+
+	if err != nil {
+		return *new([]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]byte)).(*[]byte)
+
+	return out0, err
+
+}
+
+// GetExtraParams is a free data retrieval call binding the contract method 0x4634aed5.
+//
+// Solidity: function getExtraParams(bytes32 proposalId) view returns(bytes extra_params)
+func (_ProverAxelar *ProverAxelarSession) GetExtraParams(proposalId [32]byte) ([]byte, error) {
+	return _ProverAxelar.Contract.GetExtraParams(&_ProverAxelar.CallOpts, proposalId)
+}
+
+// GetExtraParams is a free data retrieval call binding the contract method 0x4634aed5.
+//
+// Solidity: function getExtraParams(bytes32 proposalId) view returns(bytes extra_params)
+func (_ProverAxelar *ProverAxelarCallerSession) GetExtraParams(proposalId [32]byte) ([]byte, error) {
+	return _ProverAxelar.Contract.GetExtraParams(&_ProverAxelar.CallOpts, proposalId)
+}
+
+// GetSourceChain is a free data retrieval call binding the contract method 0x3ef338e3.
+//
+// Solidity: function getSourceChain(uint256 chainId) view returns(string, address)
+func (_ProverAxelar *ProverAxelarCaller) GetSourceChain(opts *bind.CallOpts, chainId *big.Int) (string, common.Address, error) {
+	var out []interface{}
+	err := _ProverAxelar.contract.Call(opts, &out, "getSourceChain", chainId)
+
+	if err != nil {
+		return *new(string), *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+	out1 := *abi.ConvertType(out[1], new(common.Address)).(*common.Address)
+
+	return out0, out1, err
+
+}
+
+// GetSourceChain is a free data retrieval call binding the contract method 0x3ef338e3.
+//
+// Solidity: function getSourceChain(uint256 chainId) view returns(string, address)
+func (_ProverAxelar *ProverAxelarSession) GetSourceChain(chainId *big.Int) (string, common.Address, error) {
+	return _ProverAxelar.Contract.GetSourceChain(&_ProverAxelar.CallOpts, chainId)
+}
+
+// GetSourceChain is a free data retrieval call binding the contract method 0x3ef338e3.
+//
+// Solidity: function getSourceChain(uint256 chainId) view returns(string, address)
+func (_ProverAxelar *ProverAxelarCallerSession) GetSourceChain(chainId *big.Int) (string, common.Address, error) {
+	return _ProverAxelar.Contract.GetSourceChain(&_ProverAxelar.CallOpts, chainId)
+}
+
+// PieceDeals is a free data retrieval call binding the contract method 0x0a0e0c91.
+//
+// Solidity: function pieceDeals(bytes ) view returns(uint64)
+func (_ProverAxelar *ProverAxelarCaller) PieceDeals(opts *bind.CallOpts, arg0 []byte) (uint64, error) {
+	var out []interface{}
+	err := _ProverAxelar.contract.Call(opts, &out, "pieceDeals", arg0)
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// PieceDeals is a free data retrieval call binding the contract method 0x0a0e0c91.
+//
+// Solidity: function pieceDeals(bytes ) view returns(uint64)
+func (_ProverAxelar *ProverAxelarSession) PieceDeals(arg0 []byte) (uint64, error) {
+	return _ProverAxelar.Contract.PieceDeals(&_ProverAxelar.CallOpts, arg0)
+}
+
+// PieceDeals is a free data retrieval call binding the contract method 0x0a0e0c91.
+//
+// Solidity: function pieceDeals(bytes ) view returns(uint64)
+func (_ProverAxelar *ProverAxelarCallerSession) PieceDeals(arg0 []byte) (uint64, error) {
+	return _ProverAxelar.Contract.PieceDeals(&_ProverAxelar.CallOpts, arg0)
+}
+
+// PieceRequests is a free data retrieval call binding the contract method 0x4e8a3ca4.
+//
+// Solidity: function pieceRequests(bytes ) view returns(bytes32 requestId, bool valid)
+func (_ProverAxelar *ProverAxelarCaller) PieceRequests(opts *bind.CallOpts, arg0 []byte) (struct {
+	RequestId [32]byte
+	Valid     bool
+}, error) {
+	var out []interface{}
+	err := _ProverAxelar.contract.Call(opts, &out, "pieceRequests", arg0)
+
+	outstruct := new(struct {
+		RequestId [32]byte
+		Valid     bool
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.RequestId = *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+	outstruct.Valid = *abi.ConvertType(out[1], new(bool)).(*bool)
+
+	return *outstruct, err
+
+}
+
+// PieceRequests is a free data retrieval call binding the contract method 0x4e8a3ca4.
+//
+// Solidity: function pieceRequests(bytes ) view returns(bytes32 requestId, bool valid)
+func (_ProverAxelar *ProverAxelarSession) PieceRequests(arg0 []byte) (struct {
+	RequestId [32]byte
+	Valid     bool
+}, error) {
+	return _ProverAxelar.Contract.PieceRequests(&_ProverAxelar.CallOpts, arg0)
+}
+
+// PieceRequests is a free data retrieval call binding the contract method 0x4e8a3ca4.
+//
+// Solidity: function pieceRequests(bytes ) view returns(bytes32 requestId, bool valid)
+func (_ProverAxelar *ProverAxelarCallerSession) PieceRequests(arg0 []byte) (struct {
+	RequestId [32]byte
+	Valid     bool
+}, error) {
+	return _ProverAxelar.Contract.PieceRequests(&_ProverAxelar.CallOpts, arg0)
+}
+
+// PieceStatus is a free data retrieval call binding the contract method 0xa6d1b7b8.
+//
+// Solidity: function pieceStatus(bytes ) view returns(uint8)
+func (_ProverAxelar *ProverAxelarCaller) PieceStatus(opts *bind.CallOpts, arg0 []byte) (uint8, error) {
+	var out []interface{}
+	err := _ProverAxelar.contract.Call(opts, &out, "pieceStatus", arg0)
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// PieceStatus is a free data retrieval call binding the contract method 0xa6d1b7b8.
+//
+// Solidity: function pieceStatus(bytes ) view returns(uint8)
+func (_ProverAxelar *ProverAxelarSession) PieceStatus(arg0 []byte) (uint8, error) {
+	return _ProverAxelar.Contract.PieceStatus(&_ProverAxelar.CallOpts, arg0)
+}
+
+// PieceStatus is a free data retrieval call binding the contract method 0xa6d1b7b8.
+//
+// Solidity: function pieceStatus(bytes ) view returns(uint8)
+func (_ProverAxelar *ProverAxelarCallerSession) PieceStatus(arg0 []byte) (uint8, error) {
+	return _ProverAxelar.Contract.PieceStatus(&_ProverAxelar.CallOpts, arg0)
+}
+
+// ProviderGasFunds is a free data retrieval call binding the contract method 0xcc7cc277.
+//
+// Solidity: function providerGasFunds(bytes ) view returns(uint256)
+func (_ProverAxelar *ProverAxelarCaller) ProviderGasFunds(opts *bind.CallOpts, arg0 []byte) (*big.Int, error) {
+	var out []interface{}
+	err := _ProverAxelar.contract.Call(opts, &out, "providerGasFunds", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ProviderGasFunds is a free data retrieval call binding the contract method 0xcc7cc277.
+//
+// Solidity: function providerGasFunds(bytes ) view returns(uint256)
+func (_ProverAxelar *ProverAxelarSession) ProviderGasFunds(arg0 []byte) (*big.Int, error) {
+	return _ProverAxelar.Contract.ProviderGasFunds(&_ProverAxelar.CallOpts, arg0)
+}
+
+// ProviderGasFunds is a free data retrieval call binding the contract method 0xcc7cc277.
+//
+// Solidity: function providerGasFunds(bytes ) view returns(uint256)
+func (_ProverAxelar *ProverAxelarCallerSession) ProviderGasFunds(arg0 []byte) (*big.Int, error) {
+	return _ProverAxelar.Contract.ProviderGasFunds(&_ProverAxelar.CallOpts, arg0)
+}
+
+// AddGasFunds is a paid mutator transaction binding the contract method 0x86a49a89.
+//
+// Solidity: function addGasFunds(bytes providerAddrData) payable returns()
+func (_ProverAxelar *ProverAxelarTransactor) AddGasFunds(opts *bind.TransactOpts, providerAddrData []byte) (*types.Transaction, error) {
+	return _ProverAxelar.contract.Transact(opts, "addGasFunds", providerAddrData)
+}
+
+// AddGasFunds is a paid mutator transaction binding the contract method 0x86a49a89.
+//
+// Solidity: function addGasFunds(bytes providerAddrData) payable returns()
+func (_ProverAxelar *ProverAxelarSession) AddGasFunds(providerAddrData []byte) (*types.Transaction, error) {
+	return _ProverAxelar.Contract.AddGasFunds(&_ProverAxelar.TransactOpts, providerAddrData)
+}
+
+// AddGasFunds is a paid mutator transaction binding the contract method 0x86a49a89.
+//
+// Solidity: function addGasFunds(bytes providerAddrData) payable returns()
+func (_ProverAxelar *ProverAxelarTransactorSession) AddGasFunds(providerAddrData []byte) (*types.Transaction, error) {
+	return _ProverAxelar.Contract.AddGasFunds(&_ProverAxelar.TransactOpts, providerAddrData)
+}
+
+// DebugCall is a paid mutator transaction binding the contract method 0x0ce9b565.
+//
+// Solidity: function debug_call(bytes commp, bytes providerAddrData, uint256 gasFunds, uint256 chainId) returns()
+func (_ProverAxelar *ProverAxelarTransactor) DebugCall(opts *bind.TransactOpts, commp []byte, providerAddrData []byte, gasFunds *big.Int, chainId *big.Int) (*types.Transaction, error) {
+	return _ProverAxelar.contract.Transact(opts, "debug_call", commp, providerAddrData, gasFunds, chainId)
+}
+
+// DebugCall is a paid mutator transaction binding the contract method 0x0ce9b565.
+//
+// Solidity: function debug_call(bytes commp, bytes providerAddrData, uint256 gasFunds, uint256 chainId) returns()
+func (_ProverAxelar *ProverAxelarSession) DebugCall(commp []byte, providerAddrData []byte, gasFunds *big.Int, chainId *big.Int) (*types.Transaction, error) {
+	return _ProverAxelar.Contract.DebugCall(&_ProverAxelar.TransactOpts, commp, providerAddrData, gasFunds, chainId)
+}
+
+// DebugCall is a paid mutator transaction binding the contract method 0x0ce9b565.
+//
+// Solidity: function debug_call(bytes commp, bytes providerAddrData, uint256 gasFunds, uint256 chainId) returns()
+func (_ProverAxelar *ProverAxelarTransactorSession) DebugCall(commp []byte, providerAddrData []byte, gasFunds *big.Int, chainId *big.Int) (*types.Transaction, error) {
+	return _ProverAxelar.Contract.DebugCall(&_ProverAxelar.TransactOpts, commp, providerAddrData, gasFunds, chainId)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0x49160658.
+//
+// Solidity: function execute(bytes32 commandId, string sourceChain, string sourceAddress, bytes payload) returns()
+func (_ProverAxelar *ProverAxelarTransactor) Execute(opts *bind.TransactOpts, commandId [32]byte, sourceChain string, sourceAddress string, payload []byte) (*types.Transaction, error) {
+	return _ProverAxelar.contract.Transact(opts, "execute", commandId, sourceChain, sourceAddress, payload)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0x49160658.
+//
+// Solidity: function execute(bytes32 commandId, string sourceChain, string sourceAddress, bytes payload) returns()
+func (_ProverAxelar *ProverAxelarSession) Execute(commandId [32]byte, sourceChain string, sourceAddress string, payload []byte) (*types.Transaction, error) {
+	return _ProverAxelar.Contract.Execute(&_ProverAxelar.TransactOpts, commandId, sourceChain, sourceAddress, payload)
+}
+
+// Execute is a paid mutator transaction binding the contract method 0x49160658.
+//
+// Solidity: function execute(bytes32 commandId, string sourceChain, string sourceAddress, bytes payload) returns()
+func (_ProverAxelar *ProverAxelarTransactorSession) Execute(commandId [32]byte, sourceChain string, sourceAddress string, payload []byte) (*types.Transaction, error) {
+	return _ProverAxelar.Contract.Execute(&_ProverAxelar.TransactOpts, commandId, sourceChain, sourceAddress, payload)
+}
+
+// HandleFilecoinMethod is a paid mutator transaction binding the contract method 0x868e10c4.
+//
+// Solidity: function handle_filecoin_method(uint64 method, uint64 , bytes params) returns(uint32, uint64, bytes)
+func (_ProverAxelar *ProverAxelarTransactor) HandleFilecoinMethod(opts *bind.TransactOpts, method uint64, arg1 uint64, params []byte) (*types.Transaction, error) {
+	return _ProverAxelar.contract.Transact(opts, "handle_filecoin_method", method, arg1, params)
+}
+
+// HandleFilecoinMethod is a paid mutator transaction binding the contract method 0x868e10c4.
+//
+// Solidity: function handle_filecoin_method(uint64 method, uint64 , bytes params) returns(uint32, uint64, bytes)
+func (_ProverAxelar *ProverAxelarSession) HandleFilecoinMethod(method uint64, arg1 uint64, params []byte) (*types.Transaction, error) {
+	return _ProverAxelar.Contract.HandleFilecoinMethod(&_ProverAxelar.TransactOpts, method, arg1, params)
+}
+
+// HandleFilecoinMethod is a paid mutator transaction binding the contract method 0x868e10c4.
+//
+// Solidity: function handle_filecoin_method(uint64 method, uint64 , bytes params) returns(uint32, uint64, bytes)
+func (_ProverAxelar *ProverAxelarTransactorSession) HandleFilecoinMethod(method uint64, arg1 uint64, params []byte) (*types.Transaction, error) {
+	return _ProverAxelar.Contract.HandleFilecoinMethod(&_ProverAxelar.TransactOpts, method, arg1, params)
+}
+
+// MakeDealProposal is a paid mutator transaction binding the contract method 0xdd27fe90.
+//
+// Solidity: function makeDealProposal((bytes,uint64,bool,string,int64,int64,uint256,uint256,uint256,uint64,(string,uint64,bool,bool)) deal) returns(bytes32)
+func (_ProverAxelar *ProverAxelarTransactor) MakeDealProposal(opts *bind.TransactOpts, deal DealClientAxlDealRequest) (*types.Transaction, error) {
+	return _ProverAxelar.contract.Transact(opts, "makeDealProposal", deal)
+}
+
+// MakeDealProposal is a paid mutator transaction binding the contract method 0xdd27fe90.
+//
+// Solidity: function makeDealProposal((bytes,uint64,bool,string,int64,int64,uint256,uint256,uint256,uint64,(string,uint64,bool,bool)) deal) returns(bytes32)
+func (_ProverAxelar *ProverAxelarSession) MakeDealProposal(deal DealClientAxlDealRequest) (*types.Transaction, error) {
+	return _ProverAxelar.Contract.MakeDealProposal(&_ProverAxelar.TransactOpts, deal)
+}
+
+// MakeDealProposal is a paid mutator transaction binding the contract method 0xdd27fe90.
+//
+// Solidity: function makeDealProposal((bytes,uint64,bool,string,int64,int64,uint256,uint256,uint256,uint64,(string,uint64,bool,bool)) deal) returns(bytes32)
+func (_ProverAxelar *ProverAxelarTransactorSession) MakeDealProposal(deal DealClientAxlDealRequest) (*types.Transaction, error) {
+	return _ProverAxelar.Contract.MakeDealProposal(&_ProverAxelar.TransactOpts, deal)
+}
+
+// RelayDeal is a paid mutator transaction binding the contract method 0x35aabf36.
+//
+// Solidity: function relayDeal(bytes pieceCid) payable returns()
+func (_ProverAxelar *ProverAxelarTransactor) RelayDeal(opts *bind.TransactOpts, pieceCid []byte) (*types.Transaction, error) {
+	return _ProverAxelar.contract.Transact(opts, "relayDeal", pieceCid)
+}
+
+// RelayDeal is a paid mutator transaction binding the contract method 0x35aabf36.
+//
+// Solidity: function relayDeal(bytes pieceCid) payable returns()
+func (_ProverAxelar *ProverAxelarSession) RelayDeal(pieceCid []byte) (*types.Transaction, error) {
+	return _ProverAxelar.Contract.RelayDeal(&_ProverAxelar.TransactOpts, pieceCid)
+}
+
+// RelayDeal is a paid mutator transaction binding the contract method 0x35aabf36.
+//
+// Solidity: function relayDeal(bytes pieceCid) payable returns()
+func (_ProverAxelar *ProverAxelarTransactorSession) RelayDeal(pieceCid []byte) (*types.Transaction, error) {
+	return _ProverAxelar.Contract.RelayDeal(&_ProverAxelar.TransactOpts, pieceCid)
+}
+
+// SetSourceChains is a paid mutator transaction binding the contract method 0xdf064a9d.
+//
+// Solidity: function setSourceChains(uint256[] chainIds, string[] sourceChains, address[] sourceOracleAddresses) returns()
+func (_ProverAxelar *ProverAxelarTransactor) SetSourceChains(opts *bind.TransactOpts, chainIds []*big.Int, sourceChains []string, sourceOracleAddresses []common.Address) (*types.Transaction, error) {
+	return _ProverAxelar.contract.Transact(opts, "setSourceChains", chainIds, sourceChains, sourceOracleAddresses)
+}
+
+// SetSourceChains is a paid mutator transaction binding the contract method 0xdf064a9d.
+//
+// Solidity: function setSourceChains(uint256[] chainIds, string[] sourceChains, address[] sourceOracleAddresses) returns()
+func (_ProverAxelar *ProverAxelarSession) SetSourceChains(chainIds []*big.Int, sourceChains []string, sourceOracleAddresses []common.Address) (*types.Transaction, error) {
+	return _ProverAxelar.Contract.SetSourceChains(&_ProverAxelar.TransactOpts, chainIds, sourceChains, sourceOracleAddresses)
+}
+
+// SetSourceChains is a paid mutator transaction binding the contract method 0xdf064a9d.
+//
+// Solidity: function setSourceChains(uint256[] chainIds, string[] sourceChains, address[] sourceOracleAddresses) returns()
+func (_ProverAxelar *ProverAxelarTransactorSession) SetSourceChains(chainIds []*big.Int, sourceChains []string, sourceOracleAddresses []common.Address) (*types.Transaction, error) {
+	return _ProverAxelar.Contract.SetSourceChains(&_ProverAxelar.TransactOpts, chainIds, sourceChains, sourceOracleAddresses)
+}
+
+// UpdateDealStatus is a paid mutator transaction binding the contract method 0xe0f886f4.
+//
+// Solidity: function updateDealStatus(bytes pieceCid) returns()
+func (_ProverAxelar *ProverAxelarTransactor) UpdateDealStatus(opts *bind.TransactOpts, pieceCid []byte) (*types.Transaction, error) {
+	return _ProverAxelar.contract.Transact(opts, "updateDealStatus", pieceCid)
+}
+
+// UpdateDealStatus is a paid mutator transaction binding the contract method 0xe0f886f4.
+//
+// Solidity: function updateDealStatus(bytes pieceCid) returns()
+func (_ProverAxelar *ProverAxelarSession) UpdateDealStatus(pieceCid []byte) (*types.Transaction, error) {
+	return _ProverAxelar.Contract.UpdateDealStatus(&_ProverAxelar.TransactOpts, pieceCid)
+}
+
+// UpdateDealStatus is a paid mutator transaction binding the contract method 0xe0f886f4.
+//
+// Solidity: function updateDealStatus(bytes pieceCid) returns()
+func (_ProverAxelar *ProverAxelarTransactorSession) UpdateDealStatus(pieceCid []byte) (*types.Transaction, error) {
+	return _ProverAxelar.Contract.UpdateDealStatus(&_ProverAxelar.TransactOpts, pieceCid)
+}
+
+// ProverAxelarDealNotifyIterator is returned from FilterDealNotify and is used to iterate over the raw logs and unpacked data for DealNotify events raised by the ProverAxelar contract.
+type ProverAxelarDealNotifyIterator struct {
+	Event *ProverAxelarDealNotify // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ProverAxelarDealNotifyIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ProverAxelarDealNotify)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ProverAxelarDealNotify)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ProverAxelarDealNotifyIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ProverAxelarDealNotifyIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ProverAxelarDealNotify represents a DealNotify event raised by the ProverAxelar contract.
+type ProverAxelarDealNotify struct {
+	DealId  uint64
+	CommP   []byte
+	ChainId []byte
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterDealNotify is a free log retrieval operation binding the contract event 0x59a909f379aa70527c8b210b2e0aaab9ba161f51e0d46af000be84e70a5f138a.
+//
+// Solidity: event DealNotify(uint64 dealId, bytes commP, bytes chainId)
+func (_ProverAxelar *ProverAxelarFilterer) FilterDealNotify(opts *bind.FilterOpts) (*ProverAxelarDealNotifyIterator, error) {
+
+	logs, sub, err := _ProverAxelar.contract.FilterLogs(opts, "DealNotify")
+	if err != nil {
+		return nil, err
+	}
+	return &ProverAxelarDealNotifyIterator{contract: _ProverAxelar.contract, event: "DealNotify", logs: logs, sub: sub}, nil
+}
+
+// WatchDealNotify is a free log subscription operation binding the contract event 0x59a909f379aa70527c8b210b2e0aaab9ba161f51e0d46af000be84e70a5f138a.
+//
+// Solidity: event DealNotify(uint64 dealId, bytes commP, bytes chainId)
+func (_ProverAxelar *ProverAxelarFilterer) WatchDealNotify(opts *bind.WatchOpts, sink chan<- *ProverAxelarDealNotify) (event.Subscription, error) {
+
+	logs, sub, err := _ProverAxelar.contract.WatchLogs(opts, "DealNotify")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ProverAxelarDealNotify)
+				if err := _ProverAxelar.contract.UnpackLog(event, "DealNotify", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDealNotify is a log parse operation binding the contract event 0x59a909f379aa70527c8b210b2e0aaab9ba161f51e0d46af000be84e70a5f138a.
+//
+// Solidity: event DealNotify(uint64 dealId, bytes commP, bytes chainId)
+func (_ProverAxelar *ProverAxelarFilterer) ParseDealNotify(log types.Log) (*ProverAxelarDealNotify, error) {
+	event := new(ProverAxelarDealNotify)
+	if err := _ProverAxelar.contract.UnpackLog(event, "DealNotify", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ProverAxelarDealProposalCreateIterator is returned from FilterDealProposalCreate and is used to iterate over the raw logs and unpacked data for DealProposalCreate events raised by the ProverAxelar contract.
+type ProverAxelarDealProposalCreateIterator struct {
+	Event *ProverAxelarDealProposalCreate // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ProverAxelarDealProposalCreateIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ProverAxelarDealProposalCreate)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ProverAxelarDealProposalCreate)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ProverAxelarDealProposalCreateIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ProverAxelarDealProposalCreateIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ProverAxelarDealProposalCreate represents a DealProposalCreate event raised by the ProverAxelar contract.
+type ProverAxelarDealProposalCreate struct {
+	Id       [32]byte
+	Size     uint64
+	Verified bool
+	Price    *big.Int
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterDealProposalCreate is a free log retrieval operation binding the contract event 0xfd6419d07e4c269e58d0c63969756c2124155b4a8d6dd08b8cd46e3a9acbf625.
+//
+// Solidity: event DealProposalCreate(bytes32 indexed id, uint64 size, bool indexed verified, uint256 price)
+func (_ProverAxelar *ProverAxelarFilterer) FilterDealProposalCreate(opts *bind.FilterOpts, id [][32]byte, verified []bool) (*ProverAxelarDealProposalCreateIterator, error) {
+
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+
+	var verifiedRule []interface{}
+	for _, verifiedItem := range verified {
+		verifiedRule = append(verifiedRule, verifiedItem)
+	}
+
+	logs, sub, err := _ProverAxelar.contract.FilterLogs(opts, "DealProposalCreate", idRule, verifiedRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ProverAxelarDealProposalCreateIterator{contract: _ProverAxelar.contract, event: "DealProposalCreate", logs: logs, sub: sub}, nil
+}
+
+// WatchDealProposalCreate is a free log subscription operation binding the contract event 0xfd6419d07e4c269e58d0c63969756c2124155b4a8d6dd08b8cd46e3a9acbf625.
+//
+// Solidity: event DealProposalCreate(bytes32 indexed id, uint64 size, bool indexed verified, uint256 price)
+func (_ProverAxelar *ProverAxelarFilterer) WatchDealProposalCreate(opts *bind.WatchOpts, sink chan<- *ProverAxelarDealProposalCreate, id [][32]byte, verified []bool) (event.Subscription, error) {
+
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+
+	var verifiedRule []interface{}
+	for _, verifiedItem := range verified {
+		verifiedRule = append(verifiedRule, verifiedItem)
+	}
+
+	logs, sub, err := _ProverAxelar.contract.WatchLogs(opts, "DealProposalCreate", idRule, verifiedRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ProverAxelarDealProposalCreate)
+				if err := _ProverAxelar.contract.UnpackLog(event, "DealProposalCreate", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDealProposalCreate is a log parse operation binding the contract event 0xfd6419d07e4c269e58d0c63969756c2124155b4a8d6dd08b8cd46e3a9acbf625.
+//
+// Solidity: event DealProposalCreate(bytes32 indexed id, uint64 size, bool indexed verified, uint256 price)
+func (_ProverAxelar *ProverAxelarFilterer) ParseDealProposalCreate(log types.Log) (*ProverAxelarDealProposalCreate, error) {
+	event := new(ProverAxelarDealProposalCreate)
+	if err := _ProverAxelar.contract.UnpackLog(event, "DealProposalCreate", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ProverAxelarDealRelayedIterator is returned from FilterDealRelayed and is used to iterate over the raw logs and unpacked data for DealRelayed events raised by the ProverAxelar contract.
+type ProverAxelarDealRelayedIterator struct {
+	Event *ProverAxelarDealRelayed // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ProverAxelarDealRelayedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ProverAxelarDealRelayed)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ProverAxelarDealRelayed)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ProverAxelarDealRelayedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ProverAxelarDealRelayedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ProverAxelarDealRelayed represents a DealRelayed event raised by the ProverAxelar contract.
+type ProverAxelarDealRelayed struct {
+	DealId  uint64
+	CommP   []byte
+	ChainId *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterDealRelayed is a free log retrieval operation binding the contract event 0x8c5a3f71cb5df87c3625953952fd0e3b8531f7349fca9a9a8a16de58ab9831d7.
+//
+// Solidity: event DealRelayed(uint64 dealId, bytes commP, uint256 chainId)
+func (_ProverAxelar *ProverAxelarFilterer) FilterDealRelayed(opts *bind.FilterOpts) (*ProverAxelarDealRelayedIterator, error) {
+
+	logs, sub, err := _ProverAxelar.contract.FilterLogs(opts, "DealRelayed")
+	if err != nil {
+		return nil, err
+	}
+	return &ProverAxelarDealRelayedIterator{contract: _ProverAxelar.contract, event: "DealRelayed", logs: logs, sub: sub}, nil
+}
+
+// WatchDealRelayed is a free log subscription operation binding the contract event 0x8c5a3f71cb5df87c3625953952fd0e3b8531f7349fca9a9a8a16de58ab9831d7.
+//
+// Solidity: event DealRelayed(uint64 dealId, bytes commP, uint256 chainId)
+func (_ProverAxelar *ProverAxelarFilterer) WatchDealRelayed(opts *bind.WatchOpts, sink chan<- *ProverAxelarDealRelayed) (event.Subscription, error) {
+
+	logs, sub, err := _ProverAxelar.contract.WatchLogs(opts, "DealRelayed")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ProverAxelarDealRelayed)
+				if err := _ProverAxelar.contract.UnpackLog(event, "DealRelayed", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDealRelayed is a log parse operation binding the contract event 0x8c5a3f71cb5df87c3625953952fd0e3b8531f7349fca9a9a8a16de58ab9831d7.
+//
+// Solidity: event DealRelayed(uint64 dealId, bytes commP, uint256 chainId)
+func (_ProverAxelar *ProverAxelarFilterer) ParseDealRelayed(log types.Log) (*ProverAxelarDealRelayed, error) {
+	event := new(ProverAxelarDealRelayed)
+	if err := _ProverAxelar.contract.UnpackLog(event, "DealRelayed", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ProverAxelarReceivedDataCapIterator is returned from FilterReceivedDataCap and is used to iterate over the raw logs and unpacked data for ReceivedDataCap events raised by the ProverAxelar contract.
+type ProverAxelarReceivedDataCapIterator struct {
+	Event *ProverAxelarReceivedDataCap // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ProverAxelarReceivedDataCapIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ProverAxelarReceivedDataCap)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ProverAxelarReceivedDataCap)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ProverAxelarReceivedDataCapIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ProverAxelarReceivedDataCapIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ProverAxelarReceivedDataCap represents a ReceivedDataCap event raised by the ProverAxelar contract.
+type ProverAxelarReceivedDataCap struct {
+	Received string
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterReceivedDataCap is a free log retrieval operation binding the contract event 0x10aa319ed8cad9bceb033c0c2788c4ae17469ac844e4c6e2c2e20e74ca8a7be8.
+//
+// Solidity: event ReceivedDataCap(string received)
+func (_ProverAxelar *ProverAxelarFilterer) FilterReceivedDataCap(opts *bind.FilterOpts) (*ProverAxelarReceivedDataCapIterator, error) {
+
+	logs, sub, err := _ProverAxelar.contract.FilterLogs(opts, "ReceivedDataCap")
+	if err != nil {
+		return nil, err
+	}
+	return &ProverAxelarReceivedDataCapIterator{contract: _ProverAxelar.contract, event: "ReceivedDataCap", logs: logs, sub: sub}, nil
+}
+
+// WatchReceivedDataCap is a free log subscription operation binding the contract event 0x10aa319ed8cad9bceb033c0c2788c4ae17469ac844e4c6e2c2e20e74ca8a7be8.
+//
+// Solidity: event ReceivedDataCap(string received)
+func (_ProverAxelar *ProverAxelarFilterer) WatchReceivedDataCap(opts *bind.WatchOpts, sink chan<- *ProverAxelarReceivedDataCap) (event.Subscription, error) {
+
+	logs, sub, err := _ProverAxelar.contract.WatchLogs(opts, "ReceivedDataCap")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ProverAxelarReceivedDataCap)
+				if err := _ProverAxelar.contract.UnpackLog(event, "ReceivedDataCap", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseReceivedDataCap is a log parse operation binding the contract event 0x10aa319ed8cad9bceb033c0c2788c4ae17469ac844e4c6e2c2e20e74ca8a7be8.
+//
+// Solidity: event ReceivedDataCap(string received)
+func (_ProverAxelar *ProverAxelarFilterer) ParseReceivedDataCap(log types.Log) (*ProverAxelarReceivedDataCap, error) {
+	event := new(ProverAxelarReceivedDataCap)
+	if err := _ProverAxelar.contract.UnpackLog(event, "ReceivedDataCap", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ProverAxelarXChainProveDataStoredIterator is returned from FilterXChainProveDataStored and is used to iterate over the raw logs and unpacked data for XChainProveDataStored events raised by the ProverAxelar contract.
+type ProverAxelarXChainProveDataStoredIterator struct {
+	Event *ProverAxelarXChainProveDataStored // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ProverAxelarXChainProveDataStoredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ProverAxelarXChainProveDataStored)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ProverAxelarXChainProveDataStored)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ProverAxelarXChainProveDataStoredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ProverAxelarXChainProveDataStoredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ProverAxelarXChainProveDataStored represents a XChainProveDataStored event raised by the ProverAxelar contract.
+type ProverAxelarXChainProveDataStored struct {
+	DestinationChain   string
+	DestinationAddress string
+	Raw                types.Log // Blockchain specific contextual infos
+}
+
+// FilterXChainProveDataStored is a free log retrieval operation binding the contract event 0x02959add8ec07f39fcceffdbd37867331ddea0291490e69b84bfd3f11f7341a9.
+//
+// Solidity: event XChainProveDataStored(string destinationChain, string destinationAddress)
+func (_ProverAxelar *ProverAxelarFilterer) FilterXChainProveDataStored(opts *bind.FilterOpts) (*ProverAxelarXChainProveDataStoredIterator, error) {
+
+	logs, sub, err := _ProverAxelar.contract.FilterLogs(opts, "XChainProveDataStored")
+	if err != nil {
+		return nil, err
+	}
+	return &ProverAxelarXChainProveDataStoredIterator{contract: _ProverAxelar.contract, event: "XChainProveDataStored", logs: logs, sub: sub}, nil
+}
+
+// WatchXChainProveDataStored is a free log subscription operation binding the contract event 0x02959add8ec07f39fcceffdbd37867331ddea0291490e69b84bfd3f11f7341a9.
+//
+// Solidity: event XChainProveDataStored(string destinationChain, string destinationAddress)
+func (_ProverAxelar *ProverAxelarFilterer) WatchXChainProveDataStored(opts *bind.WatchOpts, sink chan<- *ProverAxelarXChainProveDataStored) (event.Subscription, error) {
+
+	logs, sub, err := _ProverAxelar.contract.WatchLogs(opts, "XChainProveDataStored")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ProverAxelarXChainProveDataStored)
+				if err := _ProverAxelar.contract.UnpackLog(event, "XChainProveDataStored", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseXChainProveDataStored is a log parse operation binding the contract event 0x02959add8ec07f39fcceffdbd37867331ddea0291490e69b84bfd3f11f7341a9.
+//
+// Solidity: event XChainProveDataStored(string destinationChain, string destinationAddress)
+func (_ProverAxelar *ProverAxelarFilterer) ParseXChainProveDataStored(log types.Log) (*ProverAxelarXChainProveDataStored, error) {
+	event := new(ProverAxelarXChainProveDataStored)
+	if err := _ProverAxelar.contract.UnpackLog(event, "XChainProveDataStored", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
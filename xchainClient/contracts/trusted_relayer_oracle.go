@@ -0,0 +1,608 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// TrustedRelayerOracleMetaData contains all meta data concerning the TrustedRelayerOracle contract.
+var TrustedRelayerOracleMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"commP\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"dealID\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"relayer\",\"type\":\"address\"}],\"name\":\"ReceivedAttestation\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"relayer\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"bool\",\"name\":\"trusted\",\"type\":\"bool\"}],\"name\":\"RelayerSet\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"receiver\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"bytes\",\"name\":\"commP\",\"type\":\"bytes\"},{\"internalType\":\"int64\",\"name\":\"duration\",\"type\":\"int64\"},{\"internalType\":\"uint64\",\"name\":\"dealID\",\"type\":\"uint64\"},{\"internalType\":\"uint256\",\"name\":\"status\",\"type\":\"uint256\"}],\"internalType\":\"structDataAttestation\",\"name\":\"attestation_\",\"type\":\"tuple\"}],\"name\":\"relayAttestation\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"relayers\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"receiver_\",\"type\":\"address\"}],\"name\":\"setReceiver\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"relayer_\",\"type\":\"address\"},{\"internalType\":\"bool\",\"name\":\"trusted_\",\"type\":\"bool\"}],\"name\":\"setRelayer\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+}
+
+// TrustedRelayerOracleABI is the input ABI used to generate the binding from.
+// Deprecated: Use TrustedRelayerOracleMetaData.ABI instead.
+var TrustedRelayerOracleABI = TrustedRelayerOracleMetaData.ABI
+
+// TrustedRelayerOracle is an auto generated Go binding around an Ethereum contract.
+type TrustedRelayerOracle struct {
+	TrustedRelayerOracleCaller     // Read-only binding to the contract
+	TrustedRelayerOracleTransactor // Write-only binding to the contract
+	TrustedRelayerOracleFilterer   // Log filterer for contract events
+}
+
+// TrustedRelayerOracleCaller is an auto generated read-only Go binding around an Ethereum contract.
+type TrustedRelayerOracleCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// TrustedRelayerOracleTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type TrustedRelayerOracleTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// TrustedRelayerOracleFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type TrustedRelayerOracleFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// TrustedRelayerOracleSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type TrustedRelayerOracleSession struct {
+	Contract     *TrustedRelayerOracle // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts         // Call options to use throughout this session
+	TransactOpts bind.TransactOpts     // Transaction auth options to use throughout this session
+}
+
+// TrustedRelayerOracleCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type TrustedRelayerOracleCallerSession struct {
+	Contract *TrustedRelayerOracleCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts               // Call options to use throughout this session
+}
+
+// TrustedRelayerOracleTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type TrustedRelayerOracleTransactorSession struct {
+	Contract     *TrustedRelayerOracleTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts               // Transaction auth options to use throughout this session
+}
+
+// TrustedRelayerOracleRaw is an auto generated low-level Go binding around an Ethereum contract.
+type TrustedRelayerOracleRaw struct {
+	Contract *TrustedRelayerOracle // Generic contract binding to access the raw methods on
+}
+
+// TrustedRelayerOracleCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type TrustedRelayerOracleCallerRaw struct {
+	Contract *TrustedRelayerOracleCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// TrustedRelayerOracleTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type TrustedRelayerOracleTransactorRaw struct {
+	Contract *TrustedRelayerOracleTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewTrustedRelayerOracle creates a new instance of TrustedRelayerOracle, bound to a specific deployed contract.
+func NewTrustedRelayerOracle(address common.Address, backend bind.ContractBackend) (*TrustedRelayerOracle, error) {
+	contract, err := bindTrustedRelayerOracle(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &TrustedRelayerOracle{TrustedRelayerOracleCaller: TrustedRelayerOracleCaller{contract: contract}, TrustedRelayerOracleTransactor: TrustedRelayerOracleTransactor{contract: contract}, TrustedRelayerOracleFilterer: TrustedRelayerOracleFilterer{contract: contract}}, nil
+}
+
+// NewTrustedRelayerOracleCaller creates a new read-only instance of TrustedRelayerOracle, bound to a specific deployed contract.
+func NewTrustedRelayerOracleCaller(address common.Address, caller bind.ContractCaller) (*TrustedRelayerOracleCaller, error) {
+	contract, err := bindTrustedRelayerOracle(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &TrustedRelayerOracleCaller{contract: contract}, nil
+}
+
+// NewTrustedRelayerOracleTransactor creates a new write-only instance of TrustedRelayerOracle, bound to a specific deployed contract.
+func NewTrustedRelayerOracleTransactor(address common.Address, transactor bind.ContractTransactor) (*TrustedRelayerOracleTransactor, error) {
+	contract, err := bindTrustedRelayerOracle(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &TrustedRelayerOracleTransactor{contract: contract}, nil
+}
+
+// NewTrustedRelayerOracleFilterer creates a new log filterer instance of TrustedRelayerOracle, bound to a specific deployed contract.
+func NewTrustedRelayerOracleFilterer(address common.Address, filterer bind.ContractFilterer) (*TrustedRelayerOracleFilterer, error) {
+	contract, err := bindTrustedRelayerOracle(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &TrustedRelayerOracleFilterer{contract: contract}, nil
+}
+
+// bindTrustedRelayerOracle binds a generic wrapper to an already deployed contract.
+func bindTrustedRelayerOracle(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := TrustedRelayerOracleMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_TrustedRelayerOracle *TrustedRelayerOracleRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _TrustedRelayerOracle.Contract.TrustedRelayerOracleCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_TrustedRelayerOracle *TrustedRelayerOracleRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _TrustedRelayerOracle.Contract.TrustedRelayerOracleTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_TrustedRelayerOracle *TrustedRelayerOracleRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _TrustedRelayerOracle.Contract.TrustedRelayerOracleTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_TrustedRelayerOracle *TrustedRelayerOracleCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _TrustedRelayerOracle.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_TrustedRelayerOracle *TrustedRelayerOracleTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _TrustedRelayerOracle.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_TrustedRelayerOracle *TrustedRelayerOracleTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _TrustedRelayerOracle.Contract.contract.Transact(opts, method, params...)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_TrustedRelayerOracle *TrustedRelayerOracleCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _TrustedRelayerOracle.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_TrustedRelayerOracle *TrustedRelayerOracleSession) Owner() (common.Address, error) {
+	return _TrustedRelayerOracle.Contract.Owner(&_TrustedRelayerOracle.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_TrustedRelayerOracle *TrustedRelayerOracleCallerSession) Owner() (common.Address, error) {
+	return _TrustedRelayerOracle.Contract.Owner(&_TrustedRelayerOracle.CallOpts)
+}
+
+// Receiver is a free data retrieval call binding the contract method 0xf7260d3e.
+//
+// Solidity: function receiver() view returns(address)
+func (_TrustedRelayerOracle *TrustedRelayerOracleCaller) Receiver(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _TrustedRelayerOracle.contract.Call(opts, &out, "receiver")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Receiver is a free data retrieval call binding the contract method 0xf7260d3e.
+//
+// Solidity: function receiver() view returns(address)
+func (_TrustedRelayerOracle *TrustedRelayerOracleSession) Receiver() (common.Address, error) {
+	return _TrustedRelayerOracle.Contract.Receiver(&_TrustedRelayerOracle.CallOpts)
+}
+
+// Receiver is a free data retrieval call binding the contract method 0xf7260d3e.
+//
+// Solidity: function receiver() view returns(address)
+func (_TrustedRelayerOracle *TrustedRelayerOracleCallerSession) Receiver() (common.Address, error) {
+	return _TrustedRelayerOracle.Contract.Receiver(&_TrustedRelayerOracle.CallOpts)
+}
+
+// Relayers is a free data retrieval call binding the contract method 0x5300f841.
+//
+// Solidity: function relayers(address ) view returns(bool)
+func (_TrustedRelayerOracle *TrustedRelayerOracleCaller) Relayers(opts *bind.CallOpts, arg0 common.Address) (bool, error) {
+	var out []interface{}
+	err := _TrustedRelayerOracle.contract.Call(opts, &out, "relayers", arg0)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// Relayers is a free data retrieval call binding the contract method 0x5300f841.
+//
+// Solidity: function relayers(address ) view returns(bool)
+func (_TrustedRelayerOracle *TrustedRelayerOracleSession) Relayers(arg0 common.Address) (bool, error) {
+	return _TrustedRelayerOracle.Contract.Relayers(&_TrustedRelayerOracle.CallOpts, arg0)
+}
+
+// Relayers is a free data retrieval call binding the contract method 0x5300f841.
+//
+// Solidity: function relayers(address ) view returns(bool)
+func (_TrustedRelayerOracle *TrustedRelayerOracleCallerSession) Relayers(arg0 common.Address) (bool, error) {
+	return _TrustedRelayerOracle.Contract.Relayers(&_TrustedRelayerOracle.CallOpts, arg0)
+}
+
+// RelayAttestation is a paid mutator transaction binding the contract method 0x6af511d2.
+//
+// Solidity: function relayAttestation((bytes,int64,uint64,uint256) attestation_) returns()
+func (_TrustedRelayerOracle *TrustedRelayerOracleTransactor) RelayAttestation(opts *bind.TransactOpts, attestation_ DataAttestation) (*types.Transaction, error) {
+	return _TrustedRelayerOracle.contract.Transact(opts, "relayAttestation", attestation_)
+}
+
+// RelayAttestation is a paid mutator transaction binding the contract method 0x6af511d2.
+//
+// Solidity: function relayAttestation((bytes,int64,uint64,uint256) attestation_) returns()
+func (_TrustedRelayerOracle *TrustedRelayerOracleSession) RelayAttestation(attestation_ DataAttestation) (*types.Transaction, error) {
+	return _TrustedRelayerOracle.Contract.RelayAttestation(&_TrustedRelayerOracle.TransactOpts, attestation_)
+}
+
+// RelayAttestation is a paid mutator transaction binding the contract method 0x6af511d2.
+//
+// Solidity: function relayAttestation((bytes,int64,uint64,uint256) attestation_) returns()
+func (_TrustedRelayerOracle *TrustedRelayerOracleTransactorSession) RelayAttestation(attestation_ DataAttestation) (*types.Transaction, error) {
+	return _TrustedRelayerOracle.Contract.RelayAttestation(&_TrustedRelayerOracle.TransactOpts, attestation_)
+}
+
+// SetReceiver is a paid mutator transaction binding the contract method 0x718da7ee.
+//
+// Solidity: function setReceiver(address receiver_) returns()
+func (_TrustedRelayerOracle *TrustedRelayerOracleTransactor) SetReceiver(opts *bind.TransactOpts, receiver_ common.Address) (*types.Transaction, error) {
+	return _TrustedRelayerOracle.contract.Transact(opts, "setReceiver", receiver_)
+}
+
+// SetReceiver is a paid mutator transaction binding the contract method 0x718da7ee.
+//
+// Solidity: function setReceiver(address receiver_) returns()
+func (_TrustedRelayerOracle *TrustedRelayerOracleSession) SetReceiver(receiver_ common.Address) (*types.Transaction, error) {
+	return _TrustedRelayerOracle.Contract.SetReceiver(&_TrustedRelayerOracle.TransactOpts, receiver_)
+}
+
+// SetReceiver is a paid mutator transaction binding the contract method 0x718da7ee.
+//
+// Solidity: function setReceiver(address receiver_) returns()
+func (_TrustedRelayerOracle *TrustedRelayerOracleTransactorSession) SetReceiver(receiver_ common.Address) (*types.Transaction, error) {
+	return _TrustedRelayerOracle.Contract.SetReceiver(&_TrustedRelayerOracle.TransactOpts, receiver_)
+}
+
+// SetRelayer is a paid mutator transaction binding the contract method 0xecd8dc3a.
+//
+// Solidity: function setRelayer(address relayer_, bool trusted_) returns()
+func (_TrustedRelayerOracle *TrustedRelayerOracleTransactor) SetRelayer(opts *bind.TransactOpts, relayer_ common.Address, trusted_ bool) (*types.Transaction, error) {
+	return _TrustedRelayerOracle.contract.Transact(opts, "setRelayer", relayer_, trusted_)
+}
+
+// SetRelayer is a paid mutator transaction binding the contract method 0xecd8dc3a.
+//
+// Solidity: function setRelayer(address relayer_, bool trusted_) returns()
+func (_TrustedRelayerOracle *TrustedRelayerOracleSession) SetRelayer(relayer_ common.Address, trusted_ bool) (*types.Transaction, error) {
+	return _TrustedRelayerOracle.Contract.SetRelayer(&_TrustedRelayerOracle.TransactOpts, relayer_, trusted_)
+}
+
+// SetRelayer is a paid mutator transaction binding the contract method 0xecd8dc3a.
+//
+// Solidity: function setRelayer(address relayer_, bool trusted_) returns()
+func (_TrustedRelayerOracle *TrustedRelayerOracleTransactorSession) SetRelayer(relayer_ common.Address, trusted_ bool) (*types.Transaction, error) {
+	return _TrustedRelayerOracle.Contract.SetRelayer(&_TrustedRelayerOracle.TransactOpts, relayer_, trusted_)
+}
+
+// TrustedRelayerOracleReceivedAttestationIterator is returned from FilterReceivedAttestation and is used to iterate over the raw logs and unpacked data for ReceivedAttestation events raised by the TrustedRelayerOracle contract.
+type TrustedRelayerOracleReceivedAttestationIterator struct {
+	Event *TrustedRelayerOracleReceivedAttestation // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *TrustedRelayerOracleReceivedAttestationIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(TrustedRelayerOracleReceivedAttestation)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(TrustedRelayerOracleReceivedAttestation)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *TrustedRelayerOracleReceivedAttestationIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *TrustedRelayerOracleReceivedAttestationIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// TrustedRelayerOracleReceivedAttestation represents a ReceivedAttestation event raised by the TrustedRelayerOracle contract.
+type TrustedRelayerOracleReceivedAttestation struct {
+	CommP   []byte
+	DealID  uint64
+	Relayer common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterReceivedAttestation is a free log retrieval operation binding the contract event 0x32dccd99fe351db77ab127d80bca3951426522d137b9cb2999a834e98da92431.
+//
+// Solidity: event ReceivedAttestation(bytes commP, uint64 dealID, address relayer)
+func (_TrustedRelayerOracle *TrustedRelayerOracleFilterer) FilterReceivedAttestation(opts *bind.FilterOpts) (*TrustedRelayerOracleReceivedAttestationIterator, error) {
+
+	logs, sub, err := _TrustedRelayerOracle.contract.FilterLogs(opts, "ReceivedAttestation")
+	if err != nil {
+		return nil, err
+	}
+	return &TrustedRelayerOracleReceivedAttestationIterator{contract: _TrustedRelayerOracle.contract, event: "ReceivedAttestation", logs: logs, sub: sub}, nil
+}
+
+// WatchReceivedAttestation is a free log subscription operation binding the contract event 0x32dccd99fe351db77ab127d80bca3951426522d137b9cb2999a834e98da92431.
+//
+// Solidity: event ReceivedAttestation(bytes commP, uint64 dealID, address relayer)
+func (_TrustedRelayerOracle *TrustedRelayerOracleFilterer) WatchReceivedAttestation(opts *bind.WatchOpts, sink chan<- *TrustedRelayerOracleReceivedAttestation) (event.Subscription, error) {
+
+	logs, sub, err := _TrustedRelayerOracle.contract.WatchLogs(opts, "ReceivedAttestation")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(TrustedRelayerOracleReceivedAttestation)
+				if err := _TrustedRelayerOracle.contract.UnpackLog(event, "ReceivedAttestation", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseReceivedAttestation is a log parse operation binding the contract event 0x32dccd99fe351db77ab127d80bca3951426522d137b9cb2999a834e98da92431.
+//
+// Solidity: event ReceivedAttestation(bytes commP, uint64 dealID, address relayer)
+func (_TrustedRelayerOracle *TrustedRelayerOracleFilterer) ParseReceivedAttestation(log types.Log) (*TrustedRelayerOracleReceivedAttestation, error) {
+	event := new(TrustedRelayerOracleReceivedAttestation)
+	if err := _TrustedRelayerOracle.contract.UnpackLog(event, "ReceivedAttestation", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// TrustedRelayerOracleRelayerSetIterator is returned from FilterRelayerSet and is used to iterate over the raw logs and unpacked data for RelayerSet events raised by the TrustedRelayerOracle contract.
+type TrustedRelayerOracleRelayerSetIterator struct {
+	Event *TrustedRelayerOracleRelayerSet // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *TrustedRelayerOracleRelayerSetIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(TrustedRelayerOracleRelayerSet)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(TrustedRelayerOracleRelayerSet)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *TrustedRelayerOracleRelayerSetIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *TrustedRelayerOracleRelayerSetIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// TrustedRelayerOracleRelayerSet represents a RelayerSet event raised by the TrustedRelayerOracle contract.
+type TrustedRelayerOracleRelayerSet struct {
+	Relayer common.Address
+	Trusted bool
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterRelayerSet is a free log retrieval operation binding the contract event 0xee42947bc760229eeff964017ac68eddb00ba98b0defcd90a1bc85a5afceb057.
+//
+// Solidity: event RelayerSet(address relayer, bool trusted)
+func (_TrustedRelayerOracle *TrustedRelayerOracleFilterer) FilterRelayerSet(opts *bind.FilterOpts) (*TrustedRelayerOracleRelayerSetIterator, error) {
+
+	logs, sub, err := _TrustedRelayerOracle.contract.FilterLogs(opts, "RelayerSet")
+	if err != nil {
+		return nil, err
+	}
+	return &TrustedRelayerOracleRelayerSetIterator{contract: _TrustedRelayerOracle.contract, event: "RelayerSet", logs: logs, sub: sub}, nil
+}
+
+// WatchRelayerSet is a free log subscription operation binding the contract event 0xee42947bc760229eeff964017ac68eddb00ba98b0defcd90a1bc85a5afceb057.
+//
+// Solidity: event RelayerSet(address relayer, bool trusted)
+func (_TrustedRelayerOracle *TrustedRelayerOracleFilterer) WatchRelayerSet(opts *bind.WatchOpts, sink chan<- *TrustedRelayerOracleRelayerSet) (event.Subscription, error) {
+
+	logs, sub, err := _TrustedRelayerOracle.contract.WatchLogs(opts, "RelayerSet")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(TrustedRelayerOracleRelayerSet)
+				if err := _TrustedRelayerOracle.contract.UnpackLog(event, "RelayerSet", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRelayerSet is a log parse operation binding the contract event 0xee42947bc760229eeff964017ac68eddb00ba98b0defcd90a1bc85a5afceb057.
+//
+// Solidity: event RelayerSet(address relayer, bool trusted)
+func (_TrustedRelayerOracle *TrustedRelayerOracleFilterer) ParseRelayerSet(log types.Log) (*TrustedRelayerOracleRelayerSet, error) {
+	event := new(TrustedRelayerOracleRelayerSet)
+	if err := _TrustedRelayerOracle.contract.UnpackLog(event, "RelayerSet", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,94 @@
+// Package logging sets up the structured logger of the xchain commands.
+//
+// Services log through log/slog with fields naming what a message is about:
+// chain, offerID, aggCommP, transferID, dealUUID and dealID. Attributes whose
+// key names a secret are redacted by every handler made here.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// Field keys shared by the services
+const (
+	Chain      = "chain"
+	OfferID    = "offerID"
+	AggCommP   = "aggCommP"
+	TransferID = "transferID"
+	DealUUID   = "dealUUID"
+	DealID     = "dealID"
+)
+
+// Redacted replaces the value of secret attributes
+const Redacted = "[redacted]"
+
+// secretFragments are lower case fragments of attribute keys that always name secrets
+var secretFragments = []string{"apikey", "secret", "password", "passphrase", "accesskey"}
+
+// secretNames are attribute keys naming secrets, in lower case without separators.
+// They are matched whole, a token on its own is the address of a payment token.
+var secretNames = map[string]bool{
+	"auth":           true,
+	"authorization":  true,
+	"authtoken":      true,
+	"apitoken":       true,
+	"bearertoken":    true,
+	"accesstoken":    true,
+	"admintoken":     true,
+	"lighthouseauth": true,
+}
+
+// IsSecret reports whether an attribute or config field named key holds a secret
+func IsSecret(key string) bool {
+	key = strings.ToLower(strings.NewReplacer("_", "", "-", "", ".", "").Replace(key))
+	if secretNames[key] {
+		return true
+	}
+	for _, s := range secretFragments {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewHandler returns a text or json handler writing records of level and above to w
+func NewHandler(w io.Writer, level, format string) (slog.Handler, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q: %w", level, err)
+	}
+	opts := &slog.HandlerOptions{Level: lvl, ReplaceAttr: redact}
+	switch format {
+	case "text", "":
+		return slog.NewTextHandler(w, opts), nil
+	case "json":
+		return slog.NewJSONHandler(w, opts), nil
+	default:
+		return nil, fmt.Errorf("invalid log format %q, expected text or json", format)
+	}
+}
+
+// Setup makes the handler for level and format the default logger. Messages
+// still written with the log package go through it at info level.
+func Setup(w io.Writer, level, format string) error {
+	h, err := NewHandler(w, level, format)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(slog.New(h))
+	return nil
+}
+
+func redact(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey || a.Key == slog.MessageKey) {
+		return a
+	}
+	if IsSecret(a.Key) && a.Value.Kind() != slog.KindGroup {
+		return slog.String(a.Key, Redacted)
+	}
+	return a
+}
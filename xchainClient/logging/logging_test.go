@@ -0,0 +1,62 @@
+package logging_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/FIL-Builders/xchainClient/config"
+	"github.com/FIL-Builders/xchainClient/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedaction(t *testing.T) {
+	var buf bytes.Buffer
+	h, err := logging.NewHandler(&buf, "info", "json")
+	require.NoError(t, err)
+	logger := slog.New(h)
+
+	cfg := config.Config{LighthouseApiKey: "lh-key", AdminToken: "admin", ProviderAddr: "t01000"}
+	cfg.S3.SecretKey = "s3-secret"
+	logger.Info("Loaded configuration", "config", cfg, "apiKey", "raw", logging.OfferID, 7)
+	logger.Debug("hidden below the level")
+
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	require.Equal(t, logging.Redacted, record["apiKey"])
+	require.Equal(t, 7.0, record[logging.OfferID])
+	logged := record["config"].(map[string]interface{})
+	require.Equal(t, logging.Redacted, logged["LighthouseApiKey"])
+	require.Equal(t, logging.Redacted, logged["AdminToken"])
+	require.Equal(t, "", logged["LighthouseAuth"], "empty secrets stay empty")
+	require.Equal(t, "t01000", logged["ProviderAddr"])
+	require.Equal(t, logging.Redacted, logged["S3"].(map[string]interface{})["SecretKey"])
+	require.NotContains(t, buf.String(), "lh-key")
+	require.NotContains(t, buf.String(), "s3-secret")
+	require.NotContains(t, buf.String(), "hidden")
+
+	buf.Reset()
+	h, err = logging.NewHandler(&buf, "debug", "text")
+	require.NoError(t, err)
+	slog.New(h).Debug("Loaded configuration", "config", cfg)
+	require.Contains(t, buf.String(), "level=DEBUG")
+	require.NotContains(t, buf.String(), "lh-key")
+}
+
+func TestIsSecret(t *testing.T) {
+	for _, key := range []string{"apiKey", "LighthouseApiKey", "LighthouseAuth", "AdminToken", "auth_token", "Authorization", "SecretKey", "AccessKey", "password"} {
+		require.True(t, logging.IsSecret(key), key)
+	}
+	// Payment tokens are public addresses
+	for _, key := range []string{"token", "paymentToken", "Token", logging.OfferID, "author"} {
+		require.False(t, logging.IsSecret(key), key)
+	}
+}
+
+func TestNewHandler(t *testing.T) {
+	_, err := logging.NewHandler(&bytes.Buffer{}, "loud", "text")
+	require.ErrorContains(t, err, "invalid log level")
+	_, err = logging.NewHandler(&bytes.Buffer{}, "warn", "xml")
+	require.ErrorContains(t, err, "invalid log format")
+}
@@ -0,0 +1,175 @@
+// Package metrics holds the Prometheus metrics of the daemon services and
+// serves them at /metrics.
+//
+// Source chains are labelled by chain ID and storage providers by actor address.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "xchain"
+
+// Reasons offers are rejected by the aggregator
+const (
+	RejectInvalidSize = "invalid_size" // size is not a valid padded piece size
+	RejectTooLarge    = "too_large"    // does not fit in an aggregate on its own
+	RejectDropped     = "dropped"      // dropped through the admin API
+)
+
+// Outcomes of deal proposals and uploads
+const (
+	OutcomeAccepted = "accepted"
+	OutcomeRejected = "rejected"
+	OutcomeFailed   = "failed"
+	OutcomeSuccess  = "success"
+)
+
+// Registry holds the xchain metrics along with the Go runtime and process collectors
+var Registry = prometheus.NewRegistry()
+
+var factory = promauto.With(Registry)
+
+var (
+	OffersReceived = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "offers_received_total",
+		Help:      "Offers received from DataReady events.",
+	}, []string{"chain"})
+	OffersRejected = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "offers_rejected_total",
+		Help:      "Offers the aggregator did not aggregate, by reason.",
+	}, []string{"chain", "reason"})
+	OffersAggregated = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "offers_aggregated_total",
+		Help:      "Offers committed to the OnRamp in an aggregate.",
+	}, []string{"chain"})
+	PendingOffers = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "pending_offers",
+		Help:      "Offers waiting to be aggregated.",
+	}, []string{"chain"})
+	PendingBytes = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "pending_bytes",
+		Help:      "Padded size of the offers waiting to be aggregated.",
+	}, []string{"chain"})
+
+	AggregateFillRatio = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "aggregate_fill_ratio",
+		Help:      "Share of the deal size taken by the offered pieces of an aggregate.",
+		Buckets:   prometheus.LinearBuckets(0.1, 0.1, 10),
+	}, []string{"chain"})
+	AggregatePaddingBytes = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "aggregate_padding_bytes_total",
+		Help:      "Bytes of deals not taken by offered pieces, padding and data segment index.",
+	}, []string{"chain"})
+	CommitAggregateGas = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "commit_aggregate_gas_used",
+		Help:      "Gas used by commitAggregate transactions.",
+		Buckets:   prometheus.ExponentialBuckets(100_000, 2, 10),
+	}, []string{"chain"})
+	CommitAggregateSeconds = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "commit_aggregate_seconds",
+		Help:      "Time from sending a commitAggregate transaction until it is mined.",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 10),
+	}, []string{"chain"})
+
+	DealProposals = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "deal_proposals_total",
+		Help:      "Deal proposals sent to storage providers, by outcome.",
+	}, []string{"provider", "outcome"})
+	DealsRelayed = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "deals_relayed_total",
+		Help:      "Deal attestations sent by the deal relayer, through the Prover or the trusted relayer oracle.",
+	}, []string{"chain", "via"})
+
+	TransferBytes = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transfer_bytes_total",
+		Help:      "Aggregate bytes served to storage providers by the transfer server.",
+	}, []string{"chain"})
+	LighthouseUploadSeconds = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "lighthouse_upload_seconds",
+		Help:      "Duration of uploads to Lighthouse, by outcome.",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+	}, []string{"outcome"})
+)
+
+func init() {
+	Registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+}
+
+// WatchBufferDisk reports the bytes stored under path as the buffer disk usage,
+// measured when metrics are scraped
+func WatchBufferDisk(path string) error {
+	return Registry.Register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "buffer_disk_bytes",
+		Help:      "Bytes stored by the buffer service, including unfinished uploads.",
+	}, func() float64 {
+		size, err := dirSize(path)
+		if err != nil {
+			slog.Warn("Failed to measure buffer disk usage", "err", err)
+		}
+		return float64(size)
+	}))
+}
+
+func dirSize(path string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+// Serve serves the metrics at /metrics on addr until the context is canceled
+func Serve(ctx context.Context, addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry}))
+	server := &http.Server{
+		Addr:    addr,
+		Handler: mux,
+	}
+	slog.Info("Metrics server starting", "addr", addr)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- server.ListenAndServe()
+	}()
+	select {
+	case err := <-errc:
+		return fmt.Errorf("metrics server: %w", err)
+	case <-ctx.Done():
+	}
+	return server.Shutdown(context.Background())
+}
@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBufferDisk(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "data_0"), make([]byte, 100), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "uploads"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "uploads", "upload_1"), make([]byte, 28), 0644))
+
+	size, err := dirSize(dir)
+	require.NoError(t, err)
+	require.Equal(t, int64(128), size)
+
+	require.NoError(t, WatchBufferDisk(dir))
+	require.Error(t, WatchBufferDisk(dir), "the buffer disk usage is registered once")
+
+	OffersReceived.WithLabelValues("43113").Inc()
+	require.Equal(t, 1.0, testutil.ToFloat64(OffersReceived.WithLabelValues("43113")))
+
+	rec := httptest.NewRecorder()
+	promhttp.HandlerFor(Registry, promhttp.HandlerOpts{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "xchain_buffer_disk_bytes 128")
+	require.Contains(t, rec.Body.String(), `xchain_offers_received_total{chain="43113"} 1`)
+	require.Contains(t, rec.Body.String(), "go_goroutines")
+}
@@ -0,0 +1,376 @@
+package aggregator
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/FIL-Builders/xchainClient/logging"
+	"github.com/FIL-Builders/xchainClient/metrics"
+	"github.com/ipfs/go-cid"
+)
+
+// Admin API, served on AdminIP:AdminPort when AdminPort is set
+//
+//	GET    /status                        ingestion state and pending totals
+//	GET    /offers                        offers waiting to be aggregated
+//	DELETE /offers/{id}                   drop a pending offer
+//	GET    /aggregates                    aggregates made since start and their states
+//	POST   /aggregates/flush              aggregate the pending offers now, below the minimum deal size
+//	GET    /transfers                     aggregates served to the storage provider
+//	GET    /deals                         deals made since start and their states
+//	POST   /aggregates/{commP}/retry      run a failed aggregate again from the step it failed at
+//	POST   /deals/{commP}/retry           propose a failed deal again, same as retrying its aggregate
+//	POST   /ingestion/pause               leave new offers waiting instead of aggregating them
+//	POST   /ingestion/resume              aggregate new offers again
+//	GET    /timeline?offer=<id>           offer timelines, see timelineHandler
+//
+// When AdminToken is set every request needs an `Authorization: Bearer <AdminToken>` header.
+
+// DefaultAdminIP keeps the admin API local unless AdminIP says otherwise
+const DefaultAdminIP = "127.0.0.1"
+
+// Aggregate states
+const (
+	AggregateCommitting   = "committing"
+	AggregateSaving       = "saving"
+	AggregateUploading    = "uploading"
+	AggregateProposing    = "proposing deal"
+	AggregateDealProposed = "deal proposed"
+	AggregateFailed       = "failed"
+	AggregateDealFailed   = "deal failed"
+)
+
+// Deal states beyond the aggregate states, from the offer timeline
+const (
+	DealProven = "proven"
+	DealActive = "active"
+)
+
+// AggregateInfo is an aggregate made by the aggregator
+type AggregateInfo struct {
+	CommP      string    `json:"commP"`
+	Size       uint64    `json:"size"`
+	OfferIDs   []uint64  `json:"offerIds"`
+	TransferID int       `json:"transferId"`
+	URL        string    `json:"url,omitempty"`
+	State      string    `json:"state"`
+	DealUUID   string    `json:"dealUuid,omitempty"`
+	FailedStep string    `json:"failedStep,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Attempts   int       `json:"attempts,omitempty"` // failed attempts of the current step
+	Updated    time.Time `json:"updated"`
+}
+
+func (info *AggregateInfo) failed() bool {
+	return info.State == AggregateFailed || info.State == AggregateDealFailed
+}
+
+// PendingOffer is an offer waiting to be aggregated
+type PendingOffer struct {
+	ID       uint64 `json:"id"`
+	CommP    string `json:"commP"`
+	Size     uint64 `json:"size"`
+	Location string `json:"location"`
+	Token    string `json:"token"`
+	Amount   string `json:"amount"`
+}
+
+// TransferInfo is an aggregate the storage provider can fetch from the transfer server
+type TransferInfo struct {
+	ID        int      `json:"id"`
+	CommP     string   `json:"commP"`
+	Locations []string `json:"locations"`
+}
+
+// DealInfo is a deal proposed for an aggregate
+type DealInfo struct {
+	AggregateCommP string `json:"aggregateCommP"`
+	DealUUID       string `json:"dealUuid,omitempty"`
+	DealID         uint64 `json:"dealId,omitempty"`
+	State          string `json:"state"`
+	Error          string `json:"error,omitempty"`
+}
+
+// AdminStatus is the state of offer ingestion
+type AdminStatus struct {
+	Paused         bool   `json:"paused"`
+	PendingOffers  int    `json:"pendingOffers"`
+	PendingBytes   uint64 `json:"pendingBytes"`
+	QueuedEvents   int    `json:"queuedEvents"`
+	TargetDealSize uint64 `json:"targetDealSize"`
+	MinDealSize    uint64 `json:"minDealSize"`
+}
+
+func (a *aggregator) trackAggregate(job *aggregateJob) {
+	a.aggregatesLk.Lock()
+	defer a.aggregatesLk.Unlock()
+	a.jobs[job.commP.String()] = job
+	a.aggregates[job.commP.String()] = &AggregateInfo{
+		CommP:    job.commP.String(),
+		Size:     uint64(job.dealSize),
+		OfferIDs: job.ids,
+		State:    AggregateCommitting,
+		Updated:  time.Now(),
+	}
+}
+
+func (a *aggregator) updateAggregate(commP cid.Cid, update func(*AggregateInfo)) {
+	a.aggregatesLk.Lock()
+	defer a.aggregatesLk.Unlock()
+	if info, ok := a.aggregates[commP.String()]; ok {
+		update(info)
+		info.Updated = time.Now()
+	}
+}
+
+// listAggregates returns copies of the aggregates, least recently updated first
+func (a *aggregator) listAggregates() []AggregateInfo {
+	a.aggregatesLk.RLock()
+	defer a.aggregatesLk.RUnlock()
+	aggregates := make([]AggregateInfo, 0, len(a.aggregates))
+	for _, info := range a.aggregates {
+		aggregates = append(aggregates, *info)
+	}
+	sort.Slice(aggregates, func(i, j int) bool {
+		if !aggregates[i].Updated.Equal(aggregates[j].Updated) {
+			return aggregates[i].Updated.Before(aggregates[j].Updated)
+		}
+		return aggregates[i].CommP < aggregates[j].CommP
+	})
+	return aggregates
+}
+
+// dropOffer removes a pending offer, it reports whether the offer was pending
+func (a *aggregator) dropOffer(id uint64) bool {
+	a.pendingLk.Lock()
+	defer a.pendingLk.Unlock()
+	for i, event := range a.pending {
+		if event.OfferID == id {
+			a.pending = append(a.pending[:i], a.pending[i+1:]...)
+			a.pendingChanged()
+			metrics.OffersRejected.WithLabelValues(a.chain, metrics.RejectDropped).Inc()
+			return true
+		}
+	}
+	return false
+}
+
+func (a *aggregator) serveAdmin(ctx context.Context) error {
+	server := &http.Server{
+		Addr:    a.adminAddr,
+		Handler: a.adminHandler(),
+	}
+	a.logger.Info("Admin API starting", "addr", a.adminAddr)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- server.ListenAndServe()
+	}()
+	select {
+	case err := <-errc:
+		return fmt.Errorf("admin API: %w", err)
+	case <-ctx.Done():
+	}
+	a.logger.Info("Shutting down admin API")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), a.grace)
+	defer cancel()
+	return server.Shutdown(shutdownCtx)
+}
+
+func (a *aggregator) adminHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /status", a.statusHandler)
+	mux.HandleFunc("GET /offers", a.offersHandler)
+	mux.HandleFunc("DELETE /offers/{id}", a.dropOfferHandler)
+	mux.HandleFunc("GET /aggregates", a.aggregatesHandler)
+	mux.HandleFunc("POST /aggregates/flush", a.flushHandler)
+	mux.HandleFunc("POST /aggregates/{commP}/retry", a.retryHandler)
+	mux.HandleFunc("GET /transfers", a.transfersHandler)
+	mux.HandleFunc("GET /deals", a.dealsHandler)
+	mux.HandleFunc("POST /deals/{commP}/retry", a.retryHandler)
+	mux.HandleFunc("POST /ingestion/pause", a.pauseHandler)
+	mux.HandleFunc("POST /ingestion/resume", a.resumeHandler)
+	mux.HandleFunc("GET /timeline", a.timelineHandler)
+	if a.adminToken == "" {
+		return mux
+	}
+	want := []byte("Bearer " + a.adminToken)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), want) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func (a *aggregator) statusHandler(w http.ResponseWriter, r *http.Request) {
+	status := AdminStatus{
+		Paused:         a.paused.Load(),
+		QueuedEvents:   len(a.ch),
+		TargetDealSize: a.targetDealSize,
+		MinDealSize:    a.minDealSize,
+	}
+	a.pendingLk.Lock()
+	status.PendingOffers = len(a.pending)
+	for _, event := range a.pending {
+		status.PendingBytes += event.Offer.Size
+	}
+	a.pendingLk.Unlock()
+	writeJSON(w, status)
+}
+
+func (a *aggregator) offersHandler(w http.ResponseWriter, r *http.Request) {
+	a.pendingLk.Lock()
+	offers := make([]PendingOffer, len(a.pending))
+	for i, event := range a.pending {
+		offers[i] = PendingOffer{
+			ID:       event.OfferID,
+			CommP:    commPString(event.Offer.CommP),
+			Size:     event.Offer.Size,
+			Location: event.Offer.Location,
+			Token:    event.Offer.Token.Hex(),
+			Amount:   event.Offer.Amount.String(),
+		}
+	}
+	a.pendingLk.Unlock()
+	writeJSON(w, offers)
+}
+
+func (a *aggregator) dropOfferHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid offer ID", http.StatusBadRequest)
+		return
+	}
+	if !a.dropOffer(id) {
+		http.Error(w, fmt.Sprintf("Offer %d is not pending", id), http.StatusNotFound)
+		return
+	}
+	a.logger.Info("Dropped pending offer", logging.OfferID, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *aggregator) aggregatesHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, a.listAggregates())
+}
+
+func (a *aggregator) flushHandler(w http.ResponseWriter, r *http.Request) {
+	a.pendingLk.Lock()
+	pending := len(a.pending)
+	a.pendingLk.Unlock()
+	if pending == 0 {
+		http.Error(w, "No pending offers", http.StatusConflict)
+		return
+	}
+	select {
+	case a.flush <- struct{}{}:
+	default: // a flush is already requested
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (a *aggregator) transfersHandler(w http.ResponseWriter, r *http.Request) {
+	a.transferLk.RLock()
+	transfers := make([]TransferInfo, 0, len(a.transfers))
+	for id, transfer := range a.transfers {
+		info := TransferInfo{ID: id, Locations: transfer.locations}
+		if commP, err := transfer.agg.PieceCID(); err == nil {
+			info.CommP = commP.String()
+		}
+		transfers = append(transfers, info)
+	}
+	a.transferLk.RUnlock()
+	sort.Slice(transfers, func(i, j int) bool { return transfers[i].ID < transfers[j].ID })
+	writeJSON(w, transfers)
+}
+
+func (a *aggregator) dealsHandler(w http.ResponseWriter, r *http.Request) {
+	var deals []DealInfo
+	for _, info := range a.listAggregates() {
+		if info.State != AggregateDealProposed && info.State != AggregateDealFailed {
+			continue
+		}
+		deal := DealInfo{AggregateCommP: info.CommP, DealUUID: info.DealUUID, State: info.State, Error: info.Error}
+		if len(info.OfferIDs) > 0 {
+			// Proofs and activation are recorded per offer, all offers of an aggregate share them
+			if offers := a.timeline.list(info.OfferIDs[0]); len(offers) == 1 {
+				deal.DealID = offers[0].DealID
+				if offers[0].Proven != nil {
+					deal.State = DealProven
+				}
+				if offers[0].DealActive != nil {
+					deal.State = DealActive
+				}
+			}
+		}
+		deals = append(deals, deal)
+	}
+	writeJSON(w, deals)
+}
+
+// retryHandler queues a failed aggregate to be retried by the aggregation loop,
+// which runs every aggregate step so transactions are sent one at a time
+func (a *aggregator) retryHandler(w http.ResponseWriter, r *http.Request) {
+	commP, err := cid.Decode(r.PathValue("commP"))
+	if err != nil {
+		http.Error(w, "Invalid aggregate CommP", http.StatusBadRequest)
+		return
+	}
+	a.aggregatesLk.RLock()
+	info, ok := a.aggregates[commP.String()]
+	var state string
+	if ok {
+		state = info.State
+		ok = info.failed()
+	}
+	a.aggregatesLk.RUnlock()
+	if state == "" {
+		http.Error(w, "Unknown aggregate", http.StatusNotFound)
+		return
+	}
+	if !ok {
+		http.Error(w, fmt.Sprintf("Aggregate is %s, only failed aggregates are retried", state), http.StatusConflict)
+		return
+	}
+	select {
+	case a.retries <- commP.String():
+	default:
+		http.Error(w, "Too many retries queued", http.StatusServiceUnavailable)
+		return
+	}
+	a.logger.Info("Queued aggregate retry", logging.AggCommP, commP)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (a *aggregator) pauseHandler(w http.ResponseWriter, r *http.Request) {
+	if !a.paused.Swap(true) {
+		a.logger.Info("Offer ingestion paused")
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *aggregator) resumeHandler(w http.ResponseWriter, r *http.Request) {
+	if a.paused.Swap(false) {
+		a.logger.Info("Offer ingestion resumed")
+		select {
+		case a.wake <- struct{}{}:
+		default:
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Warn("Failed to write response", "err", err)
+	}
+}
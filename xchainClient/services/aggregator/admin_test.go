@@ -0,0 +1,212 @@
+package aggregator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/FIL-Builders/xchainClient/metrics"
+	filabi "github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func testAdmin(t *testing.T, token string) (*aggregator, http.Handler) {
+	tl, err := openTimeline(filepath.Join(t.TempDir(), "1.json"), 1)
+	require.NoError(t, err)
+	a := &aggregator{
+		ch:             make(chan DataReadyEvent, 8),
+		transfers:      make(map[int]AggregateTransfer),
+		aggregates:     make(map[string]*AggregateInfo),
+		jobs:           make(map[string]*aggregateJob),
+		retries:        make(chan string, 1),
+		flush:          make(chan struct{}, 1),
+		wake:           make(chan struct{}, 1),
+		timeline:       tl,
+		adminToken:     token,
+		minDealSize:    1 << 20,
+		targetDealSize: 1 << 20,
+		chain:          t.Name(),
+		logger:         slog.Default(),
+	}
+	for i := uint64(1); i <= 3; i++ {
+		piece := testPiece(t, byte(i), 2048)
+		a.pending = append(a.pending, DataReadyEvent{OfferID: i, Offer: Offer{
+			CommP:    piece.PieceCID.Bytes(),
+			Size:     uint64(piece.Size),
+			Location: "http://buffer/get?id=1",
+			Amount:   big.NewInt(0),
+		}})
+	}
+	return a, a.adminHandler()
+}
+
+func serve(h http.Handler, method, target, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAdminAuth(t *testing.T) {
+	_, h := testAdmin(t, "secret")
+	require.Equal(t, http.StatusUnauthorized, serve(h, http.MethodGet, "/status", "").Code)
+	require.Equal(t, http.StatusUnauthorized, serve(h, http.MethodGet, "/status", "wrong").Code)
+	require.Equal(t, http.StatusOK, serve(h, http.MethodGet, "/status", "secret").Code)
+
+	_, open := testAdmin(t, "")
+	require.Equal(t, http.StatusOK, serve(open, http.MethodGet, "/status", "").Code)
+}
+
+func TestAdminOffers(t *testing.T) {
+	a, h := testAdmin(t, "")
+
+	rec := serve(h, http.MethodDelete, "/offers/2", "")
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	require.Equal(t, 2.0, testutil.ToFloat64(metrics.PendingOffers.WithLabelValues(a.chain)))
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.OffersRejected.WithLabelValues(a.chain, metrics.RejectDropped)))
+	require.Equal(t, http.StatusNotFound, serve(h, http.MethodDelete, "/offers/2", "").Code)
+	require.Equal(t, http.StatusBadRequest, serve(h, http.MethodDelete, "/offers/x", "").Code)
+
+	var offers []PendingOffer
+	require.NoError(t, json.Unmarshal(serve(h, http.MethodGet, "/offers", "").Body.Bytes(), &offers))
+	require.Len(t, offers, 2)
+	require.Equal(t, []uint64{1, 3}, []uint64{offers[0].ID, offers[1].ID})
+
+	var status AdminStatus
+	require.NoError(t, json.Unmarshal(serve(h, http.MethodGet, "/status", "").Body.Bytes(), &status))
+	require.Equal(t, 2, status.PendingOffers)
+	require.Equal(t, uint64(4096), status.PendingBytes)
+
+	// Below the minimum deal size the offers wait unless flushed
+	pending, _, err := a.takePending(false)
+	require.NoError(t, err)
+	require.Nil(t, pending)
+	require.Equal(t, http.StatusAccepted, serve(h, http.MethodPost, "/aggregates/flush", "").Code)
+	require.Len(t, a.flush, 1)
+	pending, dealSize, err := a.takePending(true)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	require.Equal(t, 8192, int(dealSize))
+	require.Equal(t, http.StatusConflict, serve(h, http.MethodPost, "/aggregates/flush", "").Code)
+
+	// Status is served while an aggregate is made, smaller aggregates leave the target as configured
+	a.steps = []aggregateStep{{name: StepCommit, state: AggregateCommitting, run: func(context.Context, *aggregateJob) error { return nil }}}
+	done := make(chan error)
+	go func() {
+		done <- a.aggregate(context.Background(), pending, dealSize)
+	}()
+	require.NoError(t, json.Unmarshal(serve(h, http.MethodGet, "/status", "").Body.Bytes(), &status))
+	require.NoError(t, <-done)
+	require.NoError(t, json.Unmarshal(serve(h, http.MethodGet, "/status", "").Body.Bytes(), &status))
+	require.Equal(t, uint64(1<<20), status.TargetDealSize)
+}
+
+func TestAdminIngestion(t *testing.T) {
+	a, h := testAdmin(t, "")
+	require.Equal(t, http.StatusNoContent, serve(h, http.MethodPost, "/ingestion/pause", "").Code)
+	require.True(t, a.paused.Load())
+	require.Equal(t, http.StatusNoContent, serve(h, http.MethodPost, "/ingestion/resume", "").Code)
+	require.False(t, a.paused.Load())
+	require.Len(t, a.wake, 1)
+	require.Equal(t, http.StatusMethodNotAllowed, serve(h, http.MethodGet, "/ingestion/pause", "").Code)
+}
+
+func TestAdminDeals(t *testing.T) {
+	a, h := testAdmin(t, "")
+	proven := testPiece(t, 10, 1<<20)
+	failed := testPiece(t, 11, 1<<20)
+	a.trackAggregate(testJob(proven.PieceCID, 1<<20, 1))
+	a.updateAggregate(proven.PieceCID, func(info *AggregateInfo) {
+		info.State = AggregateDealProposed
+		info.DealUUID = "uuid"
+	})
+	a.trackAggregate(testJob(failed.PieceCID, 1<<20, 2))
+	a.updateAggregate(failed.PieceCID, func(info *AggregateInfo) {
+		info.State = AggregateDealFailed
+		info.Error = "boom"
+	})
+	require.NoError(t, a.timeline.aggregated(1, proven.PieceCID.Bytes(), []uint64{1}, time.Now(), 1))
+	require.NoError(t, a.timeline.proven(proven.PieceCID.Bytes(), 42, time.Now(), 2))
+
+	var deals []DealInfo
+	require.NoError(t, json.Unmarshal(serve(h, http.MethodGet, "/deals", "").Body.Bytes(), &deals))
+	require.Len(t, deals, 2)
+	require.Equal(t, DealInfo{AggregateCommP: proven.PieceCID.String(), DealUUID: "uuid", DealID: 42, State: DealProven}, deals[0])
+	require.Equal(t, AggregateDealFailed, deals[1].State)
+	require.Equal(t, "boom", deals[1].Error)
+
+	// Only failed deals are retried
+	rec := serve(h, http.MethodPost, "/deals/"+proven.PieceCID.String()+"/retry", "")
+	require.Equal(t, http.StatusConflict, rec.Code)
+	require.Contains(t, rec.Body.String(), "only failed aggregates")
+	require.Equal(t, http.StatusBadRequest, serve(h, http.MethodPost, "/deals/nope/retry", "").Code)
+	require.Equal(t, http.StatusNotFound, serve(h, http.MethodPost, "/deals/"+testPiece(t, 12, 1<<20).PieceCID.String()+"/retry", "").Code)
+	require.Equal(t, http.StatusAccepted, serve(h, http.MethodPost, "/deals/"+failed.PieceCID.String()+"/retry", "").Code)
+	require.Equal(t, failed.PieceCID.String(), <-a.retries)
+}
+
+func testJob(commP cid.Cid, size uint64, ids ...uint64) *aggregateJob {
+	return &aggregateJob{
+		commP:    commP,
+		dealSize: filabi.PaddedPieceSize(size),
+		ids:      ids,
+		logger:   slog.Default(),
+	}
+}
+
+func TestAggregateRetry(t *testing.T) {
+	a, h := testAdmin(t, "")
+	var ran []string
+	failing := map[string]error{StepUpload: errors.New("lighthouse down")}
+	for _, name := range []string{StepCommit, StepSave, StepUpload, StepDeal} {
+		name := name
+		a.steps = append(a.steps, aggregateStep{name: name, state: name, run: func(context.Context, *aggregateJob) error {
+			ran = append(ran, name)
+			return failing[name]
+		}})
+	}
+	commP := testPiece(t, 13, 1<<20).PieceCID
+	job := testJob(commP, 1<<20, 1, 2)
+	a.trackAggregate(job)
+
+	err := a.advance(context.Background(), job, StepCommit)
+	var aerr *AggregateError
+	require.ErrorAs(t, err, &aerr)
+	require.Equal(t, StepUpload, aerr.Step)
+	require.Equal(t, []string{StepCommit, StepSave, StepUpload}, ran)
+	info := a.listAggregates()[0]
+	require.Equal(t, AggregateFailed, info.State)
+	require.Equal(t, StepUpload, info.FailedStep)
+	require.Equal(t, "lighthouse down", info.Error)
+	require.Equal(t, 1, info.Attempts)
+
+	// A failed aggregate is resumed at the step it failed at
+	require.Equal(t, http.StatusAccepted, serve(h, http.MethodPost, "/aggregates/"+commP.String()+"/retry", "").Code)
+	delete(failing, StepUpload)
+	ran = nil
+	require.NoError(t, a.retryAggregate(context.Background(), <-a.retries))
+	require.Equal(t, []string{StepUpload, StepDeal}, ran)
+	require.Equal(t, StepDeal, a.listAggregates()[0].State)
+	require.Empty(t, a.listAggregates()[0].FailedStep)
+	require.Zero(t, a.listAggregates()[0].Attempts)
+
+	// Failed deals keep their own state and running aggregates are not retried
+	failing[StepDeal] = errors.New("rejected")
+	require.Error(t, a.advance(context.Background(), job, StepDeal))
+	require.Equal(t, AggregateDealFailed, a.listAggregates()[0].State)
+	a.updateAggregate(commP, func(info *AggregateInfo) { info.State = AggregateUploading })
+	require.Error(t, a.retryAggregate(context.Background(), commP.String()))
+	require.Equal(t, http.StatusConflict, serve(h, http.MethodPost, "/aggregates/"+commP.String()+"/retry", "").Code)
+}
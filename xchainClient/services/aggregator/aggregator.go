@@ -5,28 +5,30 @@ import (
 	"path/filepath"
 
 	"github.com/FIL-Builders/xchainClient/config"
+	"github.com/FIL-Builders/xchainClient/contracts"
+	"github.com/FIL-Builders/xchainClient/logging"
+	"github.com/FIL-Builders/xchainClient/metrics"
 	"github.com/FIL-Builders/xchainClient/services/buffer"
 	"github.com/FIL-Builders/xchainClient/utils"
 
 	"context"
 
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
-	"math/big"
+	"log/slog"
 	"math/bits"
 	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/sync/errgroup"
 
-	"github.com/ethereum/go-ethereum"
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -60,12 +62,72 @@ const (
 	DealProtocolv120 = "/fil/storage/mk/1.2.0"
 )
 
+// ErrDealRejected is returned when the storage provider turns a deal proposal down
+var ErrDealRejected = errors.New("deal proposal rejected")
+
+// ErrCommitReverted is returned when the commitAggregate transaction is mined but reverted
+var ErrCommitReverted = errors.New("commitAggregate transaction reverted")
+
+// Steps an aggregate goes through once it is made
+const (
+	StepCommit = "commit"
+	StepSave   = "save"
+	StepUpload = "upload"
+	StepDeal   = "deal"
+)
+
+const (
+	// retryInterval is how often failed aggregates are retried
+	retryInterval = 10 * time.Minute
+	// maxAggregateAttempts is how many times an aggregate step is tried before
+	// it is only retried through the admin API
+	maxAggregateAttempts = 5
+)
+
+// AggregateError is a failed step of an aggregate. The aggregate stays failed
+// at that step until it is retried.
+type AggregateError struct {
+	Step  string
+	CommP cid.Cid
+	Err   error
+}
+
+func (e *AggregateError) Error() string {
+	return fmt.Sprintf("aggregate %s failed at %s step: %s", e.CommP, e.Step, e.Err)
+}
+
+func (e *AggregateError) Unwrap() error {
+	return e.Err
+}
+
+type aggregateStep struct {
+	name  string
+	state string // state of the aggregate while the step runs
+	run   func(context.Context, *aggregateJob) error
+}
+
+// aggregateJob is what the steps of an aggregate need, kept to retry failed steps
+type aggregateJob struct {
+	commP       cid.Cid
+	agg         *datasegment.Aggregate
+	offers      []DataReadyEvent
+	pieces      []filabi.PieceInfo
+	dealSize    filabi.PaddedPieceSize
+	ids         []uint64
+	locations   []string
+	inclProofs  []contracts.PODSIVerifierProofData
+	indexProofs []contracts.PODSIVerifierProofData
+	transferID  int
+	path        string // aggregate file
+	url         string // where the storage provider fetches the aggregate
+	logger      *slog.Logger
+}
+
 type aggregator struct {
 	client           *ethclient.Client         // raw client for log subscriptions
-	onramp           *bind.BoundContract       // onramp binding over raw client for message sending
+	onramp           *contracts.OnRamp         // onramp binding over raw client for log subscription and message sending
 	auth             *bind.TransactOpts        // auth for message sending
-	abi              *abi.ABI                  // onramp abi for log subscription and message sending
-	onrampAddr       common.Address            // onramp address for log subscription
+	onrampAddr       common.Address            // onramp address
 	proverAddr       common.Address            // prover address for client contract deal
 	payoutAddr       common.Address            // aggregator payout address for receiving funds
 	ch               chan DataReadyEvent       // pass events to seperate goroutine for processing
@@ -74,7 +136,7 @@ type aggregator struct {
 	transferID       int                       // ID of the next transfer
 	transferAddr     string                    // address to listen for transfer requests
 	minDealSize      uint64                    // minimum deal size
-	targetDealSize   uint64                    // how big aggregates should be
+	targetDealSize   uint64                    // how big aggregates should be, read only once NewAggregator returns
 	dealDelayEpochs  uint64                    // when the deal will be active, in blocks
 	dealDuration     uint64                    // how long the deal will be active, in blocks
 	host             host.Host                 // libp2p host for deal protocol to boost
@@ -83,6 +145,23 @@ type aggregator struct {
 	lotusAPI         v0api.FullNode            // Lotus API for determining deal start epoch and collateral bounds
 	LighthouseAuth   string                    // Auth token to interact with Lighthouse Deal Engine
 	lighthouseApiKey string                    // API key for lighthouse
+	timeline         *timeline                 // when offers reached each stage, persisted locally
+	statePath        string                    // where pending offers and unfinished aggregates are saved on shutdown
+	grace            time.Duration             // how long in-flight work has to finish on shutdown
+	pending          []DataReadyEvent          // offers waiting to be aggregated
+	pendingLk        sync.Mutex                // Mutex protecting pending
+	aggregates       map[string]*AggregateInfo // aggregates made since start, by CommP
+	jobs             map[string]*aggregateJob  // data of the aggregates to run their steps, by CommP
+	steps            []aggregateStep           // steps each aggregate goes through
+	retries          chan string               // CommPs of failed aggregates to retry
+	aggregatesLk     sync.RWMutex              // Mutex protecting aggregates
+	paused           atomic.Bool               // whether new offers are left waiting in ch
+	flush            chan struct{}             // asks runAggregate to aggregate the pending offers now
+	wake             chan struct{}             // wakes runAggregate up when ingestion resumes
+	adminAddr        string                    // address to listen for admin requests, empty when disabled
+	adminToken       string                    // bearer token required by the admin API, if set
+	chain            string                    // source chain ID, labelling metrics
+	logger           *slog.Logger              // logger with the chain field set
 	cleanup          func()                    // cleanup function to call on shutdown
 }
 
@@ -92,15 +171,8 @@ type DataReadyEvent struct {
 	OfferID uint64
 }
 
-// Mirror OnRamp.sol's `Offer` struct
-type Offer struct {
-	CommP    []uint8        `json:"commP"`
-	Size     uint64         `json:"size"`
-	Cid      string         `json:"cid"`
-	Location string         `json:"location"`
-	Amount   *big.Int       `json:"amount"`
-	Token    common.Address `json:"token"`
-}
+// Offer is OnRamp.sol's `Offer` struct
+type Offer contracts.OnRampContractOffer
 
 func (o *Offer) Piece() (filabi.PieceInfo, error) {
 	pps := filabi.PaddedPieceSize(o.Size)
@@ -120,6 +192,7 @@ func (o *Offer) Piece() (filabi.PieceInfo, error) {
 type AggregateTransfer struct {
 	locations []string
 	agg       *datasegment.Aggregate
+	dealSize  filabi.PaddedPieceSize // padded size of the aggregate piece
 }
 
 type (
@@ -145,14 +218,13 @@ func NewAggregator(ctx context.Context, cfg *config.Config, srcCfg *config.Sourc
 		return nil, fmt.Errorf("failed to connect to Ethereum client for source chain at %s: %w", srcCfg.Api, err)
 	}
 
-	parsedABI, err := utils.LoadAbi(cfg.OnRampABIPath)
-	if err != nil {
-		return nil, err
-	}
 	proverContractAddress := common.HexToAddress(cfg.Destination.ProverAddr)
 	onRampContractAddress := common.HexToAddress(srcCfg.OnRampAddress)
 	payoutAddress := common.HexToAddress(cfg.PayoutAddr)
-	onramp := bind.NewBoundContract(onRampContractAddress, *parsedABI, client, client, client)
+	onramp, err := contracts.NewOnRamp(onRampContractAddress, client)
+	if err != nil {
+		return nil, err
+	}
 
 	//aggregator need to call smart contract on source Chain to send podsi proof
 	auth, err := utils.LoadPrivateKey(cfg, srcCfg.ChainID)
@@ -193,12 +265,36 @@ func NewAggregator(ctx context.Context, cfg *config.Config, srcCfg *config.Sourc
 	if len(maddrs) == 0 {
 		return nil, fmt.Errorf("storage provider %s has no multiaddrs set on-chain", providerAddr)
 	}
+	timelinePath, err := TimelinePath(cfg, srcCfg.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	tl, err := openTimeline(timelinePath, srcCfg.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	statePath, err := StatePath(cfg, srcCfg.ChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	var adminAddr string
+	if cfg.AdminPort != 0 {
+		adminIP := cfg.AdminIP
+		if adminIP == "" {
+			adminIP = DefaultAdminIP
+		}
+		adminAddr = fmt.Sprintf("%s:%d", adminIP, cfg.AdminPort)
+	}
+
+	logger := slog.Default().With(logging.Chain, srcCfg.ChainID)
+
 	psPeerInfo := &peer.AddrInfo{
 		ID:    *minfo.PeerId,
 		Addrs: maddrs,
 	}
 
-	return &aggregator{
+	a := &aggregator{
 		client:           client,
 		onramp:           onramp,
 		onrampAddr:       onRampContractAddress,
@@ -209,7 +305,6 @@ func NewAggregator(ctx context.Context, cfg *config.Config, srcCfg *config.Sourc
 		transfers:        make(map[int]AggregateTransfer),
 		transferLk:       sync.RWMutex{},
 		transferAddr:     fmt.Sprintf("%s:%d", cfg.TransferIP, cfg.TransferPort),
-		abi:              parsedABI,
 		targetDealSize:   uint64(cfg.TargetAggSize),
 		minDealSize:      uint64(cfg.MinDealSize),
 		dealDelayEpochs:  uint64(cfg.DealDelayEpochs),
@@ -220,11 +315,28 @@ func NewAggregator(ctx context.Context, cfg *config.Config, srcCfg *config.Sourc
 		lotusAPI:         lAPI,
 		LighthouseAuth:   cfg.LighthouseAuth,
 		lighthouseApiKey: cfg.LighthouseApiKey,
+		timeline:         tl,
+		statePath:        statePath,
+		grace:            cfg.GracePeriod(),
+		aggregates:       make(map[string]*AggregateInfo),
+		jobs:             make(map[string]*aggregateJob),
+		retries:          make(chan string, 16),
+		flush:            make(chan struct{}, 1),
+		wake:             make(chan struct{}, 1),
+		adminAddr:        adminAddr,
+		adminToken:       cfg.AdminToken,
+		chain:            strconv.Itoa(srcCfg.ChainID),
+		logger:           logger,
 		cleanup: func() {
 			closer()
-			log.Printf("done with lotus api closer\n")
+			logger.Debug("Closed lotus api")
 		},
-	}, nil
+	}
+	a.steps = a.aggregateSteps()
+	if err := a.restoreState(); err != nil {
+		return nil, err
+	}
+	return a, nil
 }
 
 // Run the two offerTaker persistant process
@@ -233,28 +345,24 @@ func NewAggregator(ctx context.Context, cfg *config.Config, srcCfg *config.Sourc
 //     to store and sending to filecoin boost
 func (a *aggregator) run(ctx context.Context) error {
 	defer a.cleanup()
+	if err := a.catchUpTimeline(ctx); err != nil {
+		a.logger.Warn("Failed to catch up the offer timeline", "err", err)
+	}
 	g, ctx := errgroup.WithContext(ctx)
 	// Start listening for events
 	// New DataReady events are passed through the channel to aggregation handling
 	g.Go(func() error {
-		query := ethereum.FilterQuery{
-			Addresses: []common.Address{a.onrampAddr},
-			Topics:    [][]common.Hash{{a.abi.Events["DataReady"].ID}},
-		}
-
-		err := a.SubscribeQuery(ctx, query)
-		for err == nil || strings.Contains(err.Error(), "read tcp") {
-			if err != nil {
-				log.Printf("ignoring mystery error: %s", err)
-			}
-			if ctx.Err() != nil {
-				err = ctx.Err()
-				break
-			}
-			err = a.SubscribeQuery(ctx, query)
-		}
-		log.Printf("context done exiting subscribe query\n")
-		return err
+		return a.resubscribe(ctx, a.SubscribeDataReady)
+	})
+	// Committed and proven aggregates are recorded in the offer timeline
+	g.Go(func() error {
+		return a.resubscribe(ctx, a.SubscribeAggregationCommitted)
+	})
+	g.Go(func() error {
+		return a.resubscribe(ctx, a.SubscribeProveDataStored)
+	})
+	g.Go(func() error {
+		return a.trackDeals(ctx)
 	})
 
 	// Start aggregatation event handling
@@ -262,172 +370,467 @@ func (a *aggregator) run(ctx context.Context) error {
 		return a.runAggregate(ctx)
 	})
 
+	// Start the admin API
+	if a.adminAddr != "" {
+		g.Go(func() error {
+			return a.serveAdmin(ctx)
+		})
+	}
+
 	// Start handling data transfer requests
 	g.Go(func() error {
 		http.HandleFunc("/", a.transferHandler)
-		log.Printf("Data transfer server starting at %s\n", a.transferAddr)
+		a.logger.Info("Data transfer server starting", "addr", a.transferAddr)
 		server := &http.Server{
 			Addr:    a.transferAddr,
 			Handler: nil, // http.DefaultServeMux
 		}
+		errc := make(chan error, 1)
 		go func() {
-			if err := server.ListenAndServe(); err != http.ErrServerClosed {
-				log.Fatalf("Transfer HTTP server ListenAndServe: %v", err)
-			}
+			errc <- server.ListenAndServe()
 		}()
-		<-ctx.Done()
-		log.Printf("context done about to shut down server\n")
-		// Context is cancelled, shut down the server
-		return server.Shutdown(context.Background())
+		select {
+		case err := <-errc:
+			return fmt.Errorf("transfer server: %w", err)
+		case <-ctx.Done():
+		}
+		a.logger.Info("Shutting down data transfer server")
+		// Context is cancelled, shut down the server once transfers in flight are done
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), a.grace)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
 	})
 
 	return g.Wait()
 }
 
+// resubscribe runs subscribe again whenever the event subscription drops
+func (a *aggregator) resubscribe(ctx context.Context, subscribe func(context.Context) error) error {
+	err := subscribe(ctx)
+	for err == nil || strings.Contains(err.Error(), "read tcp") {
+		if err != nil {
+			a.logger.Warn("Resubscribing after connection error", "err", err)
+		}
+		if ctx.Err() != nil {
+			err = ctx.Err()
+			break
+		}
+		err = subscribe(ctx)
+	}
+	a.logger.Debug("Context done, exiting subscription")
+	return err
+}
+
 func (a *aggregator) runAggregate(ctx context.Context) error {
-	// pieces being aggregated, flushed upon commitment
+	// offers being aggregated are kept in a.pending, flushed upon commitment
 	// Invariant: the pieces in the pending queue can always make a valid aggregate w.r.t a.targetDealSize
-	fmt.Println("Start running aggregation.")
-	var pending []DataReadyEvent
-	total := uint64(0)
+	a.logger.Info("Start running aggregation")
+	retry := time.NewTicker(retryInterval)
+	defer retry.Stop()
+	// An aggregate started before shutdown gets the grace period to finish its
+	// transactions, then the pending offers and unfinished aggregates are saved
+	work, cancel := utils.DrainContext(ctx, a.grace)
+	defer cancel()
+	defer a.saveState()
 
 	for {
+		// Receiving from a nil channel blocks, so while ingestion is paused new offers wait in a.ch
+		events := a.ch
+		if a.paused.Load() {
+			events = nil
+		}
+		// Shutdown comes first when other cases are ready too
+		if ctx.Err() != nil {
+			a.logger.Info("Shutting down aggregation")
+			return nil
+		}
 		select {
 		case <-ctx.Done():
-			log.Printf("ctx done shutting down aggregation")
+			a.logger.Info("Shutting down aggregation")
 			return nil
-		case latestEvent := <-a.ch:
+		case <-a.wake:
+		case <-retry.C:
+			a.retryFailed(work)
+		case commP := <-a.retries:
+			if err := a.retryAggregate(work, commP); err != nil {
+				a.logger.Warn("Retry failed", logging.AggCommP, commP, "err", err)
+			}
+		case <-a.flush:
+			pending, dealSize, err := a.takePending(true)
+			if err != nil {
+				return err
+			}
+			if len(pending) > 0 {
+				a.logger.Info("Flushing pending offers", "offers", len(pending))
+				if err := a.aggregate(work, pending, dealSize); err != nil {
+					return err
+				}
+			}
+		case latestEvent := <-events:
 			{
-				// Comment out to test
-				// Check if the offer is too big to fit in a valid aggregate on its own
-				// TODO: as referenced below there must be a better way when we introspect on the gory details of NewAggregate
-				latestPiece, err := latestEvent.Offer.Piece()
-				if err != nil {
-					log.Printf("skipping offer %d, size %d not valid padded piece size ", latestEvent.OfferID, latestEvent.Offer.Size)
+				if !a.accept(latestEvent) {
 					continue
 				}
-				log.Println("Extraced PieceC from Offer:", latestPiece)
-
-				_, err = datasegment.NewAggregate(filabi.PaddedPieceSize(a.targetDealSize), []filabi.PieceInfo{
-					latestPiece,
-				})
 
+				pending, dealSize, err := a.takePending(false)
 				if err != nil {
-					fmt.Errorf("skipping offer %d, size %d exceeds max PODSI packable size. %w", latestEvent.OfferID, latestEvent.Offer.Size, err)
+					return err
+				}
+				if pending == nil {
+					a.logger.Info("Offer added", logging.OfferID, latestEvent.OfferID)
 					continue
 				}
-				pending = append(pending, latestEvent)
-
-				// Turn offers into datasegment pieces
-				pieces := make([]filabi.PieceInfo, len(pending))
-				for i, event := range pending {
-					piece, err := event.Offer.Piece()
-					if err != nil {
-						return err
-					}
-					pieces[i] = piece
+				if err := a.aggregate(work, pending, dealSize); err != nil {
+					return err
 				}
+			}
+		}
+	}
+}
 
-				// aggregation process
-				aggregatePieces := pieces
-				log.Println("Aggregated Pieces are:", aggregatePieces)
-				_, size, err := datasegment.ComputeDealPlacement(aggregatePieces)
-				if err != nil {
-					panic(err)
-				}
-				overallSize := filabi.PaddedPieceSize(size)
-				log.Printf("Aggregated Piece Size is %d", overallSize)
-
-				next := 1 << (64 - bits.LeadingZeros64(uint64(overallSize+256)))
-				if next <= int(a.minDealSize) {
-					total += latestEvent.Offer.Size
-					log.Printf("Offer-%d added. %d offers pending aggregation with total size=%d\n", latestEvent.OfferID, len(pending), total)
-				} else {
-					dealSize := filabi.PaddedPieceSize(next)
-					a.targetDealSize = uint64(dealSize)
-					log.Printf("Target DealSize is %d.", a.targetDealSize)
-
-					agg, err := datasegment.NewAggregate(filabi.PaddedPieceSize(a.targetDealSize), aggregatePieces)
-					if err != nil {
-						return fmt.Errorf("failed to create aggregate from pending, should not be reachable: %w", err)
-					}
-
-					//Generates Podsi inclusion proof from aggregation
-					inclProofs := make([]merkletree.ProofData, len(pieces))
-					ids := make([]uint64, len(pieces))
-					for i, piece := range pieces {
-						podsi, err := agg.ProofForPieceInfo(piece)
-						if err != nil {
-							return err
-						}
-						ids[i] = pending[i].OfferID
-						inclProofs[i] = podsi.ProofSubtree // Only do data proofs on chain for now not index proofs
-					}
-
-					//Sending aggCommp and inclusion proof to onramp contracts
-					aggCommp, err := agg.PieceCID()
-					if err != nil {
-						return err
-					}
-					tx, err := a.onramp.Transact(a.auth, "commitAggregate", aggCommp.Bytes(), ids, inclProofs, a.payoutAddr)
-					if err != nil {
-						return err
-					}
-					receipt, err := bind.WaitMined(ctx, a.client, tx)
-					if err != nil {
-						return err
-					}
-					log.Printf("Tx %s committing aggregate commp %s included: %d", tx.Hash().Hex(), aggCommp.String(), receipt.Status)
-
-					// Schedule aggregate data for transfer
-					// After adding to the map this is now served in aggregator.transferHandler at `/?id={transferID}`
-					locations := make([]string, len(pending))
-					for i, event := range pending {
-						locations[i] = event.Offer.Location
-					}
-					var transferID int
-					a.transferLk.Lock()
-					transferID = a.transferID
-					a.transfers[transferID] = AggregateTransfer{
-						locations: locations,
-						agg:       agg,
-					}
-					a.transferID++
-					a.transferLk.Unlock()
-					log.Printf("Transfer ID %d scheduled for aggregation %s with %d urls.", transferID, aggCommp.String(), len(locations))
-
-					// Aggregate data into a file
-					homeDir, err := os.UserHomeDir()
-					if err != nil {
-						fmt.Println("Error:", err)
-						return nil
-					}
-					aggLocation := filepath.Join(homeDir, "/.xchain/", aggCommp.String())
-					err = a.saveAggregateToFile(transferID, aggLocation)
-					if err != nil {
-						log.Fatalf("failed to save aggregate to file: %s", err)
-					} else {
-						log.Println("Saved aggregated data into a file.")
-					}
-
-					// send file to lighthouse
-					lhResp, err := buffer.UploadToLighthouse(aggLocation, a.lighthouseApiKey)
-					if err != nil {
-						log.Fatalf("failed to upload to lighthouse: %s", err)
-					}
-					retrievalURL := fmt.Sprintf("https://gateway.lighthouse.storage/ipfs/%s", lhResp.Hash)
-					log.Printf("Uploaded CAR size is %s", lhResp.Size)
-
-					// Make storage deal on Filecoin network.
-					err = a.sendDeal(ctx, aggCommp, transferID, retrievalURL)
-					if err != nil {
-						log.Printf("[ERROR] failed to send deal: %s", err)
-					}
-
-					// Reset event log queue to empty
-					pending = pending[:0]
+// accept adds the offer of event to the pending queue unless it cannot be aggregated
+func (a *aggregator) accept(event DataReadyEvent) bool {
+	// Comment out to test
+	// Check if the offer is too big to fit in a valid aggregate on its own
+	// TODO: as referenced below there must be a better way when we introspect on the gory details of NewAggregate
+	piece, err := event.Offer.Piece()
+	if err != nil {
+		a.logger.Warn("Skipping offer, size is not a valid padded piece size", logging.OfferID, event.OfferID, "size", event.Offer.Size)
+		metrics.OffersRejected.WithLabelValues(a.chain, metrics.RejectInvalidSize).Inc()
+		return false
+	}
+	a.logger.Debug("Extracted piece from offer", logging.OfferID, event.OfferID, "piece", piece.PieceCID, "size", piece.Size)
+
+	_, err = datasegment.NewAggregate(filabi.PaddedPieceSize(a.targetDealSize), []filabi.PieceInfo{
+		piece,
+	})
+
+	if err != nil {
+		a.logger.Warn("Skipping offer, size exceeds max PODSI packable size", logging.OfferID, event.OfferID, "size", event.Offer.Size, "err", err)
+		metrics.OffersRejected.WithLabelValues(a.chain, metrics.RejectTooLarge).Inc()
+		return false
+	}
+	a.pendingLk.Lock()
+	a.pending = append(a.pending, event)
+	a.pendingChanged()
+	a.pendingLk.Unlock()
+	return true
+}
+
+// takePending removes the pending offers from the queue once their aggregate
+// is bigger than the minimum deal size, or whenever there are any if force is
+// set, and returns them with the size of their deal
+func (a *aggregator) takePending(force bool) ([]DataReadyEvent, filabi.PaddedPieceSize, error) {
+	a.pendingLk.Lock()
+	defer a.pendingLk.Unlock()
+	if len(a.pending) == 0 {
+		return nil, 0, nil
+	}
+
+	// Turn offers into datasegment pieces
+	pieces, err := pieceInfos(a.pending)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// aggregation process
+	a.logger.Debug("Placing pending pieces", "pieces", pieces)
+	_, size, err := datasegment.ComputeDealPlacement(pieces)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to place pending pieces: %w", err)
+	}
+	overallSize := filabi.PaddedPieceSize(size)
+	a.logger.Debug("Placed pending pieces", "size", overallSize)
+
+	next := 1 << (64 - bits.LeadingZeros64(uint64(overallSize+256)))
+	if next <= int(a.minDealSize) && !force {
+		total := uint64(0)
+		for _, event := range a.pending {
+			total += event.Offer.Size
+		}
+		a.logger.Info("Offers pending aggregation", "offers", len(a.pending), "size", total)
+		return nil, 0, nil
+	}
+	pending := a.pending
+	a.pending = nil
+	a.pendingChanged()
+	return pending, filabi.PaddedPieceSize(next), nil
+}
+
+// pendingChanged updates the pending offer metrics. The caller holds a.pendingLk.
+func (a *aggregator) pendingChanged() {
+	total := uint64(0)
+	for _, event := range a.pending {
+		total += event.Offer.Size
+	}
+	metrics.PendingOffers.WithLabelValues(a.chain).Set(float64(len(a.pending)))
+	metrics.PendingBytes.WithLabelValues(a.chain).Set(float64(total))
+}
+
+func pieceInfos(events []DataReadyEvent) ([]filabi.PieceInfo, error) {
+	pieces := make([]filabi.PieceInfo, len(events))
+	for i, event := range events {
+		piece, err := event.Offer.Piece()
+		if err != nil {
+			return nil, err
+		}
+		pieces[i] = piece
+	}
+	return pieces, nil
+}
+
+// aggregate makes an aggregate of the pending offers and takes it through its
+// steps. Only errors breaking the invariants of the pending queue are
+// returned, failed steps leave the aggregate to be retried.
+func (a *aggregator) aggregate(ctx context.Context, pending []DataReadyEvent, dealSize filabi.PaddedPieceSize) error {
+	a.logger.Info("Aggregating pending offers", "offers", len(pending), "dealSize", dealSize)
+
+	job, err := a.newJob(pending, dealSize)
+	if err != nil {
+		return err
+	}
+	a.trackAggregate(job)
+	a.advance(ctx, job, StepCommit)
+	return nil
+}
+
+// newJob makes the aggregate of offers in a deal of dealSize
+func (a *aggregator) newJob(offers []DataReadyEvent, dealSize filabi.PaddedPieceSize) (*aggregateJob, error) {
+	pieces, err := pieceInfos(offers)
+	if err != nil {
+		return nil, err
+	}
+	agg, err := datasegment.NewAggregate(dealSize, pieces)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create aggregate from pending, should not be reachable: %w", err)
+	}
+
+	// Generate the PoDSI proofs, the OnRamp checks both that each piece is in the
+	// aggregate tree and that it is listed in the data segment index
+	podsis, err := inclusionProofs(agg, pieces)
+	if err != nil {
+		return nil, fmt.Errorf("aggregate failed validation: %w", err)
+	}
+	job := &aggregateJob{
+		agg:         agg,
+		offers:      offers,
+		pieces:      pieces,
+		dealSize:    dealSize,
+		ids:         make([]uint64, len(pieces)),
+		locations:   make([]string, len(pieces)),
+		inclProofs:  make([]contracts.PODSIVerifierProofData, len(pieces)),
+		indexProofs: make([]contracts.PODSIVerifierProofData, len(pieces)),
+	}
+	for i, podsi := range podsis {
+		job.ids[i] = offers[i].OfferID
+		job.locations[i] = offers[i].Offer.Location
+		job.inclProofs[i] = proofData(podsi.ProofSubtree)
+		job.indexProofs[i] = proofData(podsi.ProofIndex)
+	}
+	if job.commP, err = agg.PieceCID(); err != nil {
+		return nil, err
+	}
+	job.logger = a.logger.With(logging.AggCommP, job.commP)
+	return job, nil
+}
+
+// advance runs the steps of an aggregate starting at step from. When a step
+// fails the aggregate is left failed at that step and its error returned.
+func (a *aggregator) advance(ctx context.Context, job *aggregateJob, from string) error {
+	started := false
+	for _, step := range a.steps {
+		if step.name == from {
+			started = true
+		}
+		if !started {
+			continue
+		}
+		a.updateAggregate(job.commP, func(info *AggregateInfo) {
+			info.State = step.state
+		})
+		if err := step.run(ctx, job); err != nil {
+			aerr := &AggregateError{Step: step.name, CommP: job.commP, Err: err}
+			a.updateAggregate(job.commP, func(info *AggregateInfo) {
+				info.State = AggregateFailed
+				if step.name == StepDeal {
+					info.State = AggregateDealFailed
 				}
+				info.FailedStep = step.name
+				info.Error = err.Error()
+				info.Attempts++
+			})
+			job.logger.Error("Aggregate step failed", "step", step.name, "err", err)
+			return aerr
+		}
+		a.updateAggregate(job.commP, func(info *AggregateInfo) {
+			if info.FailedStep == step.name {
+				info.FailedStep = ""
+				info.Error = ""
+				info.Attempts = 0
 			}
+		})
+	}
+	return nil
+}
+
+// aggregateSteps are the steps each aggregate goes through, in order
+func (a *aggregator) aggregateSteps() []aggregateStep {
+	return []aggregateStep{
+		{name: StepCommit, state: AggregateCommitting, run: a.commit},
+		{name: StepSave, state: AggregateSaving, run: a.save},
+		{name: StepUpload, state: AggregateUploading, run: a.upload},
+		{name: StepDeal, state: AggregateProposing, run: a.deal},
+	}
+}
+
+// commit sends the aggregate CommP and inclusion proofs to the OnRamp and
+// schedules the aggregate data for transfer
+func (a *aggregator) commit(ctx context.Context, job *aggregateJob) error {
+	// A retried commit may follow a transaction that was mined after waiting
+	// for it failed, committing the offers again would make a second aggregation
+	aggID, err := a.onramp.CommPToAggregateID(&bind.CallOpts{Context: ctx}, job.commP.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to look up aggregate: %w", err)
+	}
+	if aggID != 0 {
+		job.logger.Info("Aggregate already committed", "aggregationId", aggID, "offers", job.ids)
+		a.scheduleTransfer(job)
+		return nil
+	}
+
+	start := time.Now()
+	tx, err := a.onramp.CommitAggregate(a.auth, job.commP.Bytes(), job.ids, job.inclProofs, job.indexProofs, a.payoutAddr)
+	if err != nil {
+		return err
+	}
+	receipt, err := bind.WaitMined(ctx, a.client, tx)
+	if err != nil {
+		return fmt.Errorf("failed to wait for tx %s: %w", tx.Hash(), err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("%w: tx %s", ErrCommitReverted, tx.Hash())
+	}
+	metrics.CommitAggregateSeconds.WithLabelValues(a.chain).Observe(time.Since(start).Seconds())
+	metrics.CommitAggregateGas.WithLabelValues(a.chain).Observe(float64(receipt.GasUsed))
+	metrics.OffersAggregated.WithLabelValues(a.chain).Add(float64(len(job.ids)))
+	offered := uint64(0)
+	for _, piece := range job.pieces {
+		offered += uint64(piece.Size)
+	}
+	metrics.AggregateFillRatio.WithLabelValues(a.chain).Observe(float64(offered) / float64(job.dealSize))
+	metrics.AggregatePaddingBytes.WithLabelValues(a.chain).Add(float64(uint64(job.dealSize) - offered))
+	job.logger.Info("Aggregate committed", "tx", tx.Hash(), "offers", job.ids)
+
+	a.scheduleTransfer(job)
+	return nil
+}
+
+// scheduleTransfer serves the aggregate data to the storage provider
+func (a *aggregator) scheduleTransfer(job *aggregateJob) {
+	// Schedule aggregate data for transfer
+	// After adding to the map this is now served in aggregator.transferHandler at `/?id={transferID}`
+	a.transferLk.Lock()
+	job.transferID = a.transferID
+	a.transfers[job.transferID] = AggregateTransfer{
+		locations: job.locations,
+		agg:       job.agg,
+		dealSize:  job.dealSize,
+	}
+	a.transferID++
+	a.transferLk.Unlock()
+	job.logger = job.logger.With(logging.TransferID, job.transferID)
+	job.logger.Info("Transfer scheduled", "urls", len(job.locations))
+	a.updateAggregate(job.commP, func(info *AggregateInfo) {
+		info.TransferID = job.transferID
+	})
+}
+
+// save writes the aggregate data into a file
+func (a *aggregator) save(ctx context.Context, job *aggregateJob) error {
+	path, err := aggregateFile(job.commP)
+	if err != nil {
+		return err
+	}
+	job.path = path
+	if err := a.saveAggregateToFile(job.transferID, job.path); err != nil {
+		return err
+	}
+	job.logger.Info("Saved aggregate", "path", job.path)
+	return nil
+}
+
+// aggregateFile is where the data of aggregate commP is saved
+func aggregateFile(commP cid.Cid) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find home directory: %w", err)
+	}
+	return filepath.Join(homeDir, "/.xchain/", commP.String()), nil
+}
+
+// upload sends the aggregate file to lighthouse, the storage provider fetches it from there
+func (a *aggregator) upload(ctx context.Context, job *aggregateJob) error {
+	lhResp, err := buffer.UploadToLighthouse(job.path, a.lighthouseApiKey)
+	if err != nil {
+		return fmt.Errorf("failed to upload to lighthouse: %w", err)
+	}
+	job.url = fmt.Sprintf("https://gateway.lighthouse.storage/ipfs/%s", lhResp.Hash)
+	job.logger.Info("Uploaded aggregate to lighthouse", "url", job.url, "size", lhResp.Size)
+	a.updateAggregate(job.commP, func(info *AggregateInfo) {
+		info.URL = job.url
+	})
+	return nil
+}
+
+// deal makes a storage deal for the aggregate on the Filecoin network
+func (a *aggregator) deal(ctx context.Context, job *aggregateJob) error {
+	dealUuid := uuid.New()
+	err := a.sendDeal(ctx, job.commP, job.dealSize, dealUuid, job.transferID, job.url)
+	outcome := metrics.OutcomeAccepted
+	if errors.Is(err, ErrDealRejected) {
+		outcome = metrics.OutcomeRejected
+	} else if err != nil {
+		outcome = metrics.OutcomeFailed
+	}
+	metrics.DealProposals.WithLabelValues(a.spActorAddr.String(), outcome).Inc()
+	if err != nil {
+		return err
+	}
+	a.updateAggregate(job.commP, func(info *AggregateInfo) {
+		info.DealUUID = dealUuid.String()
+		info.State = AggregateDealProposed
+	})
+	if err := a.timeline.dealProposed(job.ids, job.commP, dealUuid.String(), time.Now()); err != nil {
+		job.logger.Warn("Failed to record deal in timeline", logging.DealUUID, dealUuid, "err", err)
+	}
+	return nil
+}
+
+// retryAggregate runs a failed aggregate again from the step it failed at
+func (a *aggregator) retryAggregate(ctx context.Context, commP string) error {
+	a.aggregatesLk.Lock()
+	info, ok := a.aggregates[commP]
+	job := a.jobs[commP]
+	if !ok || job == nil {
+		a.aggregatesLk.Unlock()
+		return fmt.Errorf("unknown aggregate %s", commP)
+	}
+	if !info.failed() {
+		a.aggregatesLk.Unlock()
+		return fmt.Errorf("aggregate %s is %s, only failed aggregates are retried", commP, info.State)
+	}
+	step := info.FailedStep
+	a.aggregatesLk.Unlock()
+
+	job.logger.Info("Retrying aggregate", "step", step)
+	return a.advance(ctx, job, step)
+}
+
+// retryFailed retries the failed aggregates that have attempts left
+func (a *aggregator) retryFailed(ctx context.Context) {
+	for _, info := range a.listAggregates() {
+		if info.failed() && info.Attempts < maxAggregateAttempts {
+			a.retryAggregate(ctx, info.CommP)
 		}
 	}
 }
@@ -435,7 +838,7 @@ func (a *aggregator) runAggregate(ctx context.Context) error {
 // Send deal data to the configured SP deal making address (boost node)
 // The deal is made with the configured prover client contract
 // Heavily inspired by boost client
-func (a *aggregator) sendDeal(ctx context.Context, aggCommp cid.Cid, transferID int, url string) error {
+func (a *aggregator) sendDeal(ctx context.Context, aggCommp cid.Cid, dealSize filabi.PaddedPieceSize, dealUuid uuid.UUID, transferID int, url string) error {
 	if err := a.host.Connect(ctx, *a.spDealAddr); err != nil {
 		return fmt.Errorf("failed to connect to peer %s: %w", a.spDealAddr.ID, err)
 	}
@@ -448,73 +851,124 @@ func (a *aggregator) sendDeal(ctx context.Context, aggCommp cid.Cid, transferID
 	}
 
 	// Construct deal
-	dealUuid := uuid.New()
-	log.Printf("making deal for commp=%s, UUID=%s\n", aggCommp.String(), dealUuid)
+	logger := a.logger.With(logging.AggCommP, aggCommp, logging.DealUUID, dealUuid)
+	logger.Info("Making deal")
 
-	if url == "" {
-		url = fmt.Sprintf("http://%s/?id=%d", a.transferAddr, transferID)
+	terms, err := a.dealTerms(ctx, dealSize)
+	if err != nil {
+		return err
 	}
-
-	transferParams := boosttypes2.HttpRequest{
-		URL: url,
+	dealParams, err := a.dealParams(aggCommp, dealSize, dealUuid, transferID, url, terms)
+	if err != nil {
+		return err
 	}
-	log.Printf("transfer URL: %s", url)
-	paramsBytes, err := json.Marshal(transferParams)
+	proposal := dealParams.ClientDealProposal
+	logger.Debug("Deal proposal",
+		"transferURL", url,
+		"pieceSize", proposal.Proposal.PieceSize,
+		"verifiedDeal", proposal.Proposal.VerifiedDeal,
+		"client", proposal.Proposal.Client,
+		"provider", proposal.Proposal.Provider,
+		"label", terms.labelText,
+		"startEpoch", proposal.Proposal.StartEpoch,
+		"endEpoch", proposal.Proposal.EndEpoch,
+		"storagePricePerEpoch", proposal.Proposal.StoragePricePerEpoch,
+		"providerCollateral", proposal.Proposal.ProviderCollateral)
+
+	s, err := a.host.NewStream(ctx, a.spDealAddr.ID, DealProtocolv120)
 	if err != nil {
-		return fmt.Errorf("failed to marshal transfer params: %w", err)
+		return err
 	}
-	transfer := boosttypes.Transfer{
-		Type: "http",
-		//ClientID: fmt.Sprintf("%d", transferID),
-		Params: paramsBytes,
-		Size:   a.targetDealSize - a.targetDealSize/128, // aggregate for transfer is not fr32 encoded
+	defer s.Close()
+
+	var resp boosttypes.DealResponse
+	if err := doRpc(ctx, s, dealParams, &resp); err != nil {
+		return fmt.Errorf("send proposal rpc: %w", err)
 	}
+	if !resp.Accepted {
+		return fmt.Errorf("%w: %s", ErrDealRejected, resp.Message)
+	}
+	logger.Info("Deal sent", "provider", a.spActorAddr)
+	return nil
+}
+
+// dealTerms are the parts of a deal proposal read from the chains
+type dealTerms struct {
+	collateral fbig.Int
+	start      filabi.ChainEpoch
+	end        filabi.ChainEpoch
+	label      market.DealLabel
+	labelText  string // source chain ID encoded as uint256, the label of the deal
+}
 
-	bounds, err := a.lotusAPI.StateDealProviderCollateralBounds(ctx, filabi.PaddedPieceSize(a.targetDealSize), false, lotustypes.EmptyTSK)
+func (a *aggregator) dealTerms(ctx context.Context, dealSize filabi.PaddedPieceSize) (dealTerms, error) {
+	var terms dealTerms
+	bounds, err := a.lotusAPI.StateDealProviderCollateralBounds(ctx, dealSize, false, lotustypes.EmptyTSK)
 	if err != nil {
-		return fmt.Errorf("failed to get collateral bounds: %w", err)
+		return terms, fmt.Errorf("failed to get collateral bounds: %w", err)
 	}
-	providerCollateral := fbig.Div(fbig.Mul(bounds.Min, fbig.NewInt(6)), fbig.NewInt(5)) // add 20% as boost client does
+	terms.collateral = fbig.Div(fbig.Mul(bounds.Min, fbig.NewInt(6)), fbig.NewInt(5)) // add 20% as boost client does
 	tipset, err := a.lotusAPI.ChainHead(ctx)
 	if err != nil {
-		return fmt.Errorf("cannot get chain head: %w", err)
+		return terms, fmt.Errorf("cannot get chain head: %w", err)
 	}
 	filHeight := tipset.Height()
-	dealStart := filHeight + filabi.ChainEpoch(a.dealDelayEpochs)
-	dealEnd := dealStart + filabi.ChainEpoch(a.dealDuration)
-	filClient, err := address.NewDelegatedAddress(builtintypes.EthereumAddressManagerActorID, a.proverAddr[:])
-	log.Printf("filClient = %s", filClient.String())
-	if err != nil {
-		return fmt.Errorf("failed to translate onramp address (%s) into a "+
-			"Filecoin f4 address: %w", a.onrampAddr.Hex(), err)
-	}
+	terms.start = filHeight + filabi.ChainEpoch(a.dealDelayEpochs)
+	terms.end = terms.start + filabi.ChainEpoch(a.dealDuration)
 	chainID, err := a.client.ChainID(ctx)
-	log.Printf("chainID = %d", chainID)
 	if err != nil {
-		return fmt.Errorf("failed to get chain ID: %w", err)
+		return terms, fmt.Errorf("failed to get chain ID: %w", err)
 	}
 	// Encode the chainID as uint256
-	encodedChainID, err := utils.EncodeChainIDAsString(chainID)
+	terms.labelText, err = utils.EncodeChainIDAsString(chainID)
 	if err != nil {
-		return fmt.Errorf("failed to encode chainID: %w", err)
+		return terms, fmt.Errorf("failed to encode chainID: %w", err)
 	}
-	dealLabel, err := market.NewLabelFromString(encodedChainID)
+	terms.label, err = market.NewLabelFromString(terms.labelText)
 	if err != nil {
-		return fmt.Errorf("failed to create deal label: %w", err)
+		return terms, fmt.Errorf("failed to create deal label: %w", err)
+	}
+	return terms, nil
+}
+
+// dealParams is the proposal of a deal for the aggregate aggCommp of dealSize,
+// fetched by the storage provider from url or else from the transfer server
+func (a *aggregator) dealParams(aggCommp cid.Cid, dealSize filabi.PaddedPieceSize, dealUuid uuid.UUID, transferID int, url string, terms dealTerms) (*boosttypes.DealParams, error) {
+	if url == "" {
+		url = fmt.Sprintf("http://%s/?id=%d", a.transferAddr, transferID)
+	}
+
+	transferParams := boosttypes2.HttpRequest{
+		URL: url,
+	}
+	paramsBytes, err := json.Marshal(transferParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal transfer params: %w", err)
+	}
+	transfer := boosttypes.Transfer{
+		Type: "http",
+		//ClientID: fmt.Sprintf("%d", transferID),
+		Params: paramsBytes,
+		Size:   uint64(dealSize.Unpadded()), // aggregate for transfer is not fr32 encoded
+	}
+
+	filClient, err := address.NewDelegatedAddress(builtintypes.EthereumAddressManagerActorID, a.proverAddr[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate onramp address (%s) into a "+
+			"Filecoin f4 address: %w", a.onrampAddr.Hex(), err)
 	}
-	log.Println("Start creating ClientDealProposal.")
 	proposal := market.ClientDealProposal{
 		Proposal: market.DealProposal{
 			PieceCID:             aggCommp,
-			PieceSize:            filabi.PaddedPieceSize(a.targetDealSize),
+			PieceSize:            dealSize,
 			VerifiedDeal:         true,
 			Client:               filClient,
 			Provider:             a.spActorAddr,
-			Label:                dealLabel,
-			StartEpoch:           dealStart,
-			EndEpoch:             dealEnd,
+			Label:                terms.label,
+			StartEpoch:           terms.start,
+			EndEpoch:             terms.end,
 			StoragePricePerEpoch: fbig.NewInt(0),
-			ProviderCollateral:   providerCollateral,
+			ProviderCollateral:   terms.collateral,
 		},
 		// Signature is unchecked since client is smart contract
 		ClientSignature: crypto.Signature{
@@ -523,7 +977,7 @@ func (a *aggregator) sendDeal(ctx context.Context, aggCommp cid.Cid, transferID
 		},
 	}
 
-	dealParams := boosttypes.DealParams{
+	return &boosttypes.DealParams{
 		DealUUID:           dealUuid,
 		ClientDealProposal: proposal,
 		DealDataRoot:       aggCommp,
@@ -531,37 +985,7 @@ func (a *aggregator) sendDeal(ctx context.Context, aggCommp cid.Cid, transferID
 		Transfer:           transfer,
 		RemoveUnsealedCopy: false,
 		SkipIPNIAnnounce:   false,
-	}
-	fmt.Println(dealParams.ClientDealProposal)
-	log.Println("-------------------DealProposal Details----------------------")
-	log.Println("DealUUID:", dealParams.DealUUID)
-	log.Println("PieceCID:", proposal.Proposal.PieceCID.String())
-	log.Println("PieceSize:", proposal.Proposal.PieceSize)
-	log.Println("VerifiedDeal:", proposal.Proposal.VerifiedDeal)
-	log.Println("Client:", proposal.Proposal.Client)
-	log.Println("Provider:", proposal.Proposal.Provider)
-	log.Println("Label:", proposal.Proposal.Label)
-	log.Println("StartEpoch:", proposal.Proposal.StartEpoch)
-	log.Println("EndEpoch:", proposal.Proposal.EndEpoch)
-	log.Println("StoragePricePerEpoch:", proposal.Proposal.StoragePricePerEpoch)
-	log.Println("ProviderCollateral:", proposal.Proposal.ProviderCollateral)
-	log.Println("---------------------------------------------------------------")
-
-	s, err := a.host.NewStream(ctx, a.spDealAddr.ID, DealProtocolv120)
-	if err != nil {
-		return err
-	}
-	defer s.Close()
-
-	var resp boosttypes.DealResponse
-	if err := doRpc(ctx, s, &dealParams, &resp); err != nil {
-		return fmt.Errorf("send proposal rpc: %w", err)
-	}
-	if !resp.Accepted {
-		return fmt.Errorf("deal proposal rejected: %s", resp.Message)
-	}
-	log.Printf("Deal UUID=%s is sent to miner %s.", dealUuid, a.spActorAddr)
-	return nil
+	}, nil
 }
 
 func doRpc(ctx context.Context, s inet.Stream, req interface{}, resp interface{}) error {
@@ -588,10 +1012,10 @@ func doRpc(ctx context.Context, s inet.Stream, req interface{}, resp interface{}
 	}
 }
 
-func (a *aggregator) SubscribeQuery(ctx context.Context, query ethereum.FilterQuery) error {
-	logs := make(chan types.Log)
-	log.Printf("Listening for data ready events on %s\n", a.onrampAddr.Hex())
-	sub, err := a.client.SubscribeFilterLogs(ctx, query, logs)
+func (a *aggregator) SubscribeDataReady(ctx context.Context) error {
+	events := make(chan *contracts.OnRampDataReady)
+	a.logger.Info("Listening for data ready events", "onramp", a.onrampAddr)
+	sub, err := a.onramp.WatchDataReady(&bind.WatchOpts{Context: ctx}, events)
 	if err != nil {
 		return err
 	}
@@ -608,43 +1032,47 @@ LOOP:
 			break LOOP
 		case err := <-sub.Err():
 			return err
-		case vLog := <-logs:
-			log.Println("Receive a DataReady() event.")
-			event, err := parseDataReadyEvent(vLog, a.abi)
-			if err != nil {
-				return err
-			}
+		case dataReady := <-events:
+			event := &DataReadyEvent{OfferID: dataReady.Id, Offer: Offer(dataReady.Offer)}
 
 			// Deduplication logic with mutex
 			mu.Lock()
 			if _, exists := processed[event.OfferID]; exists {
 				mu.Unlock() // Unlock and continue if duplicate
-				log.Printf("Duplicate event ignored: Offer NO. %d\n", event.OfferID)
+				a.logger.Debug("Duplicate event ignored", logging.OfferID, event.OfferID)
 				continue
 			}
 			processed[event.OfferID] = struct{}{}
 			mu.Unlock()
+			metrics.OffersReceived.WithLabelValues(a.chain).Inc()
+			if err := a.recordOffered(ctx, dataReady); err != nil {
+				a.logger.Warn("Failed to record offer in timeline", logging.OfferID, event.OfferID, "err", err)
+			}
 
-			log.Printf("Sending offer NO. %d for aggregation\n", event.OfferID)
-			log.Printf("  Offer:\n")
-			log.Printf("    CommP: %v\n", event.Offer.CommP)
-			log.Printf("    Size: %d\n", event.Offer.Size)
-			log.Printf("    Cid: %s\n", event.Offer.Cid)
-			log.Printf("    Location: %s\n", event.Offer.Location)
-			log.Printf("    Payment Token: %s\n", event.Offer.Token.Hex())      // Address needs .Hex() for printing
-			log.Printf("    Payment Amount: %s\n", event.Offer.Amount.String()) // big.Int needs .String() for printing
+			a.logger.Info("Received offer",
+				logging.OfferID, event.OfferID,
+				"commP", commPString(event.Offer.CommP),
+				"size", event.Offer.Size,
+				"cid", event.Offer.Cid,
+				"location", event.Offer.Location,
+				"token", event.Offer.Token,
+				"amount", event.Offer.Amount)
 
 			// This is where we should make packing decisions.
 			// In the current prototype we accept all offers regardless
 			// of payment type, amount or duration
-			a.ch <- *event
+			select {
+			case a.ch <- *event:
+			case <-ctx.Done():
+				break LOOP
+			}
 		}
 	}
 	return nil
 }
 
 func (a *aggregator) saveAggregateToFile(trensferId int, location string) error {
-	log.Printf("Saving aggregated data for transfer(%d) into a file:%s", trensferId, location)
+	a.logger.Info("Saving aggregate", logging.TransferID, trensferId, "path", location)
 	a.transferLk.RLock()
 	transfer, ok := a.transfers[trensferId]
 	a.transferLk.RUnlock()
@@ -655,10 +1083,10 @@ func (a *aggregator) saveAggregateToFile(trensferId int, location string) error
 	readers := []io.Reader{
 		// bytes.NewReader(prefixCARBytes)
 	}
-	log.Printf("Fetching %d pieces from buffer.", len(transfer.locations))
+	a.logger.Debug("Fetching pieces from buffer", logging.TransferID, trensferId, "pieces", len(transfer.locations))
 	// Fetch each sub piece from its buffer location and add to readers
 	for _, url := range transfer.locations {
-		lazyReader := &lazyHTTPReader{url: url}
+		lazyReader := newLazyHTTPReader(url)
 		readers = append(readers, lazyReader)
 		defer lazyReader.Close()
 	}
@@ -685,14 +1113,7 @@ func (a *aggregator) saveAggregateToFile(trensferId int, location string) error
 
 // Handle data transfer requests from boost
 func (a *aggregator) transferHandler(w http.ResponseWriter, r *http.Request) {
-	log.Println("Received data transfer from boost.")
-	w.Header().Set("Content-Type", "application/octet-stream")
-	w.Header().Set("Content-Length", strconv.Itoa(int(a.targetDealSize-a.targetDealSize/128)))
-	if r.Method == "HEAD" {
-		w.WriteHeader(http.StatusOK)
-		return
-	}
-
+	a.logger.Info("Received data transfer request", "method", r.Method, "url", r.URL)
 	idStr := r.URL.Query().Get("id")
 	if idStr == "" {
 		http.Error(w, "ID is required", http.StatusBadRequest)
@@ -711,11 +1132,17 @@ func (a *aggregator) transferHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "No data found", http.StatusNotFound)
 		return
 	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Length", strconv.FormatUint(uint64(transfer.dealSize.Unpadded()), 10))
+	if r.Method == "HEAD" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
 
 	readers := []io.Reader{}
 	// Fetch each sub piece from its buffer location and write to response
 	for _, url := range transfer.locations {
-		lazyReader := &lazyHTTPReader{url: url}
+		lazyReader := newLazyHTTPReader(url)
 		readers = append(readers, lazyReader)
 		defer lazyReader.Close()
 	}
@@ -724,77 +1151,114 @@ func (a *aggregator) transferHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, fmt.Sprintf("failed to create aggregate reader: %s", err), http.StatusInternalServerError)
 		return
 	}
-	_, err = io.Copy(w, aggReader)
+	n, err := io.Copy(w, aggReader)
+	metrics.TransferBytes.WithLabelValues(a.chain).Add(float64(n))
 	if err != nil {
-		log.Printf("failed to write aggregate stream: %s", err)
+		a.logger.Warn("Failed to write aggregate stream", logging.TransferID, id, "bytes", n, "err", err)
 	}
 }
 
-// LazyHTTPReader is an io.Reader that fetches data from an HTTP URL on the first Read call
+// LazyHTTPReader is an io.Reader that fetches data from an HTTP URL on the first Read call.
+// An offer location can list several mirrors, if a request fails the reader
+// fails over to the next one, resuming from the current offset with a Range request.
 type lazyHTTPReader struct {
-	url     string
-	reader  io.ReadCloser
-	started bool
+	urls   []string
+	next   int           // index of the next url to try
+	url    string        // url currently being read
+	reader io.ReadCloser // nil until started and after a failed read
+	offset int64         // bytes returned so far
+}
+
+func newLazyHTTPReader(location string) *lazyHTTPReader {
+	return &lazyHTTPReader{urls: buffer.ParseLocations(location)}
 }
 
 func (l *lazyHTTPReader) Read(p []byte) (int, error) {
-	if !l.started {
-		// Start the HTTP request on the first Read call
-		log.Printf("reading %s\n", l.url)
-		resp, err := http.Get(l.url)
-		if err != nil {
-			return 0, err
+	for {
+		if l.reader == nil {
+			if err := l.open(); err != nil {
+				return 0, err
+			}
 		}
-		if resp.StatusCode != http.StatusOK {
-			resp.Body.Close()
-			return 0, fmt.Errorf("failed to fetch data: %s", resp.Status)
+		n, err := l.reader.Read(p)
+		l.offset += int64(n)
+		if err == nil || err == io.EOF {
+			return n, err
+		}
+		slog.Warn("Reading piece failed", "url", l.url, "offset", l.offset, "err", err)
+		l.reader.Close()
+		l.reader = nil
+		if n > 0 {
+			return n, nil
 		}
-		l.reader = resp.Body
-		l.started = true
 	}
-	return l.reader.Read(p)
 }
 
-func (l *lazyHTTPReader) Close() error {
-	if l.reader != nil {
-		return l.reader.Close()
+// open starts a request against the next mirror that responds
+func (l *lazyHTTPReader) open() error {
+	if len(l.urls) == 0 {
+		return fmt.Errorf("offer has no data location")
 	}
-	return nil
+	var lastErr error
+	for ; l.next < len(l.urls); l.next++ {
+		url := l.urls[l.next]
+		slog.Debug("Reading piece", "url", url, "offset", l.offset)
+		body, err := l.get(url)
+		if err != nil {
+			slog.Warn("Failed to fetch piece", "url", url, "err", err)
+			lastErr = err
+			continue
+		}
+		l.url = url
+		l.reader = body
+		l.next++
+		return nil
+	}
+	return fmt.Errorf("failed to fetch data from all %d locations: %w", len(l.urls), lastErr)
 }
 
-// Function to parse the DataReady event from log data
-func parseDataReadyEvent(log types.Log, abi *abi.ABI) (*DataReadyEvent, error) {
-	eventData, err := abi.Unpack("DataReady", log.Data)
+func (l *lazyHTTPReader) get(url string) (io.ReadCloser, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unpack 'DataReady' event: %w", err)
-	}
-
-	// Assuming eventData is correctly ordered as per the event definition in the Solidity contract
-	if len(eventData) != 2 {
-		return nil, fmt.Errorf("unexpected number of fields for 'DataReady' event: got %d, want 2", len(eventData))
+		return nil, err
 	}
-
-	offerID, ok := eventData[1].(uint64)
-	if !ok {
-		return nil, fmt.Errorf("invalid type for offerID, expected uint64, got %T", eventData[1])
+	if l.offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", l.offset))
 	}
-
-	offerDataRaw := eventData[0]
-	// JSON round trip to deserialize to offer
-	bs, err := json.Marshal(offerDataRaw)
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal raw offer data to json: %w", err)
+		return nil, err
 	}
-	var offer Offer
-	err = json.Unmarshal(bs, &offer)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal raw offer data to nice offer struct: %w", err)
+	switch {
+	case resp.StatusCode == http.StatusPartialContent && l.offset > 0:
+		return resp.Body, nil
+	case resp.StatusCode == http.StatusOK:
+		// Mirror ignored the range, skip what was already read
+		if _, err := io.CopyN(io.Discard, resp.Body, l.offset); err != nil {
+			resp.Body.Close()
+			return nil, fmt.Errorf("failed to skip to offset %d: %w", l.offset, err)
+		}
+		return resp.Body, nil
+	default:
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to fetch data: %s", resp.Status)
 	}
+}
 
-	return &DataReadyEvent{
-		OfferID: offerID,
-		Offer:   offer,
-	}, nil
+func (l *lazyHTTPReader) Close() error {
+	if l.reader != nil {
+		return l.reader.Close()
+	}
+	return nil
+}
+
+// proofData converts a merkle proof into OnRamp.sol's `ProofData` struct
+func proofData(p merkletree.ProofData) contracts.PODSIVerifierProofData {
+	path := make([][32]byte, len(p.Path))
+	for i, node := range p.Path {
+		path[i] = node
+	}
+	return contracts.PODSIVerifierProofData{Index: p.Index, Path: path}
 }
 
 func NewLotusDaemonAPIClientV0(ctx context.Context, url string, timeoutSecs int, bearerToken string) (LotusDaemonAPIClientV0, jsonrpc.ClientCloser, error) {
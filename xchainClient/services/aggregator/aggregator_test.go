@@ -0,0 +1,109 @@
+package aggregator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	filabi "github.com/filecoin-project/go-state-types/abi"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// dealSizeOf checks the transfer and proposal of job are made for its own deal
+// size, whatever the size of other aggregates
+func dealSizeOf(t *testing.T, a *aggregator, job *aggregateJob) filabi.PaddedPieceSize {
+	rec := httptest.NewRecorder()
+	a.transferHandler(rec, httptest.NewRequest(http.MethodHead, fmt.Sprintf("/?id=%d", job.transferID), nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, fmt.Sprint(uint64(job.dealSize.Unpadded())), rec.Header().Get("Content-Length"))
+
+	params, err := a.dealParams(job.commP, job.dealSize, uuid.New(), job.transferID, job.url, dealTerms{})
+	require.NoError(t, err)
+	require.Equal(t, job.commP, params.ClientDealProposal.Proposal.PieceCID)
+	require.Equal(t, uint64(job.dealSize.Unpadded()), params.Transfer.Size)
+	var transfer struct{ URL string }
+	require.NoError(t, json.Unmarshal(params.Transfer.Params, &transfer))
+	if job.url == "" {
+		require.Equal(t, fmt.Sprintf("http://%s/?id=%d", a.transferAddr, job.transferID), transfer.URL)
+	} else {
+		require.Equal(t, job.url, transfer.URL)
+	}
+	return params.ClientDealProposal.Proposal.PieceSize
+}
+
+func TestDealSize(t *testing.T) {
+	a, _ := testAdmin(t, "")
+	small, err := a.newJob(a.pending[:2], 8192)
+	require.NoError(t, err)
+	a.trackAggregate(small)
+	a.scheduleTransfer(small)
+
+	// A bigger aggregate made before the small one is retried
+	large, err := a.newJob(a.pending, 1<<20)
+	require.NoError(t, err)
+	a.trackAggregate(large)
+	a.scheduleTransfer(large)
+
+	require.Equal(t, filabi.PaddedPieceSize(8192), dealSizeOf(t, a, small))
+	require.Equal(t, filabi.PaddedPieceSize(1<<20), dealSizeOf(t, a, large))
+
+	rec := httptest.NewRecorder()
+	a.transferHandler(rec, httptest.NewRequest(http.MethodHead, "/?id=7", nil))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestLazyHTTPReaderFailover(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 10000)
+	serve := func(h http.HandlerFunc) string {
+		ts := httptest.NewServer(h)
+		t.Cleanup(ts.Close)
+		return ts.URL
+	}
+	missing := serve(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "No data found", http.StatusNotFound)
+	})
+	// Drops the connection half way through the data
+	dropping := serve(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.Write(data[:len(data)/2])
+		panic(http.ErrAbortHandler)
+	})
+	ranged := serve(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(data))
+	})
+	// Ignores Range requests and always sends everything
+	full := serve(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(data)
+	})
+
+	for name, urls := range map[string][]string{
+		"single":             {ranged},
+		"missing mirror":     {missing, ranged},
+		"resume with range":  {dropping, ranged},
+		"resume from start":  {dropping, full},
+		"drop after failure": {missing, dropping, ranged},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := newLazyHTTPReader(strings.Join(urls, " "))
+			defer r.Close()
+			got, err := io.ReadAll(r)
+			require.NoError(t, err)
+			require.Equal(t, data, got)
+		})
+	}
+
+	// Every mirror failing is an error
+	r := newLazyHTTPReader(missing + " " + dropping)
+	_, err := io.ReadAll(r)
+	require.ErrorContains(t, err, "failed to fetch data from all 2 locations")
+	_, err = io.ReadAll(newLazyHTTPReader(""))
+	require.ErrorContains(t, err, "no data location")
+}
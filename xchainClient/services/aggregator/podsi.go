@@ -0,0 +1,75 @@
+package aggregator
+
+import (
+	"fmt"
+
+	"github.com/filecoin-project/go-data-segment/datasegment"
+	filabi "github.com/filecoin-project/go-state-types/abi"
+)
+
+// inclusionProofs collects the PoDSI proofs of pieces in agg and checks them
+// with checkAggregate before anything is sent on chain
+func inclusionProofs(agg *datasegment.Aggregate, pieces []filabi.PieceInfo) ([]*datasegment.InclusionProof, error) {
+	proofs := make([]*datasegment.InclusionProof, len(pieces))
+	for i, piece := range pieces {
+		podsi, err := agg.ProofForPieceInfo(piece)
+		if err != nil {
+			return nil, err
+		}
+		proofs[i] = podsi
+	}
+	if err := checkAggregate(agg, pieces, proofs); err != nil {
+		return nil, err
+	}
+	return proofs, nil
+}
+
+// checkAggregate parses the data segment index of agg back from its serialized
+// form and checks that it lists exactly the pieces, each at the offset its
+// inclusion proof places it, and that the subtree and index proofs of every
+// piece lead to the aggregate commitment as the OnRamp will verify them.
+func checkAggregate(agg *datasegment.Aggregate, pieces []filabi.PieceInfo, proofs []*datasegment.InclusionProof) error {
+	aggCommp, err := agg.PieceCID()
+	if err != nil {
+		return err
+	}
+	r, err := agg.IndexReader()
+	if err != nil {
+		return err
+	}
+	index, err := datasegment.ParseDataSegmentIndex(r)
+	if err != nil {
+		return fmt.Errorf("failed to parse data segment index: %w", err)
+	}
+	entries, err := index.ValidEntries()
+	if err != nil {
+		return fmt.Errorf("invalid data segment index: %w", err)
+	}
+	if len(entries) != len(pieces) {
+		return fmt.Errorf("data segment index has %d valid entries for %d pieces", len(entries), len(pieces))
+	}
+
+	for i, piece := range pieces {
+		aux, err := proofs[i].ComputeExpectedAuxData(datasegment.VerifierDataForPieceInfo(piece))
+		if err != nil {
+			return fmt.Errorf("invalid inclusion proof for piece %s: %w", piece.PieceCID, err)
+		}
+		if !aux.CommPa.Equals(aggCommp) || aux.SizePa != agg.DealSize {
+			return fmt.Errorf("inclusion proof for piece %s is for aggregate %s of %d bytes, not %s of %d bytes",
+				piece.PieceCID, aux.CommPa, aux.SizePa, aggCommp, agg.DealSize)
+		}
+
+		offset := proofs[i].ProofSubtree.Index * uint64(piece.Size)
+		listed := false
+		for _, e := range entries {
+			if e.PieceCID().Equals(piece.PieceCID) && e.Size == uint64(piece.Size) && e.Offset == offset {
+				listed = true
+				break
+			}
+		}
+		if !listed {
+			return fmt.Errorf("piece %s is not listed in the data segment index at offset %d", piece.PieceCID, offset)
+		}
+	}
+	return nil
+}
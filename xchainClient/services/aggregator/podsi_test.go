@@ -0,0 +1,117 @@
+package aggregator
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/filecoin-project/go-data-segment/datasegment"
+	"github.com/filecoin-project/go-data-segment/merkletree"
+	commcid "github.com/filecoin-project/go-fil-commcid"
+	filabi "github.com/filecoin-project/go-state-types/abi"
+	"github.com/stretchr/testify/require"
+)
+
+func testPiece(t *testing.T, seed byte, size filabi.PaddedPieceSize) filabi.PieceInfo {
+	comm := make([]byte, 32)
+	for i := range comm {
+		comm[i] = seed + byte(i)
+	}
+	comm[31] &= 0x3f
+	c, err := commcid.PieceCommitmentV1ToCID(comm)
+	require.NoError(t, err)
+	return filabi.PieceInfo{Size: size, PieceCID: c}
+}
+
+func TestInclusionProofs(t *testing.T) {
+	pieces := []filabi.PieceInfo{testPiece(t, 1, 2048), testPiece(t, 2, 8192), testPiece(t, 3, 2048)}
+	agg, err := datasegment.NewAggregate(1<<20, pieces)
+	require.NoError(t, err)
+
+	proofs, err := inclusionProofs(agg, pieces)
+	require.NoError(t, err)
+	require.Len(t, proofs, len(pieces))
+
+	// Proofs have to belong to the piece they are sent with
+	proofs[0], proofs[1] = proofs[1], proofs[0]
+	require.Error(t, checkAggregate(agg, pieces, proofs))
+	proofs[0], proofs[1] = proofs[1], proofs[0]
+
+	// Every entry of the index has to be one of the committed pieces
+	require.ErrorContains(t, checkAggregate(agg, pieces[:2], proofs[:2]), "valid entries")
+
+	// A proof for another aggregate is caught
+	other, err := datasegment.NewAggregate(1<<21, pieces)
+	require.NoError(t, err)
+	otherProofs, err := inclusionProofs(other, pieces)
+	require.NoError(t, err)
+	require.ErrorContains(t, checkAggregate(agg, pieces, otherProofs), "is for aggregate")
+}
+
+var updateFixtures = flag.Bool("update-fixtures", false, "rewrite the PoDSI fixture of the OnRamp contract tests")
+
+// onRampFixture is where the OnRamp contract tests read the proofs of an
+// aggregate made by go-data-segment
+const onRampFixture = "../../../onramp-contracts/test/fixtures/podsi.json"
+
+type fixtureProof struct {
+	Index uint64   `json:"index"`
+	Path  []string `json:"path"`
+}
+
+type fixturePiece struct {
+	CommP          string       `json:"commP"`
+	Size           uint64       `json:"size"`
+	InclusionProof fixtureProof `json:"inclusionProof"`
+	IndexProof     fixtureProof `json:"indexProof"`
+}
+
+type podsiFixture struct {
+	CommP  string         `json:"commP"`
+	Size   uint64         `json:"size"`
+	Pieces []fixturePiece `json:"pieces"`
+}
+
+func newFixtureProof(p merkletree.ProofData) fixtureProof {
+	fp := fixtureProof{Index: p.Index, Path: make([]string, len(p.Path))}
+	for i, node := range p.Path {
+		fp.Path[i] = "0x" + hex.EncodeToString(node[:])
+	}
+	return fp
+}
+
+// TestOnRampFixture keeps the fixture of the OnRamp contract tests in sync
+// with the proofs go-data-segment generates, run with -update-fixtures to
+// rewrite it
+func TestOnRampFixture(t *testing.T) {
+	pieces := []filabi.PieceInfo{testPiece(t, 1, 2048), testPiece(t, 2, 8192), testPiece(t, 3, 2048)}
+	agg, err := datasegment.NewAggregate(1<<20, pieces)
+	require.NoError(t, err)
+	proofs, err := inclusionProofs(agg, pieces)
+	require.NoError(t, err)
+	aggCommP, err := agg.PieceCID()
+	require.NoError(t, err)
+
+	fixture := podsiFixture{CommP: "0x" + hex.EncodeToString(aggCommP.Bytes()), Size: uint64(agg.DealSize)}
+	for i, piece := range pieces {
+		fixture.Pieces = append(fixture.Pieces, fixturePiece{
+			CommP:          "0x" + hex.EncodeToString(piece.PieceCID.Bytes()),
+			Size:           uint64(piece.Size),
+			InclusionProof: newFixtureProof(proofs[i].ProofSubtree),
+			IndexProof:     newFixtureProof(proofs[i].ProofIndex),
+		})
+	}
+	bs, err := json.MarshalIndent(fixture, "", "  ")
+	require.NoError(t, err)
+	bs = append(bs, '\n')
+
+	if *updateFixtures {
+		require.NoError(t, os.WriteFile(onRampFixture, bs, 0644))
+		return
+	}
+	saved, err := os.ReadFile(onRampFixture)
+	require.NoError(t, err)
+	require.Equal(t, string(bs), string(saved), "the fixture is out of date, run the test with -update-fixtures")
+}
@@ -0,0 +1,166 @@
+package aggregator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/FIL-Builders/xchainClient/config"
+	filabi "github.com/filecoin-project/go-state-types/abi"
+	"github.com/mitchellh/go-homedir"
+)
+
+// DefaultStateDir holds the state the aggregators save on shutdown, one file per source chain
+const DefaultStateDir = "~/.xchain/state"
+
+// State is what an aggregator saves on shutdown to carry on after a restart.
+// Offers only reach the aggregator once, as DataReady events, so offers not
+// aggregated yet and aggregates without a deal would be lost otherwise.
+type State struct {
+	ChainID    int                   `json:"chainId"`
+	Pending    []DataReadyEvent      `json:"pending"`
+	Aggregates []UnfinishedAggregate `json:"aggregates"`
+}
+
+// UnfinishedAggregate is an aggregate that failed, or was interrupted by shutdown, before its deal was proposed
+type UnfinishedAggregate struct {
+	Offers   []DataReadyEvent `json:"offers"`
+	DealSize uint64           `json:"dealSize"`
+	Step     string           `json:"step"` // step to resume at
+	URL      string           `json:"url,omitempty"`
+	Attempts int              `json:"attempts"`
+	Error    string           `json:"error,omitempty"`
+}
+
+// StatePath returns the state file of the aggregator of chain chainID
+func StatePath(cfg *config.Config, chainID int) (string, error) {
+	dir := cfg.StateDir
+	if dir == "" {
+		dir = DefaultStateDir
+	}
+	dir, err := homedir.Expand(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to expand state dir: %w", err)
+	}
+	return filepath.Join(dir, strconv.Itoa(chainID)+".json"), nil
+}
+
+// state collects the offers not aggregated yet, including those still queued
+// in a.ch, and the failed aggregates. It is called once runAggregate returned,
+// so no aggregate step is running.
+func (a *aggregator) state() *State {
+	chainID, _ := strconv.Atoi(a.chain)
+	st := &State{ChainID: chainID}
+	a.pendingLk.Lock()
+	st.Pending = append(st.Pending, a.pending...)
+	a.pendingLk.Unlock()
+	for len(a.ch) > 0 {
+		st.Pending = append(st.Pending, <-a.ch)
+	}
+
+	for _, info := range a.listAggregates() {
+		a.aggregatesLk.RLock()
+		job := a.jobs[info.CommP]
+		a.aggregatesLk.RUnlock()
+		if !info.failed() || job == nil {
+			continue
+		}
+		st.Aggregates = append(st.Aggregates, UnfinishedAggregate{
+			Offers:   job.offers,
+			DealSize: uint64(job.dealSize),
+			Step:     info.FailedStep,
+			URL:      job.url,
+			Attempts: info.Attempts,
+			Error:    info.Error,
+		})
+	}
+	return st
+}
+
+// saveState writes the state file, or removes it when there is nothing to carry over
+func (a *aggregator) saveState() {
+	st := a.state()
+	if len(st.Pending) == 0 && len(st.Aggregates) == 0 {
+		if err := os.Remove(a.statePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			a.logger.Warn("Failed to remove state", "path", a.statePath, "err", err)
+		}
+		return
+	}
+	if err := writeState(a.statePath, st); err != nil {
+		a.logger.Error("Failed to save state, pending offers and unfinished aggregates are lost", "err", err)
+		return
+	}
+	a.logger.Info("Saved state", "path", a.statePath, "pending", len(st.Pending), "aggregates", len(st.Aggregates))
+}
+
+func writeState(path string, st *State) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create state dir: %w", err)
+	}
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// restoreState takes back the state saved on the last shutdown. Pending offers
+// are queued again and unfinished aggregates are left failed at the step they
+// stopped at, to be retried. The state file is removed once restored.
+func (a *aggregator) restoreState() error {
+	data, err := os.ReadFile(a.statePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read state: %w", err)
+	}
+	var st State
+	if err := json.Unmarshal(data, &st); err != nil {
+		return fmt.Errorf("failed to decode state %s: %w", a.statePath, err)
+	}
+	if st.ChainID != 0 && strconv.Itoa(st.ChainID) != a.chain {
+		return fmt.Errorf("state %s is for chain %d, not %s", a.statePath, st.ChainID, a.chain)
+	}
+
+	for _, event := range st.Pending {
+		a.accept(event)
+	}
+	for _, unfinished := range st.Aggregates {
+		job, err := a.newJob(unfinished.Offers, filabi.PaddedPieceSize(unfinished.DealSize))
+		if err != nil {
+			return fmt.Errorf("failed to restore aggregate: %w", err)
+		}
+		job.url = unfinished.URL
+		a.trackAggregate(job)
+		if unfinished.Step != StepCommit {
+			// Committed aggregates are served to the storage provider again
+			a.scheduleTransfer(job)
+			if job.path, err = aggregateFile(job.commP); err != nil {
+				return err
+			}
+		}
+		a.updateAggregate(job.commP, func(info *AggregateInfo) {
+			info.State = AggregateFailed
+			if unfinished.Step == StepDeal {
+				info.State = AggregateDealFailed
+			}
+			info.FailedStep = unfinished.Step
+			info.URL = unfinished.URL
+			info.Attempts = unfinished.Attempts
+			info.Error = unfinished.Error
+		})
+	}
+	if err := os.Remove(a.statePath); err != nil {
+		return fmt.Errorf("failed to remove restored state: %w", err)
+	}
+	a.logger.Info("Restored state", "path", a.statePath, "pending", len(st.Pending), "aggregates", len(st.Aggregates))
+	return nil
+}
@@ -0,0 +1,94 @@
+package aggregator
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	filabi "github.com/filecoin-project/go-state-types/abi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestState(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "state", "1.json")
+	a, _ := testAdmin(t, "")
+	a.statePath = path
+	a.steps = a.aggregateSteps()
+
+	// An aggregate failed at its upload, and an offer still queued behind the pending ones
+	job, err := a.newJob(a.pending[:2], 1<<20)
+	require.NoError(t, err)
+	job.url = "https://gateway/ipfs/x"
+	a.trackAggregate(job)
+	a.updateAggregate(job.commP, func(info *AggregateInfo) {
+		info.State = AggregateFailed
+		info.FailedStep = StepUpload
+		info.Error = "lighthouse down"
+		info.Attempts = 2
+	})
+	a.ch <- a.pending[2]
+	a.saveState()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var st State
+	require.NoError(t, json.Unmarshal(data, &st))
+	require.Len(t, st.Pending, 4)
+	require.Len(t, st.Aggregates, 1)
+	require.Equal(t, StepUpload, st.Aggregates[0].Step)
+
+	// A restart takes the offers back and leaves the aggregate to be retried from its upload
+	b, _ := testAdmin(t, "")
+	b.pending = nil
+	b.statePath = path
+	b.steps = b.aggregateSteps()
+	require.NoError(t, b.restoreState())
+	require.Len(t, b.pending, 4)
+	aggregates := b.listAggregates()
+	require.Len(t, aggregates, 1)
+	require.Equal(t, AggregateInfo{
+		CommP:      job.commP.String(),
+		Size:       1 << 20,
+		OfferIDs:   []uint64{1, 2},
+		URL:        job.url,
+		State:      AggregateFailed,
+		FailedStep: StepUpload,
+		Error:      "lighthouse down",
+		Attempts:   2,
+		Updated:    aggregates[0].Updated,
+	}, aggregates[0])
+	require.Len(t, b.transfers, 1, "committed aggregates are served again")
+	require.NotEmpty(t, b.jobs[job.commP.String()].path)
+	require.NoFileExists(t, path)
+
+	// Nothing left to carry over leaves no state file
+	b.pending = nil
+	b.aggregates = make(map[string]*AggregateInfo)
+	b.saveState()
+	require.NoFileExists(t, path)
+	require.NoError(t, b.restoreState())
+}
+
+func TestRestoreDealSize(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	a, _ := testAdmin(t, "")
+	a.statePath = filepath.Join(t.TempDir(), "1.json")
+	a.steps = a.aggregateSteps()
+	require.NoError(t, writeState(a.statePath, &State{Aggregates: []UnfinishedAggregate{{
+		Offers:   a.pending[:2],
+		DealSize: 8192, // computed for the offers, below the 1 MiB target
+		Step:     StepDeal,
+		URL:      "https://gateway/ipfs/x",
+	}}}))
+	a.pending = nil
+	require.NoError(t, a.restoreState())
+
+	aggregates := a.listAggregates()
+	require.Len(t, aggregates, 1)
+	require.Equal(t, AggregateDealFailed, aggregates[0].State)
+	job := a.jobs[aggregates[0].CommP]
+	require.Equal(t, filabi.PaddedPieceSize(8192), dealSizeOf(t, a, job))
+	require.NotEqual(t, a.targetDealSize, uint64(job.dealSize))
+}
@@ -0,0 +1,591 @@
+package aggregator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/FIL-Builders/xchainClient/config"
+	"github.com/FIL-Builders/xchainClient/contracts"
+	"github.com/FIL-Builders/xchainClient/logging"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	filabi "github.com/filecoin-project/go-state-types/abi"
+	lotustypes "github.com/filecoin-project/lotus/chain/types"
+	"github.com/ipfs/go-cid"
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli/v2"
+)
+
+// DefaultTimelineDir holds the offer timelines of the aggregators, one file per source chain
+const DefaultTimelineDir = "~/.xchain/timeline"
+
+const (
+	// dealCheckInterval is how often deals of proven offers are checked for activation
+	dealCheckInterval = 5 * time.Minute
+	// timelineLogRange is the largest block range read at once when catching up
+	timelineLogRange = 5000
+)
+
+// Stages of an offer, in the order offers usually go through them
+const (
+	StageOffered      = "offered"
+	StageAggregated   = "aggregated"
+	StageDealProposed = "deal proposed"
+	StageDealActive   = "deal active"
+	StageProven       = "proven"
+)
+
+// OfferTimeline records when an offer reached each stage, joining the OnRamp
+// events with the deals made by the aggregator
+type OfferTimeline struct {
+	OfferID        uint64     `json:"offerId"`
+	CommP          string     `json:"commP,omitempty"`
+	Size           uint64     `json:"size,omitempty"`
+	Offered        *time.Time `json:"offered,omitempty"`
+	AggregationID  uint64     `json:"aggregationId,omitempty"`
+	AggregateCommP string     `json:"aggregateCommP,omitempty"`
+	Aggregated     *time.Time `json:"aggregated,omitempty"`
+	DealUUID       string     `json:"dealUuid,omitempty"`
+	DealProposed   *time.Time `json:"dealProposed,omitempty"`
+	DealID         uint64     `json:"dealId,omitempty"`
+	DealActive     *time.Time `json:"dealActive,omitempty"`
+	Proven         *time.Time `json:"proven,omitempty"`
+}
+
+// Stage is the last stage the offer reached. Deals attested when they are
+// published prove their offers before they are active, proven is final anyway.
+func (o *OfferTimeline) Stage() string {
+	switch {
+	case o.Proven != nil:
+		return StageProven
+	case o.DealActive != nil:
+		return StageDealActive
+	case o.DealProposed != nil:
+		return StageDealProposed
+	case o.Aggregated != nil:
+		return StageAggregated
+	default:
+		return StageOffered
+	}
+}
+
+// Timeline is the file holding the offer timelines of a source chain
+type Timeline struct {
+	ChainID   int                       `json:"chainId"`
+	LastBlock uint64                    `json:"lastBlock"` // last source chain block with recorded events
+	Offers    map[uint64]*OfferTimeline `json:"offers"`
+}
+
+// TimelinePath is the timeline file for chainID in the TimelineDir of cfg
+func TimelinePath(cfg *config.Config, chainID int) (string, error) {
+	dir := cfg.TimelineDir
+	if dir == "" {
+		dir = DefaultTimelineDir
+	}
+	dir, err := homedir.Expand(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to expand timeline dir: %w", err)
+	}
+	return filepath.Join(dir, strconv.Itoa(chainID)+".json"), nil
+}
+
+// LoadTimeline reads a timeline file, a missing file is an empty timeline
+func LoadTimeline(path string) (*Timeline, error) {
+	tl := &Timeline{Offers: make(map[uint64]*OfferTimeline)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return tl, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read timeline: %w", err)
+	}
+	if err := json.Unmarshal(data, tl); err != nil {
+		return nil, fmt.Errorf("failed to decode timeline %s: %w", path, err)
+	}
+	if tl.Offers == nil {
+		tl.Offers = make(map[uint64]*OfferTimeline)
+	}
+	return tl, nil
+}
+
+// List returns the timelines of ids, or of all offers when none are given,
+// ordered by offer ID
+func (tl *Timeline) List(ids ...uint64) []*OfferTimeline {
+	var offers []*OfferTimeline
+	if len(ids) == 0 {
+		for _, o := range tl.Offers {
+			offers = append(offers, o)
+		}
+	} else {
+		for _, id := range ids {
+			if o, ok := tl.Offers[id]; ok {
+				offers = append(offers, o)
+			}
+		}
+	}
+	sort.Slice(offers, func(i, j int) bool { return offers[i].OfferID < offers[j].OfferID })
+	return offers
+}
+
+// timeline keeps the Timeline of the aggregator's chain and saves it on every
+// change, or once for all the changes made while it is held
+type timeline struct {
+	path  string
+	mu    sync.Mutex
+	tl    *Timeline
+	held  bool // changes are saved by release
+	dirty bool // changes were made while held
+}
+
+func openTimeline(path string, chainID int) (*timeline, error) {
+	tl, err := LoadTimeline(path)
+	if err != nil {
+		return nil, err
+	}
+	if tl.ChainID != 0 && tl.ChainID != chainID {
+		return nil, fmt.Errorf("timeline %s is for chain %d, not %d", path, tl.ChainID, chainID)
+	}
+	tl.ChainID = chainID
+	return &timeline{path: path, tl: tl}, nil
+}
+
+// offer returns the timeline of offer id, creating it if needed. The caller holds t.mu.
+func (t *timeline) offer(id uint64) *OfferTimeline {
+	o, ok := t.tl.Offers[id]
+	if !ok {
+		o = &OfferTimeline{OfferID: id}
+		t.tl.Offers[id] = o
+	}
+	return o
+}
+
+// seen moves LastBlock up to block. The caller holds t.mu.
+func (t *timeline) seen(block uint64) {
+	if block > t.tl.LastBlock {
+		t.tl.LastBlock = block
+	}
+}
+
+func (t *timeline) offered(id uint64, commP []byte, size uint64, at time.Time, block uint64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	o := t.offer(id)
+	o.CommP = commPString(commP)
+	o.Size = size
+	o.Offered = &at
+	t.seen(block)
+	return t.changed()
+}
+
+func (t *timeline) aggregated(aggID uint64, aggCommP []byte, offerIDs []uint64, at time.Time, block uint64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, id := range offerIDs {
+		o := t.offer(id)
+		o.AggregationID = aggID
+		o.AggregateCommP = commPString(aggCommP)
+		o.Aggregated = &at
+	}
+	t.seen(block)
+	return t.changed()
+}
+
+// dealProposed is recorded by offer IDs as the deal can be made before the
+// AggregationCommitted event of the aggregate comes in
+func (t *timeline) dealProposed(offerIDs []uint64, aggCommP cid.Cid, dealUUID string, at time.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, id := range offerIDs {
+		o := t.offer(id)
+		o.AggregateCommP = aggCommP.String()
+		o.DealUUID = dealUUID
+		o.DealProposed = &at
+	}
+	return t.changed()
+}
+
+func (t *timeline) proven(aggCommP []byte, dealID uint64, at time.Time, block uint64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	key := commPString(aggCommP)
+	for _, o := range t.tl.Offers {
+		if o.AggregateCommP == key {
+			o.DealID = dealID
+			o.Proven = &at
+		}
+	}
+	t.seen(block)
+	return t.changed()
+}
+
+func (t *timeline) dealActive(dealID uint64, at time.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, o := range t.tl.Offers {
+		if o.DealID == dealID {
+			o.DealActive = &at
+		}
+	}
+	return t.changed()
+}
+
+// inactiveDeals lists the deals of proven offers not known to be active
+func (t *timeline) inactiveDeals() []uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	seen := make(map[uint64]bool)
+	var deals []uint64
+	for _, o := range t.tl.Offers {
+		if o.DealID != 0 && o.DealActive == nil && !seen[o.DealID] {
+			seen[o.DealID] = true
+			deals = append(deals, o.DealID)
+		}
+	}
+	return deals
+}
+
+func (t *timeline) lastBlock() uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.tl.LastBlock
+}
+
+// list returns copies of the timelines of ids, or of all offers
+func (t *timeline) list(ids ...uint64) []OfferTimeline {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	offers := t.tl.List(ids...)
+	out := make([]OfferTimeline, len(offers))
+	for i, o := range offers {
+		out[i] = *o
+	}
+	return out
+}
+
+// changed saves the timeline unless it is held. The caller holds t.mu.
+func (t *timeline) changed() error {
+	if t.held {
+		t.dirty = true
+		return nil
+	}
+	return t.save()
+}
+
+// hold stops saving the timeline on every change until release is called
+func (t *timeline) hold() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.held = true
+}
+
+// release saves the changes made since hold
+func (t *timeline) release() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.held = false
+	if !t.dirty {
+		return nil
+	}
+	t.dirty = false
+	return t.save()
+}
+
+// save writes the timeline through a temporary file so readers never see a
+// partial one. The caller holds t.mu.
+func (t *timeline) save() error {
+	if err := os.MkdirAll(filepath.Dir(t.path), 0755); err != nil {
+		return fmt.Errorf("failed to create timeline dir: %w", err)
+	}
+	data, err := json.MarshalIndent(t.tl, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := t.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write timeline: %w", err)
+	}
+	return os.Rename(tmp, t.path)
+}
+
+func commPString(commP []byte) string {
+	if _, c, err := cid.CidFromBytes(commP); err == nil {
+		return c.String()
+	}
+	return common.Bytes2Hex(commP)
+}
+
+// blockTime is the timestamp of source chain block n
+func (a *aggregator) blockTime(ctx context.Context, n uint64) (time.Time, error) {
+	h, err := a.client.HeaderByNumber(ctx, new(big.Int).SetUint64(n))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get block %d: %w", n, err)
+	}
+	return time.Unix(int64(h.Time), 0), nil
+}
+
+func (a *aggregator) recordOffered(ctx context.Context, ev *contracts.OnRampDataReady) error {
+	at, err := a.blockTime(ctx, ev.Raw.BlockNumber)
+	if err != nil {
+		return err
+	}
+	return a.timeline.offered(ev.Id, ev.Offer.CommP, ev.Offer.Size, at, ev.Raw.BlockNumber)
+}
+
+func (a *aggregator) recordAggregated(ctx context.Context, ev *contracts.OnRampAggregationCommitted) error {
+	at, err := a.blockTime(ctx, ev.Raw.BlockNumber)
+	if err != nil {
+		return err
+	}
+	a.logger.Info("Aggregation committed", "aggregationID", ev.AggId, logging.AggCommP, commPString(ev.CommP), "offers", ev.OfferIDs)
+	return a.timeline.aggregated(ev.AggId, ev.CommP, ev.OfferIDs, at, ev.Raw.BlockNumber)
+}
+
+func (a *aggregator) recordProven(ctx context.Context, ev *contracts.OnRampProveDataStored) error {
+	at, err := a.blockTime(ctx, ev.Raw.BlockNumber)
+	if err != nil {
+		return err
+	}
+	a.logger.Info("Aggregate proven", logging.AggCommP, commPString(ev.CommP), logging.DealID, ev.DealID)
+	return a.timeline.proven(ev.CommP, ev.DealID, at, ev.Raw.BlockNumber)
+}
+
+// catchUpTimeline records the OnRamp events emitted while the aggregator was
+// not running. The first run starts from the current block.
+func (a *aggregator) catchUpTimeline(ctx context.Context) error {
+	start := a.timeline.lastBlock() + 1
+	if start == 1 {
+		return nil
+	}
+	head, err := a.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get chain head: %w", err)
+	}
+	a.logger.Info("Catching up the offer timeline", "from", start, "to", head)
+	for start <= head {
+		end := min(start+timelineLogRange-1, head)
+		if err := a.catchUpRange(ctx, &bind.FilterOpts{Start: start, End: &end, Context: ctx}); err != nil {
+			return err
+		}
+		start = end + 1
+	}
+	return nil
+}
+
+// catchUpRange records the events of a range of blocks, saving the timeline
+// once at the end rather than rewriting it for every event
+func (a *aggregator) catchUpRange(ctx context.Context, opts *bind.FilterOpts) (err error) {
+	a.timeline.hold()
+	defer func() {
+		if rerr := a.timeline.release(); err == nil {
+			err = rerr
+		}
+	}()
+
+	offered, err := a.onramp.FilterDataReady(opts)
+	if err != nil {
+		return fmt.Errorf("failed to filter DataReady events: %w", err)
+	}
+	defer offered.Close()
+	for offered.Next() {
+		if err := a.recordOffered(ctx, offered.Event); err != nil {
+			return err
+		}
+	}
+	if err := offered.Error(); err != nil {
+		return err
+	}
+
+	aggregated, err := a.onramp.FilterAggregationCommitted(opts)
+	if err != nil {
+		return fmt.Errorf("failed to filter AggregationCommitted events: %w", err)
+	}
+	defer aggregated.Close()
+	for aggregated.Next() {
+		if err := a.recordAggregated(ctx, aggregated.Event); err != nil {
+			return err
+		}
+	}
+	if err := aggregated.Error(); err != nil {
+		return err
+	}
+
+	proven, err := a.onramp.FilterProveDataStored(opts)
+	if err != nil {
+		return fmt.Errorf("failed to filter ProveDataStored events: %w", err)
+	}
+	defer proven.Close()
+	for proven.Next() {
+		if err := a.recordProven(ctx, proven.Event); err != nil {
+			return err
+		}
+	}
+	return proven.Error()
+}
+
+func (a *aggregator) SubscribeAggregationCommitted(ctx context.Context) error {
+	events := make(chan *contracts.OnRampAggregationCommitted)
+	a.logger.Info("Listening for aggregation committed events", "onramp", a.onrampAddr)
+	sub, err := a.onramp.WatchAggregationCommitted(&bind.WatchOpts{Context: ctx}, events)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-sub.Err():
+			return err
+		case ev := <-events:
+			if err := a.recordAggregated(ctx, ev); err != nil {
+				a.logger.Warn("Failed to record aggregate in timeline", "aggregationID", ev.AggId, "err", err)
+			}
+		}
+	}
+}
+
+func (a *aggregator) SubscribeProveDataStored(ctx context.Context) error {
+	events := make(chan *contracts.OnRampProveDataStored)
+	a.logger.Info("Listening for prove data stored events", "onramp", a.onrampAddr)
+	sub, err := a.onramp.WatchProveDataStored(&bind.WatchOpts{Context: ctx}, events)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-sub.Err():
+			return err
+		case ev := <-events:
+			if err := a.recordProven(ctx, ev); err != nil {
+				a.logger.Warn("Failed to record proof in timeline", logging.AggCommP, commPString(ev.CommP), "err", err)
+			}
+		}
+	}
+}
+
+// trackDeals records when the deals of proven offers become active
+func (a *aggregator) trackDeals(ctx context.Context) error {
+	ticker := time.NewTicker(dealCheckInterval)
+	defer ticker.Stop()
+	for {
+		for _, dealID := range a.timeline.inactiveDeals() {
+			if err := a.checkDealActive(ctx, dealID); err != nil {
+				a.logger.Warn("Failed to check deal activation", logging.DealID, dealID, "err", err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *aggregator) checkDealActive(ctx context.Context, dealID uint64) error {
+	deal, err := a.lotusAPI.StateMarketStorageDeal(ctx, filabi.DealID(dealID), lotustypes.EmptyTSK)
+	if err != nil {
+		return err
+	}
+	if deal.State.SectorStartEpoch <= 0 {
+		return nil
+	}
+	ts, err := a.lotusAPI.ChainGetTipSetByHeight(ctx, deal.State.SectorStartEpoch, lotustypes.EmptyTSK)
+	if err != nil {
+		return err
+	}
+	a.logger.Info("Deal active", logging.DealID, dealID, "epoch", deal.State.SectorStartEpoch)
+	return a.timeline.dealActive(dealID, time.Unix(int64(ts.MinTimestamp()), 0))
+}
+
+// timelineHandler serves the timelines of the offers given by `offer` query
+// parameters, or of all offers, as JSON
+func (a *aggregator) timelineHandler(w http.ResponseWriter, r *http.Request) {
+	var ids []uint64
+	for _, s := range r.URL.Query()["offer"] {
+		id, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid offer ID", http.StatusBadRequest)
+			return
+		}
+		ids = append(ids, id)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(a.timeline.list(ids...)); err != nil {
+		a.logger.Warn("Failed to write timeline", "err", err)
+	}
+}
+
+// TimelineAction prints the offer timelines recorded by the aggregator of a chain
+func TimelineAction(cctx *cli.Context) error {
+	cfg, err := config.LoadConfig(cctx.String("config"))
+	if err != nil {
+		return fmt.Errorf("failed to load config: %v", err)
+	}
+	chainName := cctx.String("chain")
+	srcCfg, err := config.GetSourceConfig(cfg, chainName)
+	if err != nil {
+		return fmt.Errorf("invalid chain name '%s': %v", chainName, err)
+	}
+	var ids []uint64
+	for _, arg := range cctx.Args().Slice() {
+		id, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid offer id %q: %w", arg, err)
+		}
+		ids = append(ids, id)
+	}
+	path, err := TimelinePath(cfg, srcCfg.ChainID)
+	if err != nil {
+		return err
+	}
+	tl, err := LoadTimeline(path)
+	if err != nil {
+		return err
+	}
+	offers := tl.List(ids...)
+	if len(ids) > 0 && len(offers) < len(ids) {
+		return fmt.Errorf("only %d of %d offers are in the timeline %s", len(offers), len(ids), path)
+	}
+
+	if cctx.Bool("json") {
+		enc := json.NewEncoder(cctx.App.Writer)
+		enc.SetIndent("", "  ")
+		return enc.Encode(offers)
+	}
+	tw := tabwriter.NewWriter(cctx.App.Writer, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "OFFER\tSTAGE\tOFFERED\tAGGREGATED\tDEAL PROPOSED\tDEAL ACTIVE\tPROVEN\tAGGREGATE\tDEAL")
+	for _, o := range offers {
+		deal := "-"
+		if o.DealID != 0 {
+			deal = strconv.FormatUint(o.DealID, 10)
+		}
+		aggregate := "-"
+		if o.AggregationID != 0 {
+			aggregate = strconv.FormatUint(o.AggregationID, 10)
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", o.OfferID, o.Stage(), formatTime(o.Offered),
+			formatTime(o.Aggregated), formatTime(o.DealProposed), formatTime(o.DealActive), formatTime(o.Proven), aggregate, deal)
+	}
+	return tw.Flush()
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Local().Format(time.DateTime)
+}
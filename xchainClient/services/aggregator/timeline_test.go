@@ -0,0 +1,106 @@
+package aggregator
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "43113.json")
+	tl, err := openTimeline(path, 43113)
+	require.NoError(t, err)
+
+	piece := testPiece(t, 1, 2048)
+	agg := testPiece(t, 2, 1<<20)
+	at := time.Unix(1700000000, 0).UTC()
+
+	require.NoError(t, tl.offered(1, piece.PieceCID.Bytes(), 2048, at, 10))
+	require.NoError(t, tl.offered(2, piece.PieceCID.Bytes(), 2048, at, 12))
+	require.Equal(t, StageOffered, tl.list(1)[0].Stage())
+
+	// The deal can be proposed before the AggregationCommitted event is seen
+	require.NoError(t, tl.dealProposed([]uint64{1, 2}, agg.PieceCID, "uuid", at.Add(2*time.Minute)))
+	require.NoError(t, tl.aggregated(7, agg.PieceCID.Bytes(), []uint64{1, 2}, at.Add(time.Minute), 11))
+	require.Equal(t, StageDealProposed, tl.list(2)[0].Stage())
+	require.Empty(t, tl.inactiveDeals())
+
+	require.NoError(t, tl.proven(agg.PieceCID.Bytes(), 99, at.Add(time.Hour), 20))
+	require.Equal(t, []uint64{99}, tl.inactiveDeals())
+	require.NoError(t, tl.dealActive(99, at.Add(2*time.Hour)))
+	require.Empty(t, tl.inactiveDeals())
+
+	loaded, err := LoadTimeline(path)
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), loaded.LastBlock)
+	offers := loaded.List()
+	require.Len(t, offers, 2)
+	require.Equal(t, uint64(1), offers[0].OfferID)
+	require.Equal(t, StageProven, offers[0].Stage())
+	require.Equal(t, piece.PieceCID.String(), offers[0].CommP)
+	require.Equal(t, agg.PieceCID.String(), offers[0].AggregateCommP)
+	require.Equal(t, uint64(7), offers[0].AggregationID)
+	require.Equal(t, "uuid", offers[0].DealUUID)
+	require.Equal(t, uint64(99), offers[0].DealID)
+	require.True(t, at.Add(2*time.Hour).Equal(*offers[0].DealActive))
+	require.Empty(t, loaded.List(3))
+
+	_, err = openTimeline(path, 1)
+	require.ErrorContains(t, err, "is for chain 43113")
+}
+
+func TestTimelineHold(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "1.json")
+	tl, err := openTimeline(path, 1)
+	require.NoError(t, err)
+	piece := testPiece(t, 1, 2048)
+
+	// Changes made while held are only saved on release
+	tl.hold()
+	require.NoError(t, tl.offered(1, piece.PieceCID.Bytes(), 2048, time.Now(), 5))
+	require.NoError(t, tl.offered(2, piece.PieceCID.Bytes(), 2048, time.Now(), 6))
+	require.NoFileExists(t, path)
+	require.NoError(t, tl.release())
+	loaded, err := LoadTimeline(path)
+	require.NoError(t, err)
+	require.Len(t, loaded.List(), 2)
+	require.Equal(t, uint64(6), loaded.LastBlock)
+
+	// Once released every change is saved again
+	require.NoError(t, tl.offered(3, piece.PieceCID.Bytes(), 2048, time.Now(), 7))
+	loaded, err = LoadTimeline(path)
+	require.NoError(t, err)
+	require.Len(t, loaded.List(), 3)
+}
+
+func TestTimelineHandler(t *testing.T) {
+	tl, err := openTimeline(filepath.Join(t.TempDir(), "1.json"), 1)
+	require.NoError(t, err)
+	piece := testPiece(t, 1, 2048)
+	require.NoError(t, tl.offered(1, piece.PieceCID.Bytes(), 2048, time.Now(), 1))
+	require.NoError(t, tl.offered(2, piece.PieceCID.Bytes(), 2048, time.Now(), 1))
+	a := &aggregator{timeline: tl, logger: slog.Default()}
+
+	rec := httptest.NewRecorder()
+	a.timelineHandler(rec, httptest.NewRequest(http.MethodGet, "/timeline?offer=2", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var offers []OfferTimeline
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &offers))
+	require.Len(t, offers, 1)
+	require.Equal(t, uint64(2), offers[0].OfferID)
+
+	rec = httptest.NewRecorder()
+	a.timelineHandler(rec, httptest.NewRequest(http.MethodGet, "/timeline", nil))
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &offers))
+	require.Len(t, offers, 2)
+
+	rec = httptest.NewRecorder()
+	a.timelineHandler(rec, httptest.NewRequest(http.MethodGet, "/timeline?offer=x", nil))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
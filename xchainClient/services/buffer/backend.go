@@ -0,0 +1,206 @@
+package buffer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/FIL-Builders/xchainClient/config"
+)
+
+const lighthouseGatewayURL = "https://gateway.lighthouse.storage/ipfs/"
+
+// CommPFunc returns the CommP of the data passed to Backend.Put.
+// It is only called once the data has been read to the end.
+type CommPFunc func() (string, error)
+
+// Backend stores offered CAR files somewhere the aggregator can fetch them from
+type Backend interface {
+	// Check verifies the backend is configured and reachable before any data is sent
+	Check(ctx context.Context) error
+	// Put streams data to the buffer under the given name and returns the offer location
+	Put(ctx context.Context, name string, data io.Reader, commP CommPFunc) (string, error)
+}
+
+// BackendOptions are client side settings that apply to a single upload
+type BackendOptions struct {
+	BufferURL string // overrides the configured buffer service url
+	ChunkSize int    // chunk size for the buffer service upload protocol
+	UploadID  string // resume this buffer service upload session
+}
+
+// NewBackend returns the buffer backend with the given name: local, lighthouse or s3
+func NewBackend(name string, cfg *config.Config, srcCfg *config.SourceChainConfig, opts BackendOptions) (Backend, error) {
+	switch name {
+	case "", "local":
+		bufferURL := ResolveBufferURL(cfg, srcCfg, opts.BufferURL)
+		return &localBackend{url: bufferURL, chunkSize: opts.ChunkSize, uploadID: opts.UploadID}, nil
+	case "lighthouse":
+		if cfg.LighthouseApiKey == "" {
+			return nil, fmt.Errorf("LighthouseApiKey must be set to use the lighthouse buffer")
+		}
+		return &lighthouseBackend{apiKey: cfg.LighthouseApiKey}, nil
+	case "s3":
+		return newS3Backend(cfg.S3)
+	default:
+		return nil, fmt.Errorf("unknown buffer backend %q, expected local, lighthouse or s3", name)
+	}
+}
+
+// ResolveBufferURL picks the buffer service url in order of precedence:
+// explicit override, source chain config, global config, localhost
+func ResolveBufferURL(cfg *config.Config, srcCfg *config.SourceChainConfig, override string) string {
+	switch {
+	case override != "":
+		return override
+	case srcCfg != nil && srcCfg.BufferURL != "":
+		return srcCfg.BufferURL
+	case cfg.BufferURL != "":
+		return cfg.BufferURL
+	default:
+		return fmt.Sprintf("http://localhost:%d", cfg.BufferPort)
+	}
+}
+
+// localBackend uploads to an xchain buffer service
+type localBackend struct {
+	url       string
+	chunkSize int
+	uploadID  string
+}
+
+func (b *localBackend) Check(ctx context.Context) error {
+	u, err := url.Parse(b.url)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid buffer url %q", b.url)
+	}
+	if ip := net.ParseIP(u.Hostname()); u.Hostname() == "localhost" || (ip != nil && ip.IsLoopback()) {
+		slog.Warn("Buffer url is a loopback address and cannot be reached by a remote aggregator", "url", b.url)
+	}
+	return checkReachable(ctx, b.url+"/health")
+}
+
+// UploadError is returned by Put when data could not be sent to the upload
+// session ID of a buffer service. Rerunning the upload with the session ID set
+// in BackendOptions resumes it.
+type UploadError struct {
+	ID  string
+	Err error
+}
+
+func (e *UploadError) Error() string {
+	return fmt.Sprintf("failed to upload CAR file to session %s: %v", e.ID, e.Err)
+}
+
+func (e *UploadError) Unwrap() error {
+	return e.Err
+}
+
+// sourceReader keeps the error of the data being uploaded, failing to read it
+// is not an interrupted upload
+type sourceReader struct {
+	io.Reader
+	err error
+}
+
+func (r *sourceReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if err != nil && err != io.EOF {
+		r.err = err
+	}
+	return n, err
+}
+
+// Put resumes the upload session given in BackendOptions, if any, further
+// calls start new sessions
+func (b *localBackend) Put(ctx context.Context, name string, data io.Reader, commP CommPFunc) (string, error) {
+	var upload *ChunkedUpload
+	var err error
+	if b.uploadID != "" {
+		upload, err = ResumeChunkedUpload(ctx, b.url, b.uploadID, b.chunkSize)
+		b.uploadID = ""
+	} else {
+		upload, err = NewChunkedUpload(ctx, b.url, b.chunkSize)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	// Data received by an earlier attempt is skipped, but still has to be read for its CommP
+	if _, err := io.CopyN(io.Discard, data, upload.Offset()); err != nil {
+		return "", fmt.Errorf("failed to skip to offset %d: %w", upload.Offset(), err)
+	}
+
+	slog.Info("Uploading to buffer", "name", name, "url", b.url, "session", upload.ID(), "offset", upload.Offset())
+	src := &sourceReader{Reader: data}
+	if err := upload.Upload(ctx, src); err != nil {
+		if src.err != nil {
+			return "", err
+		}
+		return "", &UploadError{ID: upload.ID(), Err: err}
+	}
+	c, err := commP()
+	if err != nil {
+		return "", err
+	}
+	result, err := upload.Finalize(ctx, c)
+	if err != nil {
+		return "", fmt.Errorf("failed to finalize upload %s: %w", upload.ID(), err)
+	}
+	// Replicated buffers report every location the data can be fetched from
+	// once the copies have been made
+	if result.Replicating {
+		slog.Info("Waiting for the buffer to replicate", "bufferID", result.ID)
+	}
+	locations, err := upload.AwaitLocations(ctx, result)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the locations of buffer id %d: %w", result.ID, err)
+	}
+	if len(locations) > 0 {
+		return JoinLocations(locations), nil
+	}
+	return fmt.Sprintf("%s/get?id=%d", b.url, result.ID), nil
+}
+
+// lighthouseBackend pins the CAR file on Lighthouse and serves it through their gateway
+type lighthouseBackend struct {
+	apiKey string
+}
+
+func (b *lighthouseBackend) Check(ctx context.Context) error {
+	return checkReachable(ctx, lighthouseNodeURL)
+}
+
+func (b *lighthouseBackend) Put(ctx context.Context, name string, data io.Reader, commP CommPFunc) (string, error) {
+	start := time.Now()
+	resp, err := UploadReaderToLighthouse(name, data, b.apiKey)
+	if err != nil {
+		return "", err
+	}
+	slog.Info("Uploaded CAR file to lighthouse", "hash", resp.Hash, "size", resp.Size, "duration", time.Since(start))
+	return lighthouseGatewayURL + resp.Hash, nil
+}
+
+// checkReachable fails unless the url answers an HTTP request, any status below 500 counts
+func checkReachable(ctx context.Context, url string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("buffer at %s is unreachable: %w", url, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("buffer at %s is unhealthy: %s", url, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package buffer
+
+import (
+	"testing"
+
+	"github.com/FIL-Builders/xchainClient/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveBufferURL(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		global   string
+		chain    *config.SourceChainConfig
+		override string
+		expected string
+	}{
+		{name: "localhost", expected: "http://localhost:5077"},
+		{name: "no chain config", global: "https://global", expected: "https://global"},
+		{name: "global", global: "https://global", chain: &config.SourceChainConfig{}, expected: "https://global"},
+		{name: "chain", global: "https://global", chain: &config.SourceChainConfig{BufferURL: "https://chain"}, expected: "https://chain"},
+		{name: "chain without global", chain: &config.SourceChainConfig{BufferURL: "https://chain"}, expected: "https://chain"},
+		{name: "override", global: "https://global", chain: &config.SourceChainConfig{BufferURL: "https://chain"}, override: "https://flag", expected: "https://flag"},
+		{name: "override without config", override: "https://flag", expected: "https://flag"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &config.Config{BufferPort: 5077, BufferURL: tc.global}
+			require.Equal(t, tc.expected, ResolveBufferURL(cfg, tc.chain, tc.override))
+		})
+	}
+}
+
+func TestNewBackend(t *testing.T) {
+	s3 := config.S3Config{Endpoint: "https://s3.example.com/", Bucket: "offers", AccessKey: "access", SecretKey: "secret"}
+	for _, tc := range []struct {
+		name    string
+		backend string
+		cfg     config.Config
+		opts    BackendOptions
+		err     string
+		check   func(t *testing.T, b Backend)
+	}{
+		{
+			name: "default is local",
+			cfg:  config.Config{BufferPort: 5077},
+			check: func(t *testing.T, b Backend) {
+				require.Equal(t, &localBackend{url: "http://localhost:5077"}, b)
+			},
+		},
+		{
+			name:    "local with options",
+			backend: "local",
+			cfg:     config.Config{BufferPort: 5077, BufferURL: "https://global"},
+			opts:    BackendOptions{BufferURL: "https://flag", ChunkSize: 1024, UploadID: "session"},
+			check: func(t *testing.T, b Backend) {
+				require.Equal(t, &localBackend{url: "https://flag", chunkSize: 1024, uploadID: "session"}, b)
+			},
+		},
+		{
+			name:    "lighthouse",
+			backend: "lighthouse",
+			cfg:     config.Config{LighthouseApiKey: "key"},
+			check: func(t *testing.T, b Backend) {
+				require.Equal(t, &lighthouseBackend{apiKey: "key"}, b)
+			},
+		},
+		{
+			name:    "lighthouse without api key",
+			backend: "lighthouse",
+			err:     "LighthouseApiKey must be set",
+		},
+		{
+			name:    "s3",
+			backend: "s3",
+			cfg:     config.Config{S3: s3},
+			check: func(t *testing.T, b Backend) {
+				require.IsType(t, &s3Backend{}, b)
+				require.Equal(t, "https://s3.example.com", b.(*s3Backend).cfg.Endpoint)
+				require.Equal(t, "us-east-1", b.(*s3Backend).cfg.Region)
+			},
+		},
+		{
+			name:    "s3 without bucket",
+			backend: "s3",
+			cfg:     config.Config{S3: config.S3Config{Endpoint: s3.Endpoint, AccessKey: s3.AccessKey, SecretKey: s3.SecretKey}},
+			err:     "must be set to use the s3 buffer",
+		},
+		{
+			name:    "unknown",
+			backend: "ipfs",
+			err:     `unknown buffer backend "ipfs"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := NewBackend(tc.backend, &tc.cfg, nil, tc.opts)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			tc.check(t, b)
+		})
+	}
+}
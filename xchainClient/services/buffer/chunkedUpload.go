@@ -0,0 +1,453 @@
+package buffer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	commcid "github.com/filecoin-project/go-fil-commcid"
+	commp "github.com/filecoin-project/go-fil-commp-hashhash"
+	"github.com/google/uuid"
+)
+
+// Chunked upload protocol
+//
+//	POST  /upload                           create a session, returns {"id": "<session>", "offset": 0}
+//	HEAD  /upload?id=<session>              current offset in the Upload-Offset header
+//	PATCH /upload?id=<session>              append the body at the offset given in Upload-Offset
+//	POST  /upload/finalize?id=<session>&commp=<cid>
+//	                                        check the CommP of the session data and move it
+//	                                        into the buffer, returns {"id": <buffer id>, "locations": [...]}
+//	                                        like /put
+//	GET   /locations?id=<buffer id>         locations of buffered data, "replicating" is set while
+//	                                        the data is mirrored to peer buffers
+//
+// Session data lives on disk under <BufferPath>/uploads so an interrupted
+// upload can be resumed after a client or buffer restart.
+
+const (
+	uploadOffsetHeader = "Upload-Offset"
+	uploadsDir         = "uploads"
+
+	// DefaultChunkSize is the size of each PATCH request sent by ChunkedUpload
+	DefaultChunkSize = 16 << 20
+	// number of attempts made for a single chunk before giving up
+	maxChunkAttempts = 5
+)
+
+type uploadSession struct {
+	ID     string `json:"id"`
+	Offset int64  `json:"offset"`
+}
+
+func (s *BufferHTTPService) sessionPath(id string) (string, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return "", fmt.Errorf("invalid upload id %q", id)
+	}
+	return filepath.Join(s.basePath, uploadsDir, "upload_"+id), nil
+}
+
+// sessionLock serializes writes to a single upload session
+type sessionLock struct {
+	sync.Mutex
+	refs int // requests holding or waiting for the lock, guarded by BufferHTTPService.mu
+}
+
+// lockSession locks upload session id until unlock is called. The lock is
+// dropped once no request holds or waits for it, so finalized and abandoned
+// sessions leave nothing behind.
+func (s *BufferHTTPService) lockSession(id string) (unlock func()) {
+	s.mu.Lock()
+	lk, ok := s.sessionLks[id]
+	if !ok {
+		lk = &sessionLock{}
+		s.sessionLks[id] = lk
+	}
+	lk.refs++
+	s.mu.Unlock()
+
+	lk.Lock()
+	return func() {
+		lk.Unlock()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if lk.refs--; lk.refs == 0 {
+			delete(s.sessionLks, id)
+		}
+	}
+}
+
+func (s *BufferHTTPService) UploadHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		s.createUpload(w, r)
+	case http.MethodHead:
+		s.uploadOffset(w, r)
+	case http.MethodPatch:
+		s.patchUpload(w, r)
+	default:
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *BufferHTTPService) createUpload(w http.ResponseWriter, r *http.Request) {
+	id := uuid.New().String()
+	path, err := s.sessionPath(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		http.Error(w, fmt.Errorf("failed to create uploads dir %w", err).Error(), http.StatusInternalServerError)
+		return
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		http.Error(w, fmt.Errorf("failed to create upload session %w", err).Error(), http.StatusInternalServerError)
+		return
+	}
+	f.Close()
+
+	slog.Info("Created upload session", "session", id)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(uploadOffsetHeader, "0")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(uploadSession{ID: id})
+}
+
+func (s *BufferHTTPService) uploadOffset(w http.ResponseWriter, r *http.Request) {
+	path, err := s.sessionPath(r.URL.Query().Get("id"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		http.Error(w, "No upload found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set(uploadOffsetHeader, strconv.FormatInt(fi.Size(), 10))
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *BufferHTTPService) patchUpload(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	path, err := s.sessionPath(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	offset, err := strconv.ParseInt(r.Header.Get(uploadOffsetHeader), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid "+uploadOffsetHeader, http.StatusBadRequest)
+		return
+	}
+
+	defer s.lockSession(id)()
+
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		http.Error(w, "No upload found", http.StatusNotFound)
+		return
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		http.Error(w, fmt.Errorf("failed to stat upload %w", err).Error(), http.StatusInternalServerError)
+		return
+	}
+	// Chunks must be appended in order, tell the client where to resume from
+	if offset != fi.Size() {
+		w.Header().Set(uploadOffsetHeader, strconv.FormatInt(fi.Size(), 10))
+		http.Error(w, "Offset mismatch", http.StatusConflict)
+		return
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		http.Error(w, fmt.Errorf("failed to seek upload %w", err).Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Partial writes are kept so that the client can resume from the new offset
+	n, err := io.Copy(f, r.Body)
+	w.Header().Set(uploadOffsetHeader, strconv.FormatInt(offset+n, 10))
+	if err != nil {
+		http.Error(w, "Failed to write data", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *BufferHTTPService) FinalizeUploadHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+	id := r.URL.Query().Get("id")
+	path, err := s.sessionPath(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	expected := r.URL.Query().Get("commp")
+	if expected == "" {
+		http.Error(w, "commp is required", http.StatusBadRequest)
+		return
+	}
+
+	defer s.lockSession(id)()
+
+	actual, err := fileCommP(path)
+	if errors.Is(err, os.ErrNotExist) {
+		http.Error(w, "No upload found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, fmt.Errorf("failed to compute commp %w", err).Error(), http.StatusInternalServerError)
+		return
+	}
+	if actual != expected {
+		http.Error(w, fmt.Sprintf("CommP mismatch: expected %s, got %s", expected, actual), http.StatusUnprocessableEntity)
+		return
+	}
+
+	s.mu.Lock()
+	bufferID := s.nextID
+	s.nextID++
+	s.mu.Unlock()
+
+	if err := os.Rename(path, filepath.Join(s.basePath, fmt.Sprintf("data_%d", bufferID))); err != nil {
+		http.Error(w, fmt.Errorf("failed to store upload %w", err).Error(), http.StatusInternalServerError)
+		return
+	}
+	slog.Info("Upload session finalized", "session", id, "bufferID", bufferID, "commP", actual)
+
+	s.writePutResult(w, r, bufferID)
+}
+
+// fileCommP streams the file at path through a CommP calculator
+func fileCommP(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	cp := new(commp.Calc)
+	if _, err := io.Copy(cp, bufio.NewReaderSize(f, 1<<20)); err != nil {
+		return "", err
+	}
+	rawCommP, _, err := cp.Digest()
+	if err != nil {
+		return "", err
+	}
+	c, err := commcid.DataCommitmentV1ToCID(rawCommP)
+	if err != nil {
+		return "", err
+	}
+	return c.String(), nil
+}
+
+// ChunkedUpload is the client side of a resumable upload session on a buffer service
+type ChunkedUpload struct {
+	baseURL   string
+	id        string
+	offset    int64
+	chunkSize int
+	client    *http.Client
+}
+
+// NewChunkedUpload opens a new upload session on the buffer service at baseURL
+func NewChunkedUpload(ctx context.Context, baseURL string, chunkSize int) (*ChunkedUpload, error) {
+	u := newChunkedUpload(baseURL, "", chunkSize)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/upload", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create upload session: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("failed to create upload session: %s", readError(resp))
+	}
+	var session uploadSession
+	if err := json.NewDecoder(resp.Body).Decode(&session); err != nil {
+		return nil, fmt.Errorf("failed to decode upload session: %w", err)
+	}
+	u.id = session.ID
+	return u, nil
+}
+
+// ResumeChunkedUpload reattaches to an existing upload session and fetches its offset
+func ResumeChunkedUpload(ctx context.Context, baseURL string, id string, chunkSize int) (*ChunkedUpload, error) {
+	u := newChunkedUpload(baseURL, id, chunkSize)
+	offset, err := u.serverOffset(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resume upload session %s: %w", id, err)
+	}
+	u.offset = offset
+	return u, nil
+}
+
+func newChunkedUpload(baseURL string, id string, chunkSize int) *ChunkedUpload {
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	return &ChunkedUpload{
+		baseURL:   baseURL,
+		id:        id,
+		chunkSize: chunkSize,
+		client:    &http.Client{Timeout: 10 * time.Minute},
+	}
+}
+
+// ID of the upload session, pass it to ResumeChunkedUpload to continue an interrupted upload
+func (u *ChunkedUpload) ID() string {
+	return u.id
+}
+
+// Offset is the number of bytes the buffer service has acknowledged
+func (u *ChunkedUpload) Offset() int64 {
+	return u.offset
+}
+
+// Upload sends everything read from r starting at the current offset.
+// The caller is responsible for positioning r at Offset() before calling.
+// Failed chunks are retried, resyncing with the server offset between attempts.
+func (u *ChunkedUpload) Upload(ctx context.Context, r io.Reader) error {
+	buf := make([]byte, u.chunkSize)
+	for {
+		n, readErr := io.ReadFull(r, buf)
+		if n > 0 {
+			if err := u.sendChunk(ctx, buf[:n]); err != nil {
+				return err
+			}
+		}
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			return nil
+		}
+		if readErr != nil {
+			return fmt.Errorf("failed to read upload data: %w", readErr)
+		}
+	}
+}
+
+func (u *ChunkedUpload) sendChunk(ctx context.Context, chunk []byte) error {
+	start := u.offset
+	end := start + int64(len(chunk))
+	var lastErr error
+	for attempt := 0; attempt < maxChunkAttempts; attempt++ {
+		if attempt > 0 {
+			slog.Warn("Retrying chunk", "session", u.id, "offset", u.offset, "attempt", attempt+1, "err", lastErr)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(attempt) * time.Second):
+			}
+			// The previous attempt may have been partially written
+			offset, err := u.serverOffset(ctx)
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			if offset < start || offset > end {
+				return fmt.Errorf("upload %s offset %d outside of chunk [%d, %d)", u.id, offset, start, end)
+			}
+			u.offset = offset
+		}
+		if u.offset == end {
+			return nil
+		}
+
+		offset, err := u.patch(ctx, chunk[u.offset-start:])
+		if offset >= start && offset <= end {
+			u.offset = offset
+		}
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+	}
+	return fmt.Errorf("failed to upload chunk at offset %d after %d attempts: %w", start, maxChunkAttempts, lastErr)
+}
+
+// patch sends data at the current offset and returns the offset reported by the server
+func (u *ChunkedUpload) patch(ctx context.Context, data []byte) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, u.sessionURL("/upload"), bytes.NewReader(data))
+	if err != nil {
+		return -1, err
+	}
+	req.Header.Set("Content-Type", "application/offset+octet-stream")
+	req.Header.Set(uploadOffsetHeader, strconv.FormatInt(u.offset, 10))
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return -1, err
+	}
+	defer resp.Body.Close()
+	offset, perr := strconv.ParseInt(resp.Header.Get(uploadOffsetHeader), 10, 64)
+	if perr != nil {
+		offset = -1
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		return offset, fmt.Errorf("unexpected response: %s", readError(resp))
+	}
+	return offset, nil
+}
+
+func (u *ChunkedUpload) serverOffset(ctx context.Context) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, u.sessionURL("/upload"), nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return strconv.ParseInt(resp.Header.Get(uploadOffsetHeader), 10, 64)
+}
+
+// Finalize asks the buffer service to verify the uploaded data against commP
+// and returns the buffer id and locations the data is now served under
+func (u *ChunkedUpload) Finalize(ctx context.Context, commP string) (*PutResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.sessionURL("/upload/finalize")+"&commp="+commP, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to finalize upload: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to finalize upload: %s", readError(resp))
+	}
+	var result PutResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse finalize response: %w", err)
+	}
+	return &result, nil
+}
+
+func (u *ChunkedUpload) sessionURL(path string) string {
+	return fmt.Sprintf("%s%s?id=%s", u.baseURL, path, u.id)
+}
+
+func readError(resp *http.Response) string {
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Sprintf("%s: %s", resp.Status, bytes.TrimSpace(msg))
+}
@@ -0,0 +1,113 @@
+package buffer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBuffer(t *testing.T) (*BufferHTTPService, *httptest.Server) {
+	srv, err := newBufferHTTPService(context.Background(), t.TempDir())
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/put", srv.PutHandler)
+	mux.HandleFunc("/get", srv.GetHandler)
+	mux.HandleFunc("/upload", srv.UploadHandler)
+	mux.HandleFunc("/upload/finalize", srv.FinalizeUploadHandler)
+	mux.HandleFunc("/locations", srv.LocationsHandler)
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+	srv.publicURL = ts.URL
+	return srv, ts
+}
+
+func TestChunkedUploadResume(t *testing.T) {
+	ctx := context.Background()
+	srv, ts := newTestBuffer(t)
+
+	data := make([]byte, 10000)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+	dataPath := filepath.Join(t.TempDir(), "data")
+	require.NoError(t, os.WriteFile(dataPath, data, 0644))
+	commP, err := fileCommP(dataPath)
+	require.NoError(t, err)
+
+	// Upload the first part then abandon the session
+	upload, err := NewChunkedUpload(ctx, ts.URL, 1024)
+	require.NoError(t, err)
+	require.NoError(t, upload.Upload(ctx, bytes.NewReader(data[:4500])))
+	assert.EqualValues(t, 4500, upload.Offset())
+
+	// Resume from the offset the server reports
+	resumed, err := ResumeChunkedUpload(ctx, ts.URL, upload.ID(), 1024)
+	require.NoError(t, err)
+	assert.EqualValues(t, 4500, resumed.Offset())
+	require.NoError(t, resumed.Upload(ctx, bytes.NewReader(data[resumed.Offset():])))
+
+	_, err = resumed.Finalize(ctx, "baga6ea4seaqao7s73y24kcutaosvacpdjgfe5pw76ooefnyqw4ynr3d2y6x2mpq")
+	assert.Error(t, err, "finalize must reject a wrong commp")
+
+	result, err := resumed.Finalize(ctx, commP)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.ID)
+	assert.Equal(t, 2, srv.nextID)
+	assert.Empty(t, srv.sessionLks, "session locks are dropped once released")
+
+	resp, err := http.Get(ts.URL + "/get?id=1")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	got, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, data, got)
+}
+
+func TestChunkedUploadOffsetConflict(t *testing.T) {
+	ctx := context.Background()
+	srv, ts := newTestBuffer(t)
+
+	upload, err := NewChunkedUpload(ctx, ts.URL, 16)
+	require.NoError(t, err)
+	require.NoError(t, upload.Upload(ctx, bytes.NewReader([]byte("0123456789"))))
+
+	// A stale client writing at an old offset is told where to resume
+	stale := newChunkedUpload(ts.URL, upload.ID(), 16)
+	offset, err := stale.patch(ctx, []byte("abc"))
+	assert.Error(t, err)
+	assert.EqualValues(t, 10, offset)
+	assert.Empty(t, srv.sessionLks, "abandoned sessions keep no lock")
+}
+
+// failingReader returns some data then fails, like a dropped client connection
+type failingReader struct{ sent bool }
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.sent {
+		return 0, io.ErrUnexpectedEOF
+	}
+	r.sent = true
+	return copy(p, "partial"), nil
+}
+
+func TestStoreFailure(t *testing.T) {
+	srv, _ := newTestBuffer(t)
+	_, err := srv.store(&failingReader{})
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.NoFileExists(t, filepath.Join(srv.basePath, "data_1"))
+	tmp, err := filepath.Glob(filepath.Join(srv.basePath, "put_*"))
+	require.NoError(t, err)
+	assert.Empty(t, tmp, "partial data is removed")
+
+	id, err := srv.store(bytes.NewReader([]byte("data")))
+	require.NoError(t, err)
+	assert.Equal(t, 1, id)
+}
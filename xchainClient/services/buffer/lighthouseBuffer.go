@@ -1,7 +1,6 @@
 package buffer
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -10,6 +9,8 @@ import (
 	"os"
 	"path/filepath"
 	"time"
+
+	"github.com/FIL-Builders/xchainClient/metrics"
 )
 
 const lighthouseNodeURL = "https://node.lighthouse.storage"
@@ -21,31 +22,46 @@ type UploadFileResponse struct {
 }
 
 func UploadToLighthouse(sourcePath, apiKey string) (*UploadFileResponse, error) {
-	endpoint := lighthouseNodeURL + "/api/v0/add?wrap-with-directory=false"
-
 	file, err := os.Open(sourcePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
 	defer file.Close()
 
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
+	return UploadReaderToLighthouse(filepath.Base(sourcePath), file, apiKey)
+}
 
-	part, err := writer.CreateFormFile("file", filepath.Base(sourcePath))
+// UploadReaderToLighthouse streams data to lighthouse as a file with the given name
+func UploadReaderToLighthouse(name string, data io.Reader, apiKey string) (*UploadFileResponse, error) {
+	start := time.Now()
+	resp, err := uploadToLighthouse(name, data, apiKey)
+	outcome := metrics.OutcomeSuccess
 	if err != nil {
-		return nil, fmt.Errorf("failed to create form file: %w", err)
+		outcome = metrics.OutcomeFailed
 	}
+	metrics.LighthouseUploadSeconds.WithLabelValues(outcome).Observe(time.Since(start).Seconds())
+	return resp, err
+}
 
-	_, err = io.Copy(part, file)
-	if err != nil {
-		return nil, fmt.Errorf("failed to copy file content: %w", err)
-	}
+func uploadToLighthouse(name string, data io.Reader, apiKey string) (*UploadFileResponse, error) {
+	endpoint := lighthouseNodeURL + "/api/v0/add?wrap-with-directory=false"
 
-	err = writer.Close()
-	if err != nil {
-		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
-	}
+	// Write the multipart body as it is sent rather than buffering it
+	body, bodyWriter := io.Pipe()
+	writer := multipart.NewWriter(bodyWriter)
+	go func() {
+		part, err := writer.CreateFormFile("file", name)
+		if err != nil {
+			bodyWriter.CloseWithError(fmt.Errorf("failed to create form file: %w", err))
+			return
+		}
+		if _, err := io.Copy(part, data); err != nil {
+			bodyWriter.CloseWithError(fmt.Errorf("failed to copy file content: %w", err))
+			return
+		}
+		bodyWriter.CloseWithError(writer.Close())
+	}()
+	defer body.Close()
 
 	req, err := http.NewRequest("POST", endpoint, body)
 	if err != nil {
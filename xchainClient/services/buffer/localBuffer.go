@@ -4,22 +4,30 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"path/filepath"
 
 	"github.com/FIL-Builders/xchainClient/config"
+	"github.com/FIL-Builders/xchainClient/metrics"
 	"github.com/mitchellh/go-homedir"
 
 	"strconv"
+	"strings"
 	"sync"
+	"time"
 )
 
 type BufferHTTPService struct {
-	basePath string
-	nextID   int
-	mu       sync.Mutex
+	ctx         context.Context // lifetime of the service, background replication stops with it
+	basePath    string
+	nextID      int
+	mu          sync.Mutex
+	sessionLks  map[string]*sessionLock // per chunked upload session write locks, while requests hold them
+	replicating map[int]bool            // buffer ids being mirrored to the peers
+	publicURL   string                  // base url other services reach this buffer at
+	peers       []string                // base urls of peer buffers uploads are mirrored to
 }
 
 // Function to start the buffer service
@@ -32,39 +40,74 @@ func StartBufferService(ctx context.Context, cfg *config.Config) error {
 		return err
 	}
 
-	srv, err := newBufferHTTPService(cfg.BufferPath)
+	srv, err := newBufferHTTPService(ctx, cfg.BufferPath)
 	if err != nil {
-		return &http.MaxBytesError{}
+		return err
+	}
+	srv.publicURL = cfg.BufferURL
+	if srv.publicURL == "" {
+		srv.publicURL = fmt.Sprintf("http://localhost:%d", cfg.BufferPort)
+	}
+	srv.peers = cfg.BufferPeers
+	if err := metrics.WatchBufferDisk(path); err != nil {
+		return err
 	}
 	http.HandleFunc("/put", srv.PutHandler)
 	http.HandleFunc("/get", srv.GetHandler)
+	http.HandleFunc("/upload", srv.UploadHandler)
+	http.HandleFunc("/upload/finalize", srv.FinalizeUploadHandler)
+	http.HandleFunc("/locations", srv.LocationsHandler)
+	http.HandleFunc("/health", srv.HealthHandler)
 
-	log.Printf("Buffer service starting on port %d\n", cfg.BufferPort)
+	slog.Info("Buffer service starting", "port", cfg.BufferPort)
 	server := &http.Server{
 		Addr:    fmt.Sprintf("0.0.0.0:%d", cfg.BufferPort),
 		Handler: nil, // http.DefaultServeMux
 	}
 
 	// Start server in a goroutine
+	errc := make(chan error, 1)
 	go func() {
-		if err := server.ListenAndServe(); err != http.ErrServerClosed {
-			log.Fatalf("Buffer HTTP server ListenAndServe: %v", err)
-		}
+		errc <- server.ListenAndServe()
 	}()
 
-	// Wait for context cancellation
-	<-ctx.Done()
-	return server.Shutdown(context.Background())
+	// Wait for context cancellation, or the server failing to listen
+	select {
+	case err := <-errc:
+		return fmt.Errorf("buffer server: %w", err)
+	case <-ctx.Done():
+	}
+	// Let uploads and downloads in flight finish within the grace period
+	slog.Info("Shutting down buffer service")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.GracePeriod())
+	defer cancel()
+	return server.Shutdown(shutdownCtx)
 }
 
-func newBufferHTTPService(basePath string) (*BufferHTTPService, error) {
+func newBufferHTTPService(ctx context.Context, basePath string) (*BufferHTTPService, error) {
 	path, err := homedir.Expand(basePath)
 	if err != nil {
 		return nil, err
 	}
+	// Continue numbering after any data already in the buffer so that
+	// a restart does not overwrite previously stored uploads
+	nextID := 1
+	matches, err := filepath.Glob(filepath.Join(path, "data_*"))
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range matches {
+		id, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(m), "data_"))
+		if err == nil && id >= nextID {
+			nextID = id + 1
+		}
+	}
 	return &BufferHTTPService{
-		basePath: path,
-		nextID:   1,
+		ctx:         ctx,
+		basePath:    path,
+		nextID:      nextID,
+		sessionLks:  make(map[string]*sessionLock),
+		replicating: make(map[int]bool),
 	}, nil
 }
 
@@ -74,28 +117,46 @@ func (s *BufferHTTPService) PutHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	filePath := filepath.Join(s.basePath, fmt.Sprintf("data_%d", s.nextID))
-	file, err := os.Create(filePath)
+	id, err := s.store(r.Body)
 	if err != nil {
-		http.Error(w, fmt.Errorf("failed to create file %w", err).Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	defer file.Close()
+	s.writePutResult(w, r, id)
+}
 
-	_, err = io.Copy(file, r.Body)
+// store writes data into the buffer under the next free id. The data is
+// written to a temporary file first so that a large upload does not hold up
+// the id allocation of every other upload.
+func (s *BufferHTTPService) store(data io.Reader) (int, error) {
+	file, err := os.CreateTemp(s.basePath, "put_*")
 	if err != nil {
-		http.Error(w, "Failed to write data", http.StatusInternalServerError)
-		return
+		return 0, fmt.Errorf("failed to create file %w", err)
+	}
+	_, err = io.Copy(file, data)
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return 0, fmt.Errorf("failed to write data: %w", err)
 	}
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	id := s.nextID
+	if err := os.Rename(file.Name(), filepath.Join(s.basePath, fmt.Sprintf("data_%d", id))); err != nil {
+		os.Remove(file.Name())
+		return 0, fmt.Errorf("failed to store data: %w", err)
+	}
 	s.nextID++
+	return id, nil
+}
 
+// HealthHandler lets clients check the buffer is reachable before uploading
+func (s *BufferHTTPService) HealthHandler(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
-	w.Write([]byte(fmt.Sprintf("{\"id\": %d}", id)))
+	w.Write([]byte("ok"))
 }
 
 func (s *BufferHTTPService) GetHandler(w http.ResponseWriter, r *http.Request) {
@@ -119,5 +180,6 @@ func (s *BufferHTTPService) GetHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	defer file.Close()
 
-	io.Copy(w, file)
+	// ServeContent honours Range requests so readers can resume from an offset
+	http.ServeContent(w, r, "", time.Time{}, file)
 }
@@ -0,0 +1,263 @@
+package buffer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Offers carry a single location string. When data is mirrored across buffer
+// nodes every url it can be fetched from is listed, separated by spaces.
+
+// ParseLocations splits an offer location into the urls it lists
+func ParseLocations(location string) []string {
+	return strings.Fields(location)
+}
+
+// JoinLocations encodes a list of urls into a single offer location
+func JoinLocations(urls []string) string {
+	return strings.Join(urls, " ")
+}
+
+// PutResult is the response of /put, /upload/finalize and /locations
+type PutResult struct {
+	ID        int      `json:"id"`
+	Locations []string `json:"locations,omitempty"`
+	// Replicating is set while copies are pushed to peers, poll /locations for them
+	Replicating bool `json:"replicating,omitempty"`
+}
+
+const (
+	// time allowed to push a single copy to a peer
+	replicaPushTimeout = 2 * time.Hour
+	// number of /locations polls in a row that may fail before giving up
+	maxLocationsAttempts = 5
+)
+
+// locationsPollInterval is how often clients ask for the locations of data being replicated
+var locationsPollInterval = 5 * time.Second
+
+// location is the url the buffered data with the given id is served under by this node
+func (s *BufferHTTPService) location(id int) string {
+	return fmt.Sprintf("%s/get?id=%d", s.publicURL, id)
+}
+
+func (s *BufferHTTPService) locationsPath(id int) string {
+	return filepath.Join(s.basePath, fmt.Sprintf("locations_%d.json", id))
+}
+
+// startReplication mirrors the buffered data with the given id to the peers in
+// the background, on the lifetime of the service rather than of the request
+// that stored it. The returned result lists this node only until it is done.
+func (s *BufferHTTPService) startReplication(id int) PutResult {
+	result := PutResult{ID: id, Locations: []string{s.location(id)}}
+	if len(s.peers) == 0 {
+		return result
+	}
+	s.mu.Lock()
+	s.replicating[id] = true
+	s.mu.Unlock()
+
+	go func() {
+		replicated := PutResult{ID: id, Locations: s.replicate(s.ctx, id)}
+		if err := writeJSON(s.locationsPath(id), replicated); err != nil {
+			slog.Warn("Failed to save buffer data locations", "bufferID", id, "err", err)
+		}
+		s.mu.Lock()
+		delete(s.replicating, id)
+		s.mu.Unlock()
+	}()
+	result.Replicating = true
+	return result
+}
+
+// replicate mirrors the buffered data with the given id to every configured
+// peer and returns all locations it can be fetched from, this node first.
+// Peers that fail are logged and left out.
+func (s *BufferHTTPService) replicate(ctx context.Context, id int) []string {
+	locations := make([]string, 1+len(s.peers))
+	locations[0] = s.location(id)
+
+	var wg sync.WaitGroup
+	for i, peer := range s.peers {
+		wg.Add(1)
+		go func(i int, peer string) {
+			defer wg.Done()
+			peerID, err := s.pushToPeer(ctx, peer, id)
+			if err != nil {
+				slog.Warn("Failed to replicate buffer data", "bufferID", id, "peer", peer, "err", err)
+				return
+			}
+			locations[i+1] = fmt.Sprintf("%s/get?id=%d", peer, peerID)
+			slog.Info("Replicated buffer data", "bufferID", id, "peer", peer, "peerBufferID", peerID)
+		}(i, peer)
+	}
+	wg.Wait()
+
+	replicated := locations[:0]
+	for _, l := range locations {
+		if l != "" {
+			replicated = append(replicated, l)
+		}
+	}
+	return replicated
+}
+
+// pushToPeer uploads a buffered file to a peer buffer node marked as a replica
+// so that the peer does not replicate it further
+func (s *BufferHTTPService) pushToPeer(ctx context.Context, peer string, id int) (int, error) {
+	file, err := os.Open(filepath.Join(s.basePath, fmt.Sprintf("data_%d", id)))
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, replicaPushTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, peer+"/put?replica=true", file)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected response: %s", readError(resp))
+	}
+	var result PutResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return result.ID, nil
+}
+
+// writePutResult replies with the buffer id as soon as the data is stored.
+// Unless the request is itself a replica pushed by a peer, the data is then
+// mirrored to the peers in the background.
+func (s *BufferHTTPService) writePutResult(w http.ResponseWriter, r *http.Request, id int) {
+	result := PutResult{ID: id}
+	if r.URL.Query().Get("replica") == "" {
+		result = s.startReplication(id)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
+
+// LocationsHandler reports every location the buffered data with the given id
+// is served under, with replicating set while copies are pushed to peers
+func (s *BufferHTTPService) LocationsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		return
+	}
+	id, err := strconv.Atoi(r.URL.Query().Get("id"))
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	result, err := s.locations(id)
+	if errors.Is(err, os.ErrNotExist) {
+		http.Error(w, "No data found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (s *BufferHTTPService) locations(id int) (*PutResult, error) {
+	s.mu.Lock()
+	replicating := s.replicating[id]
+	s.mu.Unlock()
+	if replicating {
+		return &PutResult{ID: id, Locations: []string{s.location(id)}, Replicating: true}, nil
+	}
+
+	bs, err := os.ReadFile(s.locationsPath(id))
+	if err == nil {
+		var result PutResult
+		if err := json.Unmarshal(bs, &result); err != nil {
+			return nil, fmt.Errorf("invalid locations of buffer id %d: %w", id, err)
+		}
+		return &result, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	// Data stored without peers, or whose replication was cut short by a restart
+	if _, err := os.Stat(filepath.Join(s.basePath, fmt.Sprintf("data_%d", id))); err != nil {
+		return nil, err
+	}
+	return &PutResult{ID: id, Locations: []string{s.location(id)}}, nil
+}
+
+// writeJSON writes v to path through a temporary file so readers never see a partial file
+func writeJSON(path string, v any) error {
+	bs, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, bs, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// AwaitLocations waits for the buffer service to replicate the data of a
+// finalized upload to its peers and returns every location it is served under
+func (u *ChunkedUpload) AwaitLocations(ctx context.Context, result *PutResult) ([]string, error) {
+	failures := 0
+	for result.Replicating {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(locationsPollInterval):
+		}
+		next, err := u.locations(ctx, result.ID)
+		if err != nil {
+			if failures++; failures == maxLocationsAttempts {
+				return nil, err
+			}
+			slog.Warn("Failed to get buffer data locations", "bufferID", result.ID, "err", err)
+			continue
+		}
+		failures = 0
+		result = next
+	}
+	return result.Locations, nil
+}
+
+func (u *ChunkedUpload) locations(ctx context.Context, id int) (*PutResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/locations?id=%d", u.baseURL, id), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response: %s", readError(resp))
+	}
+	var result PutResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode locations: %w", err)
+	}
+	return &result, nil
+}
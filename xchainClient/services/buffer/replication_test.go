@@ -0,0 +1,128 @@
+package buffer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocations(t *testing.T) {
+	urls := []string{"http://a:5077/get?id=1", "http://b:5077/get?id=7"}
+	location := JoinLocations(urls)
+	assert.Equal(t, "http://a:5077/get?id=1 http://b:5077/get?id=7", location)
+	assert.Equal(t, urls, ParseLocations(location))
+
+	// A single url is a location of its own
+	assert.Equal(t, []string{"https://gateway/ipfs/cid"}, ParseLocations("https://gateway/ipfs/cid"))
+	assert.Equal(t, urls, ParseLocations("  http://a:5077/get?id=1 \n\thttp://b:5077/get?id=7 "))
+	assert.Empty(t, ParseLocations(""))
+}
+
+func get(t *testing.T, url string) []byte {
+	t.Helper()
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return data
+}
+
+func TestReplicate(t *testing.T) {
+	ctx := context.Background()
+	origin, ts := newTestBuffer(t)
+	peer, peerTS := newTestBuffer(t)
+	_, down := newTestBuffer(t)
+	down.Close()
+	origin.peers = []string{peerTS.URL, down.URL}
+
+	// Ids already taken on the peer show the replica gets an id of its own
+	_, err := peer.store(bytes.NewReader([]byte("other")))
+	require.NoError(t, err)
+
+	data := make([]byte, 10000)
+	_, err = rand.Read(data)
+	require.NoError(t, err)
+	id, err := origin.store(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	// Peers that fail are left out
+	locations := origin.replicate(ctx, id)
+	require.Equal(t, []string{ts.URL + "/get?id=1", peerTS.URL + "/get?id=2"}, locations)
+	for _, l := range locations {
+		assert.Equal(t, data, get(t, l))
+	}
+	// The peer does not replicate the copy further
+	assert.Empty(t, peer.replicating)
+}
+
+func TestReplicateInBackground(t *testing.T) {
+	interval := locationsPollInterval
+	locationsPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { locationsPollInterval = interval })
+	ctx := context.Background()
+	origin, ts := newTestBuffer(t)
+	_, peerTS := newTestBuffer(t)
+
+	// Peer pushes are held up until the finalize response has been received
+	release := make(chan struct{})
+	slow := http.NewServeMux()
+	slow.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		resp, err := http.Post(peerTS.URL+r.URL.RequestURI(), "application/octet-stream", r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		defer resp.Body.Close()
+		w.WriteHeader(resp.StatusCode)
+		io.Copy(w, resp.Body)
+	})
+	slowTS := httptest.NewServer(slow)
+	t.Cleanup(slowTS.Close)
+	origin.peers = []string{peerTS.URL, slowTS.URL}
+
+	data := bytes.Repeat([]byte("replicated data "), 64)
+	dataPath := filepath.Join(t.TempDir(), "data")
+	require.NoError(t, os.WriteFile(dataPath, data, 0644))
+	commP, err := fileCommP(dataPath)
+	require.NoError(t, err)
+
+	upload, err := NewChunkedUpload(ctx, ts.URL, 1024)
+	require.NoError(t, err)
+	require.NoError(t, upload.Upload(ctx, bytes.NewReader(data)))
+	result, err := upload.Finalize(ctx, commP)
+	require.NoError(t, err)
+	assert.True(t, result.Replicating)
+	assert.Equal(t, []string{ts.URL + "/get?id=1"}, result.Locations)
+
+	close(release)
+	locations, err := upload.AwaitLocations(ctx, result)
+	require.NoError(t, err)
+	require.Len(t, locations, 3)
+	for _, l := range locations {
+		assert.Equal(t, data, get(t, l))
+	}
+
+	// Locations are kept once replication is done
+	done, err := origin.locations(1)
+	require.NoError(t, err)
+	assert.False(t, done.Replicating)
+	assert.Equal(t, locations, done.Locations)
+
+	resp, err := http.Get(ts.URL + "/locations?id=2")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,347 @@
+package buffer
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FIL-Builders/xchainClient/config"
+)
+
+const (
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+	// longest validity S3 accepts for presigned urls
+	maxPresignExpiry = 7 * 24 * time.Hour
+	// part size for multipart uploads, S3 allows at most 10000 parts per object
+	s3PartSize = 16 << 20
+)
+
+// s3Backend uploads CAR files to an S3 compatible bucket using path style requests
+// signed with AWS signature version 4
+type s3Backend struct {
+	cfg    config.S3Config
+	client *http.Client
+}
+
+func newS3Backend(cfg config.S3Config) (*s3Backend, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" || cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return nil, fmt.Errorf("S3 Endpoint, Bucket, AccessKey and SecretKey must be set to use the s3 buffer")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	cfg.Endpoint = strings.TrimSuffix(cfg.Endpoint, "/")
+	return &s3Backend{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 2 * time.Hour},
+	}, nil
+}
+
+func (b *s3Backend) Check(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, b.objectURL(""), nil)
+	if err != nil {
+		return err
+	}
+	b.sign(req, time.Now())
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("s3 bucket %s is unreachable: %w", b.cfg.Bucket, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("s3 bucket %s is not accessible: %s", b.cfg.Bucket, resp.Status)
+	}
+	return nil
+}
+
+func (b *s3Backend) Put(ctx context.Context, name string, data io.Reader, commP CommPFunc) (string, error) {
+	start := time.Now()
+	uploadID, err := b.createMultipartUpload(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	parts, err := b.uploadParts(ctx, name, uploadID, data)
+	if err == nil {
+		err = b.completeMultipartUpload(ctx, name, uploadID, parts)
+	}
+	if err != nil {
+		// Leave no orphaned parts behind, they are billed until aborted
+		if abortErr := b.abortMultipartUpload(context.Background(), name, uploadID); abortErr != nil {
+			slog.Warn("Failed to abort s3 upload", "upload", uploadID, "err", abortErr)
+		}
+		return "", fmt.Errorf("failed to upload to s3: %w", err)
+	}
+	slog.Info("Uploaded CAR file to s3", "bucket", b.cfg.Bucket, "name", name, "parts", len(parts), "duration", time.Since(start))
+
+	if b.cfg.PublicURL != "" {
+		return strings.TrimSuffix(b.cfg.PublicURL, "/") + "/" + name, nil
+	}
+	// Private bucket, hand out a url that stays valid long enough to aggregate
+	return b.presign(http.MethodGet, b.objectURL(name), time.Now(), maxPresignExpiry)
+}
+
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+func (b *s3Backend) createMultipartUpload(ctx context.Context, key string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.objectURL(key)+"?uploads=", nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/vnd.ipld.car")
+	resp, err := b.do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		UploadID string `xml:"UploadId"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode multipart upload: %w", err)
+	}
+	return result.UploadID, nil
+}
+
+// uploadParts reads data in s3PartSize parts, only one part is held in memory at a time
+func (b *s3Backend) uploadParts(ctx context.Context, key string, uploadID string, data io.Reader) ([]completedPart, error) {
+	var parts []completedPart
+	buf := make([]byte, s3PartSize)
+	for partNumber := 1; ; partNumber++ {
+		n, err := io.ReadFull(data, buf)
+		if err == io.EOF && partNumber > 1 {
+			return parts, nil
+		}
+		if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+			return nil, err
+		}
+		etag, perr := b.uploadPart(ctx, key, uploadID, partNumber, buf[:n])
+		if perr != nil {
+			return nil, perr
+		}
+		parts = append(parts, completedPart{PartNumber: partNumber, ETag: etag})
+		if err != nil {
+			return parts, nil
+		}
+	}
+}
+
+func (b *s3Backend) uploadPart(ctx context.Context, key string, uploadID string, partNumber int, part []byte) (string, error) {
+	partURL := fmt.Sprintf("%s?partNumber=%d&uploadId=%s", b.objectURL(key), partNumber, url.QueryEscape(uploadID))
+	var lastErr error
+	for attempt := 1; attempt <= maxChunkAttempts; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPut, partURL, bytes.NewReader(part))
+		if err != nil {
+			return "", err
+		}
+		resp, err := b.do(req)
+		if err == nil {
+			resp.Body.Close()
+			return resp.Header.Get("ETag"), nil
+		}
+		lastErr = err
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		slog.Warn("S3 part upload failed", "part", partNumber, "attempt", attempt, "err", err)
+		time.Sleep(time.Duration(attempt) * time.Second)
+	}
+	return "", fmt.Errorf("part %d failed after %d attempts: %w", partNumber, maxChunkAttempts, lastErr)
+}
+
+func (b *s3Backend) completeMultipartUpload(ctx context.Context, key string, uploadID string, parts []completedPart) error {
+	body, err := xml.Marshal(struct {
+		XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+		Parts   []completedPart `xml:"Part"`
+	}{Parts: parts})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.objectURL(key)+"?uploadId="+url.QueryEscape(uploadID), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	resp, err := b.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// S3 may report a failure with a 200 status once it has started to respond
+	var result struct {
+		XMLName xml.Name
+		Message string `xml:"Message"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode complete multipart upload: %w", err)
+	}
+	if result.XMLName.Local == "Error" {
+		return fmt.Errorf("failed to complete multipart upload: %s", result.Message)
+	}
+	return nil
+}
+
+func (b *s3Backend) abortMultipartUpload(ctx context.Context, key string, uploadID string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, b.objectURL(key)+"?uploadId="+url.QueryEscape(uploadID), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := b.do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// do signs and sends req, any status other than 2xx is returned as an error
+func (b *s3Backend) do(req *http.Request) (*http.Response, error) {
+	b.sign(req, time.Now())
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		return nil, fmt.Errorf("%s %s: %s", req.Method, req.URL.Path, readError(resp))
+	}
+	return resp, nil
+}
+
+func (b *s3Backend) objectURL(key string) string {
+	return fmt.Sprintf("%s/%s/%s", b.cfg.Endpoint, awsURIEncode(b.cfg.Bucket, true), awsURIEncode(key, false))
+}
+
+// sign adds an Authorization header to req, all headers set on req are signed
+func (b *s3Backend) sign(req *http.Request, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	if req.Header.Get("x-amz-content-sha256") == "" {
+		req.Header.Set("x-amz-content-sha256", unsignedPayload)
+	}
+	req.Header.Set("x-amz-date", amzDate)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		headers[strings.ToLower(k)] = strings.TrimSpace(strings.Join(v, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, k := range names {
+		canonicalHeaders.WriteString(k + ":" + headers[k] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		req.Header.Get("x-amz-content-sha256"),
+	}, "\n")
+	scope, signature := b.signature(amzDate, canonicalRequest)
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		b.cfg.AccessKey, scope, signedHeaders, signature))
+}
+
+// presign returns rawURL with a query string signature valid for expiry
+func (b *s3Backend) presign(method string, rawURL string, now time.Time, expiry time.Duration) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	amzDate := now.UTC().Format("20060102T150405Z")
+	query := u.Query()
+	query.Set("X-Amz-Algorithm", "AWS4-HMAC-SHA256")
+	query.Set("X-Amz-Credential", fmt.Sprintf("%s/%s", b.cfg.AccessKey, b.scope(amzDate)))
+	query.Set("X-Amz-Date", amzDate)
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expiry.Seconds())))
+	query.Set("X-Amz-SignedHeaders", "host")
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		u.EscapedPath(),
+		canonicalQuery(query),
+		"host:" + u.Host + "\n",
+		"host",
+		unsignedPayload,
+	}, "\n")
+	_, signature := b.signature(amzDate, canonicalRequest)
+	u.RawQuery = canonicalQuery(query) + "&X-Amz-Signature=" + signature
+	return u.String(), nil
+}
+
+func (b *s3Backend) scope(amzDate string) string {
+	return fmt.Sprintf("%s/%s/s3/aws4_request", amzDate[:8], b.cfg.Region)
+}
+
+func (b *s3Backend) signature(amzDate string, canonicalRequest string) (string, string) {
+	scope := b.scope(amzDate)
+	hashed := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hex.EncodeToString(hashed[:])}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+b.cfg.SecretKey), amzDate[:8])
+	key = hmacSHA256(key, b.cfg.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return scope, hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		values := query[k]
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, awsURIEncode(k, true)+"="+awsURIEncode(v, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// awsURIEncode percent encodes everything except the RFC 3986 unreserved characters
+func awsURIEncode(s string, encodeSlash bool) string {
+	var sb strings.Builder
+	for _, c := range []byte(s) {
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			sb.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			sb.WriteByte(c)
+		default:
+			fmt.Fprintf(&sb, "%%%02X", c)
+		}
+	}
+	return sb.String()
+}
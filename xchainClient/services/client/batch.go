@@ -0,0 +1,299 @@
+package client
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/FIL-Builders/xchainClient/config"
+	"github.com/FIL-Builders/xchainClient/logging"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/urfave/cli/v2"
+)
+
+// batchColumns are the fields of a batch manifest row, in offer-car argument order
+var batchColumns = []string{"commP", "size", "cid", "location", "token", "amount"}
+
+// BatchRow is one offer of a batch manifest
+type BatchRow struct {
+	CommP    string `json:"commP"`
+	Size     string `json:"size"`
+	Cid      string `json:"cid"`
+	Location string `json:"location"`
+	Token    string `json:"token"`
+	Amount   string `json:"amount"`
+}
+
+// BatchResult is the outcome of offering a BatchRow, Row counts from 1
+type BatchResult struct {
+	Row int `json:"row"`
+	BatchRow
+	TxHash  string `json:"txHash,omitempty"`
+	OfferID uint64 `json:"offerId,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+func OfferBatchAction(cctx *cli.Context) error {
+	if cctx.Args().Len() != 1 {
+		return fmt.Errorf("Usage: <manifest.csv|manifest.json>")
+	}
+	manifestPath := cctx.Args().First()
+	rows, err := ReadBatchManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+	resultsPath := cctx.String("results")
+	if resultsPath == "" {
+		ext := filepath.Ext(manifestPath)
+		resultsPath = strings.TrimSuffix(manifestPath, ext) + ".results" + ext
+	}
+
+	cfg, err := config.LoadConfig(cctx.String("config"))
+	if err != nil {
+		return fmt.Errorf("failed to load config: %v", err)
+	}
+	chainName := cctx.String("chain")
+	srcCfg, err := config.GetSourceConfig(cfg, chainName)
+	if err != nil {
+		return fmt.Errorf("invalid chain name '%s': %v", chainName, err)
+	}
+	o, err := newOfferer(cfg, srcCfg, nil)
+	if err != nil {
+		return err
+	}
+
+	results := o.offerBatch(cctx.Context, o.payments(cctx.Bool("base-units")), rows, cctx.Int("parallel"))
+	if err := WriteBatchResults(resultsPath, results); err != nil {
+		return err
+	}
+	failed := 0
+	for _, r := range results {
+		if r.Error != "" {
+			failed++
+		}
+	}
+	slog.Info("Offered batch", "offered", len(results)-failed, "rows", len(results), "results", resultsPath)
+	if failed > 0 {
+		return fmt.Errorf("%d of %d offers failed, see %s", failed, len(results), resultsPath)
+	}
+	return nil
+}
+
+// offerBatch sends an offerData transaction for every row without waiting for
+// the previous one to be mined. Nonces are assigned locally. A failed send may
+// still have broadcast its transaction, so the next nonce is read again from the
+// pending state after any error instead of being reused.
+// Up to parallel transactions are awaited at the same time.
+func (o *offerer) offerBatch(ctx context.Context, pay *payments, rows []BatchRow, parallel int) []BatchResult {
+	if parallel < 1 {
+		parallel = 1
+	}
+	results := make([]BatchResult, len(rows))
+	for i, row := range rows {
+		results[i] = BatchResult{Row: i + 1, BatchRow: row}
+	}
+
+	fail := func(err error) []BatchResult {
+		for i := range results {
+			if results[i].Error == "" {
+				results[i].Error = err.Error()
+			}
+		}
+		return results
+	}
+
+	// Build every offer up front so all payments can be approved at once
+	offers := make([]*Offer, len(rows))
+	totals := make(map[common.Address]*big.Int)
+	for i, row := range rows {
+		payment, err := pay.parse(ctx, row.Token, row.Amount)
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		offers[i], err = MakeOffer(row.CommP, row.Size, row.Cid, row.Location, payment)
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		addTotal(totals, payment)
+	}
+	if err := pay.ensureFunds(ctx, totals); err != nil {
+		return fail(err)
+	}
+
+	// Approvals use nonces too, so the first offer nonce is only read after them
+	nonce, err := o.client.PendingNonceAt(ctx, o.auth.From)
+	if err != nil {
+		return fail(fmt.Errorf("failed to get nonce: %v", err))
+	}
+
+	var wg sync.WaitGroup
+	var stopErr error
+	sem := make(chan struct{}, parallel)
+	for i, offer := range offers {
+		if offer == nil {
+			continue
+		}
+
+		// Limit the number of transactions in flight
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Error = ctx.Err().Error()
+			continue
+		}
+		tx, err := o.submit(ctx, offer, new(big.Int).SetUint64(nonce))
+		if err != nil {
+			<-sem
+			results[i].Error = err.Error()
+			next, nerr := o.client.PendingNonceAt(ctx, o.auth.From)
+			if nerr != nil {
+				stopErr = fmt.Errorf("failed to get nonce after row %d failed: %v", i+1, nerr)
+				break
+			}
+			if next > nonce {
+				results[i].Error += fmt.Sprintf(" (nonce %d was used, the offer may still be made)", nonce)
+				nonce = next
+			}
+			continue
+		}
+		nonce++
+		results[i].TxHash = tx.Hash().Hex()
+		slog.Info("Row sent", "row", i+1, "tx", tx.Hash().Hex(), "nonce", tx.Nonce())
+
+		wg.Add(1)
+		go func(result *BatchResult, tx *types.Transaction) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			offerID, err := o.confirm(ctx, tx)
+			if err != nil {
+				result.Error = err.Error()
+				return
+			}
+			result.OfferID = offerID
+			slog.Info("Row offered", "row", result.Row, logging.OfferID, offerID)
+		}(&results[i], tx)
+	}
+	wg.Wait()
+	if stopErr != nil {
+		for i := range results {
+			if offers[i] != nil && results[i].TxHash == "" && results[i].Error == "" {
+				results[i].Error = stopErr.Error()
+			}
+		}
+	}
+	return results
+}
+
+// ReadBatchManifest reads offers from a JSON array of rows or a CSV file
+// with a header naming the columns commP, size, cid, location, token and amount
+func ReadBatchManifest(path string) ([]BatchRow, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var rows []BatchRow
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		if err := json.NewDecoder(f).Decode(&rows); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	case ".csv":
+		rows, err = readBatchCSV(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported manifest %s, expected a .csv or .json file", path)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("manifest %s has no rows", path)
+	}
+	return rows, nil
+}
+
+func readBatchCSV(r io.Reader) ([]BatchRow, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, column := range batchColumns {
+		if _, ok := index[strings.ToLower(column)]; !ok {
+			return nil, fmt.Errorf("missing column %q", column)
+		}
+	}
+
+	var rows []BatchRow
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		field := func(column string) string {
+			return strings.TrimSpace(record[index[strings.ToLower(column)]])
+		}
+		rows = append(rows, BatchRow{
+			CommP:    field("commP"),
+			Size:     field("size"),
+			Cid:      field("cid"),
+			Location: field("location"),
+			Token:    field("token"),
+			Amount:   field("amount"),
+		})
+	}
+}
+
+// WriteBatchResults writes results as JSON or CSV depending on the extension of path
+func WriteBatchResults(path string, results []BatchResult) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		return enc.Encode(results)
+	}
+
+	w := csv.NewWriter(f)
+	header := append([]string{"row"}, batchColumns...)
+	if err := w.Write(append(header, "txHash", "offerId", "error")); err != nil {
+		return err
+	}
+	for _, r := range results {
+		offerID := ""
+		if r.OfferID != 0 {
+			offerID = strconv.FormatUint(r.OfferID, 10)
+		}
+		record := []string{strconv.Itoa(r.Row), r.CommP, r.Size, r.Cid, r.Location, r.Token, r.Amount, r.TxHash, offerID, r.Error}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadBatchManifest(t *testing.T) {
+	dir := t.TempDir()
+	want := []BatchRow{
+		{CommP: "baga1", Size: "2048", Cid: "bafy1", Location: "http://buffer/get?id=1", Token: "0x01", Amount: "10"},
+		{CommP: "baga2", Size: "4096", Cid: "bafy2", Location: "http://buffer/get?id=2", Token: "0x02", Amount: "0"},
+	}
+
+	// Columns may come in any order
+	csvPath := filepath.Join(dir, "offers.csv")
+	require.NoError(t, os.WriteFile(csvPath, []byte(
+		"size,commP,cid,location,token,amount\n"+
+			"2048,baga1,bafy1,http://buffer/get?id=1,0x01,10\n"+
+			"4096, baga2,bafy2,http://buffer/get?id=2,0x02,0\n"), 0644))
+	rows, err := ReadBatchManifest(csvPath)
+	require.NoError(t, err)
+	require.Equal(t, want, rows)
+
+	jsonPath := filepath.Join(dir, "offers.json")
+	require.NoError(t, os.WriteFile(jsonPath, []byte(`[
+		{"commP": "baga1", "size": "2048", "cid": "bafy1", "location": "http://buffer/get?id=1", "token": "0x01", "amount": "10"},
+		{"commP": "baga2", "size": "4096", "cid": "bafy2", "location": "http://buffer/get?id=2", "token": "0x02", "amount": "0"}
+	]`), 0644))
+	rows, err = ReadBatchManifest(jsonPath)
+	require.NoError(t, err)
+	require.Equal(t, want, rows)
+
+	missing := filepath.Join(dir, "missing.csv")
+	require.NoError(t, os.WriteFile(missing, []byte("commP,size\nbaga1,2048\n"), 0644))
+	_, err = ReadBatchManifest(missing)
+	require.ErrorContains(t, err, "missing column")
+}
+
+func TestWriteBatchResults(t *testing.T) {
+	results := []BatchResult{
+		{Row: 1, BatchRow: BatchRow{CommP: "baga1"}, TxHash: "0xaa", OfferID: 7},
+		{Row: 2, BatchRow: BatchRow{CommP: "baga2"}, Error: "failed to parse cid"},
+	}
+	csvPath := filepath.Join(t.TempDir(), "offers.results.csv")
+	require.NoError(t, WriteBatchResults(csvPath, results))
+
+	f, err := os.Open(csvPath)
+	require.NoError(t, err)
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	require.Equal(t, []string{"row", "commP", "size", "cid", "location", "token", "amount", "txHash", "offerId", "error"}, records[0])
+	require.Equal(t, "0xaa", records[1][7])
+	require.Equal(t, "7", records[1][8])
+	require.Equal(t, "failed to parse cid", records[2][9])
+}
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+
+	commcid "github.com/filecoin-project/go-fil-commcid"
+	commp "github.com/filecoin-project/go-fil-commp-hashhash"
+	"github.com/ipfs/go-cid"
+	carv2 "github.com/ipld/go-car/v2"
+	"github.com/ipld/go-car/v2/storage"
+	"github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+)
+
+// Offered CARs are generated, hashed for CommP and uploaded in a single pass
+// over the input. The root of the DAG is only known once all of it has been
+// built, after the CARv1 header has been sent, so the header holds
+// placeholderRoot and the actual root is recorded in the offer and the manifest.
+// Retrievals always start from the root of the offer.
+
+// placeholderRoot is the root written in the header of offered CARs, the
+// identity CID of an empty raw block, which needs no block in the CAR
+var placeholderRoot = cid.MustParse("bafkqaaa")
+
+// writeCar writes a CARv1 holding the UnixFS DAG for src and returns its root
+func writeCar(ctx context.Context, src *dagSource, w io.Writer) (cid.Cid, error) {
+	car, err := storage.NewWritable(w, []cid.Cid{placeholderRoot}, carv2.WriteAsCarV1(true))
+	if err != nil {
+		return cid.Undef, err
+	}
+	ls := cidlink.DefaultLinkSystem()
+	ls.TrustedStorage = true
+	ls.SetWriteStorage(car)
+	writeOpener := ls.StorageWriteOpener
+	ls.StorageWriteOpener = func(lctx ipld.LinkContext) (io.Writer, ipld.BlockWriteCommitter, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		return writeOpener(lctx)
+	}
+
+	root, err := src.build(&ls)
+	if err != nil {
+		return cid.Undef, err
+	}
+	return root, car.Finalize()
+}
+
+// carStream is an io.Reader generating the CAR for a dagSource on the fly and
+// computing its CommP over the bytes read
+type carStream struct {
+	pr      *io.PipeReader
+	cp      *commp.Calc
+	size    uint64 // CAR bytes read so far
+	maxSize uint64 // the CAR fails to stream beyond this size
+	eof     bool
+
+	root cid.Cid // set by the generating goroutine before it closes the pipe
+
+	once       sync.Once
+	commP      string
+	paddedSize uint64
+	digestErr  error
+}
+
+func newCarStream(ctx context.Context, src *dagSource, maxSize uint64) *carStream {
+	pr, pw := io.Pipe()
+	s := &carStream{pr: pr, cp: new(commp.Calc), maxSize: maxSize}
+	go func() {
+		root, err := writeCar(ctx, src, pw)
+		s.root = root
+		pw.CloseWithError(err)
+	}()
+	return s
+}
+
+func (s *carStream) Read(p []byte) (int, error) {
+	n, err := s.pr.Read(p)
+	if n > 0 {
+		if _, werr := s.cp.Write(p[:n]); werr != nil {
+			return n, fmt.Errorf("failed to compute CommP: %w", werr)
+		}
+		s.size += uint64(n)
+		if s.size > s.maxSize {
+			return n, fmt.Errorf("CAR is larger than the %d bytes that fit in a piece, only single files can be split", s.maxSize)
+		}
+	}
+	if err == io.EOF {
+		s.eof = true
+	}
+	return n, err
+}
+
+// Root of the DAG in the CAR, only available once the stream has been read to the end
+func (s *carStream) Root() (cid.Cid, error) {
+	if !s.eof {
+		return cid.Undef, fmt.Errorf("CAR stream has not been read to the end")
+	}
+	return s.root, nil
+}
+
+// CommP of the CAR, only available once the stream has been read to the end
+func (s *carStream) CommP() (string, error) {
+	commP, _, err := s.Digest()
+	return commP, err
+}
+
+// Digest returns the CommP and padded piece size of the CAR once it has been read to the end
+func (s *carStream) Digest() (string, uint64, error) {
+	if !s.eof {
+		return "", 0, fmt.Errorf("CAR stream has not been read to the end")
+	}
+	s.once.Do(func() {
+		rawCommP, paddedSize, err := s.cp.Digest()
+		if err != nil {
+			s.digestErr = err
+			return
+		}
+		commCid, err := commcid.DataCommitmentV1ToCID(rawCommP)
+		if err != nil {
+			s.digestErr = err
+			return
+		}
+		s.commP = commCid.String()
+		s.paddedSize = paddedSize
+		slog.Info("Streamed CAR", "size", s.size, "root", s.root, "commP", s.commP, "paddedSize", s.paddedSize)
+	})
+	return s.commP, s.paddedSize, s.digestErr
+}
+
+// Close stops CAR generation if the stream was not read to the end
+func (s *carStream) Close() error {
+	return s.pr.Close()
+}
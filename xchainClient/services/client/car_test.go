@@ -0,0 +1,175 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-unixfsnode/data"
+	carv2 "github.com/ipld/go-car/v2"
+	dagpb "github.com/ipld/go-codec-dagpb"
+	"github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	"github.com/ipld/go-ipld-prime/storage/memstore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCarStream(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "data.bin")
+	data := bytes.Repeat([]byte("xchain"), 1<<18)
+	require.NoError(t, os.WriteFile(filePath, data, 0644))
+
+	src, err := newDagSource(filePath, SymlinksPreserve)
+	require.NoError(t, err)
+	stream := newCarStream(context.Background(), src, 1<<30)
+	_, err = stream.CommP()
+	require.Error(t, err, "CommP is unknown before the end of the stream")
+	_, err = stream.Root()
+	require.Error(t, err, "root is unknown before the end of the stream")
+
+	carBytes, err := io.ReadAll(stream)
+	require.NoError(t, err)
+	root, err := stream.Root()
+	require.NoError(t, err)
+	commP, paddedSize, err := stream.Digest()
+	require.NoError(t, err)
+	require.NotEmpty(t, commP)
+	require.GreaterOrEqual(t, paddedSize, uint64(len(carBytes)))
+
+	// The streamed CAR is a CARv1 holding the placeholder root and every block of the DAG
+	reader, err := carv2.NewBlockReader(bytes.NewReader(carBytes))
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), reader.Version)
+	require.Equal(t, []cid.Cid{placeholderRoot}, reader.Roots)
+	carPath := filepath.Join(t.TempDir(), "data.car")
+	require.NoError(t, os.WriteFile(carPath, carBytes, 0644))
+	out := filepath.Join(t.TempDir(), "out")
+	require.NoError(t, extractCar(context.Background(), carPath, root, out, nil))
+	extracted, err := os.ReadFile(filepath.Join(out, "data.bin"))
+	require.NoError(t, err)
+	require.Equal(t, data, extracted)
+
+	// Generation is deterministic so an interrupted upload can be resumed
+	again := newCarStream(context.Background(), src, 1<<30)
+	againBytes, err := io.ReadAll(again)
+	require.NoError(t, err)
+	require.Equal(t, carBytes, againBytes)
+	againCommP, err := again.CommP()
+	require.NoError(t, err)
+	require.Equal(t, commP, againCommP)
+	againRoot, err := again.Root()
+	require.NoError(t, err)
+	require.Equal(t, root, againRoot)
+}
+
+func TestCarStreamMaxSize(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "data.bin")
+	require.NoError(t, os.WriteFile(filePath, bytes.Repeat([]byte("xchain"), 1000), 0644))
+	src, err := newDagSource(filePath, SymlinksPreserve)
+	require.NoError(t, err)
+
+	stream := newCarStream(context.Background(), src, 4096)
+	defer stream.Close()
+	_, err = io.ReadAll(stream)
+	require.ErrorContains(t, err, "larger than the 4096 bytes")
+	_, err = stream.CommP()
+	require.Error(t, err)
+}
+
+func TestMakeOfferUsesRootCID(t *testing.T) {
+	const (
+		commP = "baga6ea4seaqao7s73y24kcutaosvacpdjgfe5pw76ooefnyqw4ynr3d2y6x2mpq"
+		root  = "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi"
+	)
+	payment := &Payment{Token: NativeToken, Amount: big.NewInt(0)}
+	offer, err := MakeOffer(commP, "2048", root, "http://localhost:5077/get?id=1", payment)
+	require.NoError(t, err)
+	require.Equal(t, root, offer.Cid)
+
+	// Swapping the CommP and the root CID is caught
+	_, err = MakeOffer(commP, "2048", commP, "http://localhost:5077/get?id=1", payment)
+	require.Error(t, err)
+	_, err = MakeOffer(root, "2048", root, "http://localhost:5077/get?id=1", payment)
+	require.Error(t, err)
+}
+
+// buildDir builds the DAG for pattern into memory and returns the root node's UnixFS data
+func buildDir(t *testing.T, pattern string, symlinks string) (cid.Cid, data.UnixFSData, dagpb.PBNode) {
+	t.Helper()
+	src, err := newDagSource(pattern, symlinks)
+	require.NoError(t, err)
+
+	ls := cidlink.DefaultLinkSystem()
+	store := &memstore.Store{}
+	ls.SetReadStorage(store)
+	ls.SetWriteStorage(store)
+	root, err := src.build(&ls)
+	require.NoError(t, err)
+
+	nd, err := ls.Load(ipld.LinkContext{}, cidlink.Link{Cid: root}, dagpb.Type.PBNode)
+	require.NoError(t, err)
+	pbNode := nd.(dagpb.PBNode)
+	ufsData, err := data.DecodeUnixFSData(pbNode.Data.Must().Bytes())
+	require.NoError(t, err)
+	return root, ufsData, pbNode
+}
+
+func TestDagSourceDirectory(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.log"), []byte("b"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "c.txt"), []byte("c"), 0644))
+	require.NoError(t, os.Symlink("a.txt", filepath.Join(dir, "link")))
+
+	// A directory is the root of the DAG
+	preserved, ufsData, node := buildDir(t, dir, SymlinksPreserve)
+	require.Equal(t, data.Data_Directory, ufsData.FieldDataType().Int())
+	require.Equal(t, int64(4), node.Links.Length())
+
+	followed, _, node := buildDir(t, dir, SymlinksFollow)
+	require.Equal(t, int64(4), node.Links.Length())
+	require.NotEqual(t, preserved, followed)
+
+	_, _, node = buildDir(t, dir, SymlinksSkip)
+	require.Equal(t, int64(3), node.Links.Length())
+
+	// A glob becomes a directory of its matches
+	_, ufsData, node = buildDir(t, filepath.Join(dir, "*.txt"), SymlinksPreserve)
+	require.Equal(t, data.Data_Directory, ufsData.FieldDataType().Int())
+	require.Equal(t, int64(1), node.Links.Length())
+
+	_, err := newDagSource(filepath.Join(dir, "*.none"), SymlinksPreserve)
+	require.Error(t, err)
+	_, err = newDagSource(dir, "copy")
+	require.Error(t, err)
+}
+
+func TestDagSourceSymlinkCycle(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0755))
+	require.NoError(t, os.Symlink("..", filepath.Join(dir, "sub", "up")))
+
+	src, err := newDagSource(dir, SymlinksFollow)
+	require.NoError(t, err)
+	_, err = io.ReadAll(newCarStream(context.Background(), src, 1<<20))
+	require.ErrorContains(t, err, "symlink cycle")
+
+	// Preserved symlinks are not traversed
+	buildDir(t, dir, SymlinksPreserve)
+}
+
+func TestDagSourceShardsLargeDirectories(t *testing.T) {
+	dir := t.TempDir()
+	for i := 0; i < 6000; i++ {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, fmt.Sprintf("file-with-a-long-name-%05d", i)), nil, 0644))
+	}
+	_, ufsData, _ := buildDir(t, dir, SymlinksPreserve)
+	require.Equal(t, data.Data_HAMTShard, ufsData.FieldDataType().Int())
+}
@@ -1,103 +1,51 @@
 package client
 
 import (
-	"bufio"
-	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"log"
+	"log/slog"
 	"math/big"
-	"net/http"
 	"os"
-	"path"
 	"path/filepath"
 	"strconv"
 
 	"github.com/FIL-Builders/xchainClient/config"
+	"github.com/FIL-Builders/xchainClient/contracts"
+	"github.com/FIL-Builders/xchainClient/logging"
+	"github.com/FIL-Builders/xchainClient/services/buffer"
 	"github.com/FIL-Builders/xchainClient/utils"
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/google/uuid"
 	"github.com/ipfs/go-cid"
-	"github.com/ipfs/go-libipfs/blocks"
-	"github.com/ipfs/go-unixfsnode/data/builder"
-	"github.com/ipld/go-car/v2"
-	"github.com/ipld/go-car/v2/blockstore"
-	dagpb "github.com/ipld/go-codec-dagpb"
-	"github.com/ipld/go-ipld-prime"
-	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
-	"github.com/multiformats/go-multicodec"
-	"github.com/multiformats/go-multihash"
 	"github.com/urfave/cli/v2"
-
-	commcid "github.com/filecoin-project/go-fil-commcid"
-	commp "github.com/filecoin-project/go-fil-commp-hashhash"
 )
 
 func OfferFileAction(cctx *cli.Context) error {
-	// Expect exactly 3 arguments: <file_path> <payment-addr> <payment-amount>
+	// Expect exactly 3 arguments: <path> <payment-token> <payment-amount>
 	if cctx.Args().Len() != 3 {
-		return fmt.Errorf("Usage: <file_path> <payment-addr> <payment-amount>")
-	}
-	filePath := cctx.Args().Get(0)
-	paymentAddr := cctx.Args().Get(1)
-	paymentAmount := cctx.Args().Get(2)
-
-	// Create the CAR file name.
-	carFilePath := filePath + ".car"
-	// Remove any existing CAR file.
-	if _, err := os.Stat(carFilePath); err == nil {
-		if err := os.Remove(carFilePath); err != nil {
-			return fmt.Errorf("failed to remove existing CAR file: %v", err)
-		}
+		return fmt.Errorf("Usage: <path> <payment-token> <payment-amount>")
 	}
-
-	// Create the CAR file (using our stub; replace with proper go-car logic as needed).
-	if err := createCarFile(filePath, carFilePath); err != nil {
-		return fmt.Errorf("failed to create CAR file: %v", err)
-	}
-
-	// Compute the CommP and padded piece size.
-	commPStr, paddedSize, err := calcStreamCommp(carFilePath)
+	src, err := newDagSource(cctx.Args().Get(0), cctx.String("symlinks"))
 	if err != nil {
-		return fmt.Errorf("failed to compute CommP: %v", err)
-	}
-	sizeStr := strconv.FormatUint(paddedSize, 10)
-
-	// Data Plane: Upload the CAR file to the local server.
-	carFileBytes, err := os.ReadFile(carFilePath)
-	if err != nil {
-		return fmt.Errorf("failed to read CAR file: %v", err)
-	}
-	urlPut := "http://localhost:5077/put"
-	resp, err := http.Post(urlPut, "application/octet-stream", bytes.NewReader(carFileBytes))
-	if err != nil {
-		return fmt.Errorf("failed to POST CAR file: %v", err)
-	}
-	defer resp.Body.Close()
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read response: %v", err)
-	}
-	var postResult map[string]interface{}
-	if err := json.Unmarshal(bodyBytes, &postResult); err != nil {
-		return fmt.Errorf("failed to parse JSON response: %v", err)
+		return err
 	}
-	var bufferID string
-	if idStr, ok := postResult["id"].(string); ok {
-		bufferID = idStr
-	} else if idNum, ok := postResult["id"].(float64); ok {
-		bufferID = strconv.FormatFloat(idNum, 'f', -1, 64)
-	} else {
-		return fmt.Errorf("failed to extract buffer id from response")
+	resuming := cctx.String("upload-id") != "" || cctx.Int("part") != 0
+	var kr *keyring
+	var srcID string
+	if cctx.Bool("encrypt") {
+		if kr, srcID, err = encryptSource(src, cctx.String("keyring"), resuming); err != nil {
+			return err
+		}
 	}
-	bufferAddr := fmt.Sprintf("http://localhost:5077/get?id=%s", bufferID)
+	paymentToken := cctx.Args().Get(1)
+	paymentAmount := cctx.Args().Get(2)
 
-	// Blockchain: Load configuration and prepare the transaction.
+	// Load configuration and make sure the buffer is reachable before doing any work.
 	cfg, err := config.LoadConfig(cctx.String("config"))
 	if err != nil {
 		return fmt.Errorf("failed to load config: %v", err)
@@ -107,287 +55,417 @@ func OfferFileAction(cctx *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("invalid chain name '%s': %v", chainName, err)
 	}
-	client, err := ethclient.Dial(srcCfg.Api)
+	backend, err := buffer.NewBackend(cctx.String("buffer"), cfg, srcCfg, buffer.BackendOptions{
+		BufferURL: cctx.String("buffer-url"),
+		ChunkSize: cctx.Int("chunk-size"),
+		UploadID:  cctx.String("upload-id"),
+	})
 	if err != nil {
-		return fmt.Errorf("failed to connect to Ethereum client for source chain %s at %s: %v", chainName, srcCfg.Api, err)
+		return err
 	}
-	contractAddress := common.HexToAddress(srcCfg.OnRampAddress)
-	parsedABI, err := utils.LoadAbi(cfg.OnRampABIPath)
+	if err := backend.Check(cctx.Context); err != nil {
+		return err
+	}
+
+	// Inputs that do not fit in a single piece are offered in several parts
+	maxPieceSize, err := resolveMaxPieceSize(cfg, cctx.Uint64("max-piece-size"))
 	if err != nil {
-		return fmt.Errorf("failed to load ABI: %v", err)
+		return err
 	}
-	onramp := bind.NewBoundContract(contractAddress, *parsedABI, client, client, client)
-	auth, err := utils.LoadPrivateKey(cfg, srcCfg.ChainID)
+	parts, err := src.split(maxCarSize(maxPieceSize))
 	if err != nil {
-		return fmt.Errorf("failed to load private key: %v", err)
+		return err
 	}
-
-	// Map parameters for the offer.
-	// Here we use the computed commPStr both as the CommP and (as a placeholder) as the file CID.
-	offerObj, err := MakeOffer(commPStr, sizeStr, commPStr, bufferAddr, paymentAddr, paymentAmount, *parsedABI)
+	first, err := firstPart(cctx.Int("part"), len(parts), cctx.String("upload-id"))
 	if err != nil {
-		return fmt.Errorf("failed to pack offer data params: %v", err)
+		return err
 	}
+	manifestPath := cctx.String("manifest")
+	var manifest *Manifest
+	var offerIDs []uint64
+	if len(parts) > 1 || manifestPath != "" {
+		if manifest, err = newManifest(parts, maxPieceSize); err != nil {
+			return err
+		}
+		if manifestPath == "" {
+			manifestPath = manifest.Name + ".manifest.json"
+		}
+		// The parts offered before the interrupted one are taken from the manifest
+		if first > 0 {
+			if manifest, err = resumeManifest(manifestPath, manifest, first); err != nil {
+				return err
+			}
+			for _, p := range manifest.Parts {
+				offerIDs = append(offerIDs, p.OfferID)
+			}
+		}
+		slog.Info("Offering in parts", "src", src, "parts", len(parts), "from", first+1, "maxPartSize", maxPieceSize, "manifest", manifestPath)
+	}
+	parts = parts[first:]
 
-	// Submit the offer transaction.
-	tx, err := onramp.Transact(auth, "offerData", offerObj)
+	o, err := newOfferer(cfg, srcCfg, backend)
 	if err != nil {
-		return fmt.Errorf("failed to send transaction: %v", err)
+		return err
 	}
-	log.Printf("Waiting for transaction: %s\n", tx.Hash().Hex())
-	receipt, err := bind.WaitMined(cctx.Context, client, tx)
+
+	// Every part pays the full amount, make sure the OnRamp can collect all of them
+	pay := o.payments(cctx.Bool("base-units"))
+	payment, err := pay.parse(cctx.Context, paymentToken, paymentAmount)
 	if err != nil {
-		return fmt.Errorf("failed to wait for tx: %v", err)
+		return err
+	}
+	total := new(big.Int).Mul(payment.Amount, big.NewInt(int64(len(parts))))
+	if err := pay.ensureFunds(cctx.Context, map[common.Address]*big.Int{payment.Token: total}); err != nil {
+		return err
+	}
+
+	for _, part := range parts {
+		result, err := o.offer(cctx.Context, part, maxPieceSize, payment)
+		if err != nil {
+			return fmt.Errorf("failed to offer %s%s: %w", part, resumeHint(part, err), err)
+		}
+		// Offer ids go to stdout so they can be captured by scripts
+		fmt.Println(result.OfferID)
+		offerIDs = append(offerIDs, result.OfferID)
+		if manifest != nil {
+			manifest.add(part, result)
+			if err := manifest.Write(manifestPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	if kr != nil {
+		if err := kr.dropSeed(srcID); err != nil {
+			return err
+		}
+	}
+
+	if cctx.Bool("wait") {
+		return o.waitProven(cctx.Context, offerIDs, cctx.Duration("poll-interval"))
 	}
-	log.Printf("Tx %s included: %d", tx.Hash().Hex(), receipt.Status)
 	return nil
 }
 
-func OfferCarAction(cctx *cli.Context) error {
-	cfg, err := config.LoadConfig(cctx.String("config"))
-	if err != nil {
-		log.Fatal(err)
+// firstPart returns the index of the first part to offer when resuming a split
+// file at part, counted from 1, or 0 when not resuming
+func firstPart(part int, count int, uploadID string) (int, error) {
+	switch {
+	case part == 0 && uploadID != "" && count > 1:
+		return 0, fmt.Errorf("the input is split into %d parts, give the --part the upload session belongs to with --upload-id", count)
+	case part == 0:
+		return 0, nil
+	case count == 1:
+		return 0, fmt.Errorf("--part can only be used when the input is split, it fits in a single part")
+	case part < 1 || part > count:
+		return 0, fmt.Errorf("--part must be between 1 and %d", count)
+	}
+	return part - 1, nil
+}
+
+// resumeHint tells how to continue an interrupted upload of src, if err is one
+func resumeHint(src *dagSource, err error) string {
+	var uerr *buffer.UploadError
+	if !errors.As(err, &uerr) {
+		return ""
+	}
+	if src.part != nil {
+		return fmt.Sprintf(", resume with --part %d --upload-id %s", src.part.index+1, uerr.ID)
 	}
+	return fmt.Sprintf(", resume with --upload-id %s", uerr.ID)
+}
 
-	// Get chain name
-	chainName := cctx.String("chain")
-	srcCfg, err := config.GetSourceConfig(cfg, chainName)
+// encryptSource encrypts the files of src with the default key of the keyring
+// at keyringPath. The salt seed of the offer is kept in the keyring under the
+// returned source id until it is dropped, a resumed upload reuses it.
+func encryptSource(src *dagSource, keyringPath string, resume bool) (*keyring, string, error) {
+	kr, err := openKeyring(keyringPath)
+	if err != nil {
+		return nil, "", err
+	}
+	key, err := kr.defaultKey()
+	if err != nil {
+		return nil, "", err
+	}
+	srcID, err := src.id()
+	if err != nil {
+		return nil, "", err
+	}
+	seed, err := kr.offerSeed(srcID, resume)
 	if err != nil {
-		log.Fatalf("Invalid chain name '%s': %v", chainName, err)
+		return nil, "", err
 	}
+	src.enc = newEncryptor(key, seed)
+	slog.Info("Encrypting", "src", src, "key", key.id)
+	return kr, srcID, nil
+}
+
+// OfferResult is what a single offer made by offer-file ended up as
+type OfferResult struct {
+	RootCID   string `json:"rootCid"`
+	CommP     string `json:"commP"`
+	PieceSize uint64 `json:"pieceSize"`
+	Location  string `json:"location"`
+	TxHash    string `json:"txHash"`
+	OfferID   uint64 `json:"offerId"`
+	KeyID     string `json:"keyId,omitempty"` // keyring key the files were encrypted with
+}
+
+// onrampConn is a read only connection to the source chain OnRamp
+type onrampConn struct {
+	client     *ethclient.Client
+	onramp     *contracts.OnRamp
+	onrampAddr common.Address
+	decimals   map[common.Address]uint8 // cached token decimals
+}
 
-	// Dial network
+func dialOnRamp(srcCfg *config.SourceChainConfig) (*onrampConn, error) {
 	client, err := ethclient.Dial(srcCfg.Api)
 	if err != nil {
-		log.Fatalf("failed to connect to Ethereum client for source chain %s at %s: %v", chainName, srcCfg.Api, err)
+		return nil, fmt.Errorf("failed to connect to Ethereum client at %s: %v", srcCfg.Api, err)
 	}
-
-	// Load onramp contract handle
 	contractAddress := common.HexToAddress(srcCfg.OnRampAddress)
-	parsedABI, err := utils.LoadAbi(cfg.OnRampABIPath)
+	onramp, err := contracts.NewOnRamp(contractAddress, client)
 	if err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("failed to bind OnRamp: %v", err)
 	}
-	onramp := bind.NewBoundContract(contractAddress, *parsedABI, client, client, client)
+	return &onrampConn{
+		client:     client,
+		onramp:     onramp,
+		onrampAddr: contractAddress,
+		decimals:   make(map[common.Address]uint8),
+	}, nil
+}
+
+// offerer buffers CARs and offers them on the source chain OnRamp
+type offerer struct {
+	*onrampConn
+	auth    *bind.TransactOpts
+	backend buffer.Backend
+}
+
+func newOfferer(cfg *config.Config, srcCfg *config.SourceChainConfig, backend buffer.Backend) (*offerer, error) {
+	conn, err := dialOnRamp(srcCfg)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-
-	// Get auth
 	auth, err := utils.LoadPrivateKey(cfg, srcCfg.ChainID)
 	if err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("failed to load private key: %v", err)
 	}
+	return &offerer{
+		onrampConn: conn,
+		auth:       auth,
+		backend:    backend,
+	}, nil
+}
 
-	// Send Tx
-	offer, err := MakeOffer(
-		cctx.Args().First(),
-		cctx.Args().Get(1),
-		cctx.Args().Get(2),
-		cctx.Args().Get(3),
-		cctx.Args().Get(4),
-		cctx.Args().Get(5),
-		*parsedABI,
-	)
+// offer streams the CAR for src into the buffer and submits an offer for it
+func (o *offerer) offer(ctx context.Context, src *dagSource, maxPieceSize uint64, payment *Payment) (*OfferResult, error) {
+	// Generate the CAR and stream it through CommP calculation straight into the
+	// buffer. Its root is only known at the end, the CAR is named after the upload.
+	carStream := newCarStream(ctx, src, maxCarSize(maxPieceSize))
+	defer carStream.Close()
 
+	// Data Plane: Upload the CAR file to the selected buffer.
+	bufferAddr, err := o.backend.Put(ctx, uuid.New().String()+".car", carStream, carStream.CommP)
 	if err != nil {
-		log.Fatalf("failed to pack offer data params: %v", err)
+		return nil, err
 	}
-	tx, err := onramp.Transact(auth, "offerData", offer)
+	slog.Info("CAR file buffered", "location", bufferAddr)
+
+	// The CAR has been read to the end by the upload so its root and CommP are now known
+	rootCID, err := carStream.Root()
 	if err != nil {
-		log.Fatalf("failed to send tx: %v", err)
+		return nil, err
 	}
-
-	log.Printf("Waiting for transaction: %s\n", tx.Hash().Hex())
-	receipt, err := bind.WaitMined(cctx.Context, client, tx)
+	commPStr, paddedSize, err := carStream.Digest()
 	if err != nil {
-		log.Fatalf("failed to wait for tx: %v", err)
+		return nil, fmt.Errorf("failed to compute CommP: %v", err)
 	}
-	log.Printf("Tx %s included: %d", tx.Hash().Hex(), receipt.Status)
-
-	return nil
-}
+	sizeStr := strconv.FormatUint(paddedSize, 10)
 
-func createCarFile(inputPath, outputPath string) error {
-	ctx := context.Background()
+	// Map parameters for the offer
+	offerObj, err := MakeOffer(commPStr, sizeStr, rootCID.String(), bufferAddr, payment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack offer data params: %v", err)
+	}
 
-	// Create a placeholder CID for initialization
-	hasher, err := multihash.GetHasher(multihash.SHA2_256)
+	// Submit the offer transaction.
+	tx, err := o.submit(ctx, offerObj, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	digest := hasher.Sum([]byte{})
-	hash, err := multihash.Encode(digest, multihash.SHA2_256)
+	offerID, err := o.confirm(ctx, tx)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	proxyRoot := cid.NewCidV1(uint64(multicodec.DagPb), hash)
-
-	// Open CAR file for writing
-	options := []car.Option{blockstore.WriteAsCarV1(true)}
+	slog.Info("Offer created", logging.OfferID, offerID, "src", src)
 
-	// Open CAR file for writing
-	bs, err := blockstore.OpenReadWrite(outputPath, []cid.Cid{proxyRoot}, options...)
+	result := &OfferResult{
+		RootCID:   rootCID.String(),
+		CommP:     commPStr,
+		PieceSize: paddedSize,
+		Location:  bufferAddr,
+		TxHash:    tx.Hash().Hex(),
+		OfferID:   offerID,
+	}
+	if src.enc != nil {
+		result.KeyID = src.enc.key.id.String()
+	}
+	return result, nil
+}
 
+// submit sends an offerData transaction, nonce is taken from the pending state when nil
+func (o *offerer) submit(ctx context.Context, offer *Offer, nonce *big.Int) (*types.Transaction, error) {
+	opts := *o.auth
+	opts.Context = ctx
+	opts.Nonce = nonce
+	// Native payments are sent along with the offer, tokens are collected by the OnRamp
+	if offer.Token == NativeToken {
+		opts.Value = offer.Amount
+	}
+	tx, err := o.onramp.OfferData(&opts, *offer)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("failed to send transaction: %v", err)
 	}
-	defer bs.Finalize()
+	return tx, nil
+}
 
-	// Write UnixFS DAG
-	rootCID, err := writeFileToCar(ctx, bs, inputPath)
+// confirm waits for an offerData transaction to be mined and returns the id of the new offer
+func (o *offerer) confirm(ctx context.Context, tx *types.Transaction) (uint64, error) {
+	slog.Info("Waiting for transaction", "tx", tx.Hash().Hex())
+	receipt, err := bind.WaitMined(ctx, o.client, tx)
 	if err != nil {
-		return err
+		return 0, fmt.Errorf("failed to wait for tx: %v", err)
 	}
-	fmt.Println("CID ", rootCID)
-
-	// Replace root with actual rootCID
-	return car.ReplaceRootsInFile(outputPath, []cid.Cid{rootCID})
+	slog.Info("Transaction included", "tx", tx.Hash().Hex(), "status", receipt.Status)
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return 0, fmt.Errorf("offer transaction %s reverted", tx.Hash().Hex())
+	}
+	return offerIDFromReceipt(receipt, &o.onramp.OnRampFilterer, o.onrampAddr)
 }
 
-func writeFileToCar(ctx context.Context, bs *blockstore.ReadWrite, filePath string) (cid.Cid, error) {
-	ls := cidlink.DefaultLinkSystem()
-	ls.TrustedStorage = true
+// dataReadyTopic is the topic of the DataReady events emitted for new offers
+var dataReadyTopic = func() common.Hash {
+	parsed, err := contracts.OnRampMetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+	return parsed.Events["DataReady"].ID
+}()
 
-	ls.StorageReadOpener = func(_ ipld.LinkContext, l ipld.Link) (io.Reader, error) {
-		cl, ok := l.(cidlink.Link)
-		if !ok {
-			return nil, fmt.Errorf("not a cidlink")
+// offerIDFromReceipt finds the id of the offer created by an offerData transaction
+func offerIDFromReceipt(receipt *types.Receipt, onramp *contracts.OnRampFilterer, onrampAddr common.Address) (uint64, error) {
+	for _, l := range receipt.Logs {
+		if l.Address != onrampAddr || len(l.Topics) == 0 || l.Topics[0] != dataReadyTopic {
+			continue
 		}
-		blk, err := bs.Get(ctx, cl.Cid)
+		dataReady, err := onramp.ParseDataReady(*l)
 		if err != nil {
-			return nil, err
+			return 0, err
 		}
-		return bytes.NewBuffer(blk.RawData()), nil
+		return dataReady.Id, nil
 	}
+	return 0, fmt.Errorf("no DataReady event in transaction %s", receipt.TxHash.Hex())
+}
 
-	ls.StorageWriteOpener = func(_ ipld.LinkContext) (io.Writer, ipld.BlockWriteCommitter, error) {
-		buf := bytes.NewBuffer(nil)
-		return buf, func(l ipld.Link) error {
-			cl, ok := l.(cidlink.Link)
-			if !ok {
-				return fmt.Errorf("not a cidlink")
-			}
-			blk, err := blocks.NewBlockWithCid(buf.Bytes(), cl.Cid)
-			if err != nil {
-				return err
-			}
-			return bs.Put(ctx, blk)
-		}, nil
+func OfferCarAction(cctx *cli.Context) error {
+	if cctx.Args().Len() != 6 {
+		return fmt.Errorf("Usage: <commP> <size> <cid> <bufferLocation> <token-hex> <token-amount>")
 	}
-
-	// Open file and get its actual size
-	fi, err := os.Stat(filePath)
+	cfg, err := config.LoadConfig(cctx.String("config"))
 	if err != nil {
-		return cid.Undef, err
+		return fmt.Errorf("failed to load config: %v", err)
 	}
-	fileSize := fi.Size()
 
-	// Build UnixFS DAG for input file
-	l, _, err := builder.BuildUnixFSRecursive(filePath, &ls)
+	// Get chain name
+	chainName := cctx.String("chain")
+	srcCfg, err := config.GetSourceConfig(cfg, chainName)
 	if err != nil {
-		return cid.Undef, err
+		return fmt.Errorf("invalid chain name '%s': %v", chainName, err)
 	}
-
-	// Ensure size is set correctly
-	entry, err := builder.BuildUnixFSDirectoryEntry(path.Base(filePath), fileSize, l)
-
+	o, err := newOfferer(cfg, srcCfg, nil)
 	if err != nil {
-		return cid.Undef, err
+		return err
 	}
 
-	root, _, err := builder.BuildUnixFSDirectory([]dagpb.PBLink{entry}, &ls)
+	// Make sure the OnRamp can collect the payment
+	pay := o.payments(cctx.Bool("base-units"))
+	payment, err := pay.parse(cctx.Context, cctx.Args().Get(4), cctx.Args().Get(5))
 	if err != nil {
-		return cid.Undef, err
+		return err
 	}
-
-	rcl, ok := root.(cidlink.Link)
-	if !ok {
-		return cid.Undef, fmt.Errorf("could not interpret root as CID link")
+	if err := pay.ensureFunds(cctx.Context, map[common.Address]*big.Int{payment.Token: payment.Amount}); err != nil {
+		return err
 	}
 
-	fmt.Printf("Generated CAR Root CID: %s with File Size: %d\n", rcl.Cid.String(), fileSize)
-
-	return rcl.Cid, nil
-}
-
-const BufSize = ((16 << 20) / 128 * 127)
-
-// calcStreamCommp computes the CommP and padded piece size for a given CAR file.
-func calcStreamCommp(carPath string) (string, uint64, error) {
-	f, err := os.Open(carPath)
+	// Send Tx
+	offer, err := MakeOffer(
+		cctx.Args().First(),
+		cctx.Args().Get(1),
+		cctx.Args().Get(2),
+		cctx.Args().Get(3),
+		payment,
+	)
 	if err != nil {
-		return "", 0, err
+		return fmt.Errorf("failed to pack offer data params: %v", err)
 	}
-	defer f.Close()
-
-	// Create a new CommP calculator
-	cp := new(commp.Calc)
-
-	// Buffered reader to optimize IO performance
-	streamBuf := bufio.NewReaderSize(f, BufSize)
-
-	// Feed data into commp.Calc via io.TeeReader
-	_, err = io.Copy(cp, streamBuf)
+	tx, err := o.submit(cctx.Context, offer, nil)
 	if err != nil {
-		return "", 0, err
+		return err
 	}
-
-	// Compute raw CommP and padded size
-	rawCommP, paddedSize, err := cp.Digest()
+	offerID, err := o.confirm(cctx.Context, tx)
 	if err != nil {
-		return "", 0, err
+		return err
 	}
+	slog.Info("Offer created", logging.OfferID, offerID)
+	fmt.Println(offerID)
 
-	// Convert raw CommP to CID
-	commCid, err := commcid.DataCommitmentV1ToCID(rawCommP)
-	if err != nil {
-		return "", 0, err
+	if cctx.Bool("wait") {
+		return o.waitProven(cctx.Context, []uint64{offerID}, cctx.Duration("poll-interval"))
 	}
-
-	return commCid.String(), paddedSize, nil
+	return nil
 }
 
-func MakeOffer(commpStr string, sizeStr string, cidStr string, location string, token string, amountStr string, abi abi.ABI) (*Offer, error) {
-	log.Printf("MakeOffer called with commpStr: %s, sizeStr: %s, cidStr: %s,  location: %s, token: %s, amountStr: %s\n", commpStr, sizeStr, cidStr, location, token, amountStr)
+func MakeOffer(commpStr string, sizeStr string, cidStr string, location string, payment *Payment) (*Offer, error) {
+	slog.Debug("Making offer", "commP", commpStr, "size", sizeStr, "cid", cidStr, "location", location, "token", payment.Token.Hex(), "amount", payment.Amount)
 
 	commP, err := cid.Decode(commpStr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse cid %w", err)
 	}
-
-	size, err := strconv.Atoi(sizeStr)
+	if commP.Prefix().Codec != cid.FilCommitmentUnsealed {
+		return nil, fmt.Errorf("%s is not a piece commitment", commpStr)
+	}
+	// The root CID lets the data be fetched by content address once it is stored
+	rootCid, err := cid.Decode(cidStr)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to parse root cid %w", err)
+	}
+	if rootCid.Prefix().Codec == cid.FilCommitmentUnsealed {
+		return nil, fmt.Errorf("root cid %s is a piece commitment, expected the CAR root", cidStr)
 	}
-	amount, err := strconv.Atoi(amountStr)
+
+	size, err := strconv.ParseUint(sizeStr, 10, 64)
 	if err != nil {
 		return nil, err
 	}
 
-	amountBig := big.NewInt(0).SetUint64(uint64(amount))
-
 	offer := Offer{
 		CommP:    commP.Bytes(),
 		Location: location,
-		Cid:      cidStr,
-		Token:    common.HexToAddress(token),
-		Amount:   amountBig,
-		Size:     uint64(size),
+		Cid:      rootCid.String(),
+		Token:    payment.Token,
+		Amount:   payment.Amount,
+		Size:     size,
 	}
 
 	return &offer, nil
 }
 
 // Mirror OnRamp.sol's `Offer` struct
-type Offer struct {
-	CommP    []uint8        `json:"commP"`
-	Size     uint64         `json:"size"`
-	Cid      string         `json:"cid"`
-	Location string         `json:"location"`
-	Amount   *big.Int       `json:"amount"`
-	Token    common.Address `json:"token"`
-}
+type Offer = contracts.OnRampContractOffer
 
 // generateEthereumAccount creates a new Ethereum account and saves it to the specified JSON file
 func GenerateEthereumAccount(keystoreFile, password string) (string, error) {
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/FIL-Builders/xchainClient/contracts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOfferIDFromReceipt(t *testing.T) {
+	onrampABI, err := contracts.OnRampMetaData.GetAbi()
+	require.NoError(t, err)
+	onrampAddr := common.HexToAddress("0xeE857540dddB6E6EA10a5c84f57562F11D5Fb47D")
+	onramp, err := contracts.NewOnRampFilterer(onrampAddr, nil)
+	require.NoError(t, err)
+
+	event := onrampABI.Events["DataReady"]
+	data, err := event.Inputs.Pack(Offer{
+		CommP:    []byte{0x01},
+		Size:     2048,
+		Cid:      "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi",
+		Location: "http://localhost:5077/get?id=1",
+		Amount:   big.NewInt(0),
+	}, uint64(42))
+	require.NoError(t, err)
+
+	receipt := &types.Receipt{Logs: []*types.Log{
+		// Logs of other contracts are ignored
+		{Address: common.HexToAddress("0x01"), Topics: []common.Hash{event.ID}, Data: []byte{0xff}},
+		{Address: onrampAddr, Topics: []common.Hash{event.ID}, Data: data},
+	}}
+	offerID, err := offerIDFromReceipt(receipt, onramp, onrampAddr)
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), offerID)
+
+	_, err = offerIDFromReceipt(&types.Receipt{}, onramp, onrampAddr)
+	require.ErrorContains(t, err, "no DataReady event")
+}
+
+func TestOfferStatusString(t *testing.T) {
+	require.Equal(t, "pending", OfferPending.String())
+	require.Equal(t, "proven", OfferProven.String())
+	require.Equal(t, "unknown(7)", OfferStatus(7).String())
+}
@@ -0,0 +1,214 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Encrypted files are an envelope around the plaintext:
+//
+//	magic (8) | key id (8) | salt (32) | chunk | chunk | ...
+//
+// The file key is HMAC-SHA256(keyring key, salt), so every file gets its own
+// key while only the keyring key has to be kept. The plaintext is sealed with
+// AES-256-GCM in chunks of encChunkSize. The nonce of a chunk is its counter,
+// with the last byte set on the final chunk so that a truncated file does not
+// decrypt. The header is authenticated as additional data of every chunk.
+//
+// Salts are derived from a random seed per offer-file run and the name of the
+// file, HMAC-SHA256(seed, name). The seed is kept in the keyring until the offers
+// are made, so that a resumed upload encrypts every file the same way again.
+const (
+	encMagic     = "xchainE1"
+	encSaltSize  = 32
+	encSeedSize  = 32
+	encHeaderLen = len(encMagic) + keyIDSize + encSaltSize
+	encChunkSize = 64 << 10
+)
+
+var errNotEncrypted = errors.New("not an encrypted file")
+
+// encryptor encrypts the files of a dagSource
+type encryptor struct {
+	key  *keyringKey
+	seed []byte
+}
+
+func newEncryptor(key *keyringKey, seed []byte) *encryptor {
+	return &encryptor{key: key, seed: seed}
+}
+
+// newSeed returns a random seed for the salts of an encryptor
+func newSeed() ([]byte, error) {
+	seed := make([]byte, encSeedSize)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, err
+	}
+	return seed, nil
+}
+
+// reader encrypts r, name identifies the plaintext among the files of the offer
+func (e *encryptor) reader(name string, r io.Reader) (io.Reader, error) {
+	mac := hmac.New(sha256.New, e.seed)
+	mac.Write([]byte(name))
+	return newEncryptReader(e.key, mac.Sum(nil), r)
+}
+
+// encryptedSize is the size of the envelope of a plaintext of n bytes
+func encryptedSize(n int64) int64 {
+	chunks := (n + encChunkSize - 1) / encChunkSize
+	if chunks == 0 {
+		chunks = 1 // an empty plaintext still has a final chunk
+	}
+	return int64(encHeaderLen) + n + chunks*16
+}
+
+func fileCipher(key *keyringKey, salt []byte) (cipher.AEAD, error) {
+	mac := hmac.New(sha256.New, key.secret)
+	mac.Write(salt)
+	block, err := aes.NewCipher(mac.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func chunkNonce(counter uint64, last bool) []byte {
+	nonce := make([]byte, 12)
+	binary.BigEndian.PutUint64(nonce[3:11], counter)
+	if last {
+		nonce[11] = 1
+	}
+	return nonce
+}
+
+type encryptReader struct {
+	aead    cipher.AEAD
+	header  []byte
+	src     *bufio.Reader
+	buf     []byte
+	out     []byte // sealed data not read yet
+	counter uint64
+	done    bool
+}
+
+func newEncryptReader(key *keyringKey, salt []byte, r io.Reader) (*encryptReader, error) {
+	aead, err := fileCipher(key, salt)
+	if err != nil {
+		return nil, err
+	}
+	header := make([]byte, 0, encHeaderLen)
+	header = append(header, encMagic...)
+	header = append(header, key.id[:]...)
+	header = append(header, salt...)
+	return &encryptReader{
+		aead:   aead,
+		header: header,
+		src:    bufio.NewReaderSize(r, encChunkSize),
+		buf:    make([]byte, encChunkSize),
+		out:    header,
+	}, nil
+}
+
+func (e *encryptReader) Read(p []byte) (int, error) {
+	for len(e.out) == 0 {
+		if e.done {
+			return 0, io.EOF
+		}
+		n, err := io.ReadFull(e.src, e.buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return 0, err
+		}
+		// A chunk is the last one when no byte follows it
+		last := err != nil
+		if !last {
+			if _, err := e.src.Peek(1); err == io.EOF {
+				last = true
+			} else if err != nil {
+				return 0, err
+			}
+		}
+		e.out = e.aead.Seal(e.buf[:0:0], chunkNonce(e.counter, last), e.buf[:n], e.header)
+		e.counter++
+		e.done = last
+	}
+	n := copy(p, e.out)
+	e.out = e.out[n:]
+	return n, nil
+}
+
+// decryptReader opens the envelope written by encryptReader
+type decryptReader struct {
+	aead    cipher.AEAD
+	header  []byte
+	src     *bufio.Reader
+	buf     []byte
+	out     []byte
+	counter uint64
+	done    bool
+}
+
+// newDecryptReader reads the header of an envelope and finds its key in the keyring
+func newDecryptReader(kr *keyring, r io.Reader) (*decryptReader, error) {
+	src := bufio.NewReaderSize(r, encChunkSize+16)
+	header := make([]byte, encHeaderLen)
+	if _, err := io.ReadFull(src, header); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, errNotEncrypted
+		}
+		return nil, err
+	}
+	if !bytes.Equal(header[:len(encMagic)], []byte(encMagic)) {
+		return nil, errNotEncrypted
+	}
+	var id keyID
+	copy(id[:], header[len(encMagic):])
+	key, err := kr.get(id)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := fileCipher(key, header[len(encMagic)+keyIDSize:])
+	if err != nil {
+		return nil, err
+	}
+	return &decryptReader{aead: aead, header: header, src: src, buf: make([]byte, encChunkSize+16)}, nil
+}
+
+func (d *decryptReader) Read(p []byte) (int, error) {
+	for len(d.out) == 0 {
+		if d.done {
+			return 0, io.EOF
+		}
+		n, err := io.ReadFull(d.src, d.buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return 0, err
+		}
+		last := err != nil
+		if !last {
+			if _, err := d.src.Peek(1); err == io.EOF {
+				last = true
+			} else if err != nil {
+				return 0, err
+			}
+		}
+		out, err := d.aead.Open(d.buf[:0:0], chunkNonce(d.counter, last), d.buf[:n], d.header)
+		if err != nil {
+			return 0, fmt.Errorf("failed to decrypt chunk %d: %w", d.counter, err)
+		}
+		d.out = out
+		d.counter++
+		d.done = last
+	}
+	n := copy(p, d.out)
+	d.out = d.out[n:]
+	return n, nil
+}
@@ -0,0 +1,266 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/FIL-Builders/xchainClient/config"
+	"github.com/FIL-Builders/xchainClient/services/buffer"
+	"github.com/stretchr/testify/require"
+)
+
+func testKeyring(t *testing.T) (*keyring, *keyringKey) {
+	kr, err := openKeyring(filepath.Join(t.TempDir(), "keyring.json"))
+	require.NoError(t, err)
+	key, err := kr.defaultKey()
+	require.NoError(t, err)
+	return kr, key
+}
+
+func testEncryptor(t *testing.T, key *keyringKey) *encryptor {
+	seed, err := newSeed()
+	require.NoError(t, err)
+	return newEncryptor(key, seed)
+}
+
+func encrypt(t *testing.T, key *keyringKey, plaintext []byte) []byte {
+	r, err := testEncryptor(t, key).reader("test", bytes.NewReader(plaintext))
+	require.NoError(t, err)
+	ciphertext, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return ciphertext
+}
+
+func decrypt(kr *keyring, ciphertext []byte) ([]byte, error) {
+	r, err := newDecryptReader(kr, bytes.NewReader(ciphertext))
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+func TestEncryptRoundTrip(t *testing.T) {
+	kr, key := testKeyring(t)
+	for _, n := range []int{0, 1, encChunkSize - 1, encChunkSize, encChunkSize + 1, 3 * encChunkSize} {
+		plaintext := bytes.Repeat([]byte{0x5a}, n)
+		ciphertext := encrypt(t, key, plaintext)
+		require.Equal(t, encryptedSize(int64(n)), int64(len(ciphertext)), "size %d", n)
+
+		decrypted, err := decrypt(kr, ciphertext)
+		require.NoError(t, err, "size %d", n)
+		require.Equal(t, plaintext, decrypted)
+	}
+
+	// Every file gets its own salt and so its own key
+	require.NotEqual(t, encrypt(t, key, []byte("same")), encrypt(t, key, []byte("same")))
+}
+
+func TestDecryptDetectsTampering(t *testing.T) {
+	kr, key := testKeyring(t)
+	ciphertext := encrypt(t, key, bytes.Repeat([]byte("xchain"), encChunkSize))
+
+	flipped := bytes.Clone(ciphertext)
+	flipped[encHeaderLen+10] ^= 1
+	_, err := decrypt(kr, flipped)
+	require.Error(t, err)
+
+	// Dropping whole chunks leaves a file without its final chunk
+	_, err = decrypt(kr, ciphertext[:encHeaderLen+2*(encChunkSize+16)])
+	require.Error(t, err)
+
+	// The salt is authenticated with every chunk
+	salted := bytes.Clone(ciphertext)
+	salted[encHeaderLen-1] ^= 1
+	_, err = decrypt(kr, salted)
+	require.Error(t, err)
+
+	other, _ := testKeyring(t)
+	_, err = decrypt(other, ciphertext)
+	require.ErrorContains(t, err, "is not in keyring")
+
+	_, err = decrypt(kr, []byte("plain text file"))
+	require.ErrorIs(t, err, errNotEncrypted)
+}
+
+func TestKeyringDefaultKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "xchain", "keyring.json")
+	kr, err := openKeyring(path)
+	require.NoError(t, err)
+	key, err := kr.defaultKey()
+	require.NoError(t, err)
+
+	fi, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+
+	// The key is kept for later runs
+	reopened, err := openKeyring(path)
+	require.NoError(t, err)
+	again, err := reopened.defaultKey()
+	require.NoError(t, err)
+	require.Equal(t, key.id, again.id)
+	require.Equal(t, key.secret, again.secret)
+}
+
+func TestExtractEncryptedDirectory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "offered")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0755))
+	large := bytes.Repeat([]byte("large file "), 1<<17)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "large.bin"), large, 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "small.txt"), []byte("small file"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "empty"), nil, 0644))
+	require.NoError(t, os.Symlink("sub/small.txt", filepath.Join(dir, "link")))
+
+	kr, key := testKeyring(t)
+	src, err := newDagSource(dir, SymlinksPreserve)
+	require.NoError(t, err)
+	src.enc = testEncryptor(t, key)
+	stream := newCarStream(context.Background(), src, 1<<30)
+	carBytes, err := io.ReadAll(stream)
+	require.NoError(t, err)
+	root, err := stream.Root()
+	require.NoError(t, err)
+	require.NotContains(t, string(carBytes), "small file")
+
+	carPath := filepath.Join(t.TempDir(), "offer.car")
+	require.NoError(t, os.WriteFile(carPath, carBytes, 0644))
+	out := filepath.Join(t.TempDir(), "out")
+	require.NoError(t, extractCar(context.Background(), carPath, root, out, kr))
+
+	got, err := os.ReadFile(filepath.Join(out, "large.bin"))
+	require.NoError(t, err)
+	require.Equal(t, large, got)
+	got, err = os.ReadFile(filepath.Join(out, "sub", "small.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "small file", string(got))
+	got, err = os.ReadFile(filepath.Join(out, "empty"))
+	require.NoError(t, err)
+	require.Empty(t, got)
+	target, err := os.Readlink(filepath.Join(out, "link"))
+	require.NoError(t, err)
+	require.Equal(t, "sub/small.txt", target)
+
+	// Without the keyring the files are extracted as stored
+	raw := filepath.Join(t.TempDir(), "raw")
+	require.NoError(t, extractCar(context.Background(), carPath, root, raw, nil))
+	got, err = os.ReadFile(filepath.Join(raw, "sub", "small.txt"))
+	require.NoError(t, err)
+	require.Equal(t, encryptedSize(int64(len("small file"))), int64(len(got)))
+}
+
+// startBufferService runs a buffer service on a free port and returns its url
+func startBufferService(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+	url := fmt.Sprintf("http://localhost:%d", port)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- buffer.StartBufferService(ctx, &config.Config{BufferPath: t.TempDir(), BufferPort: port, BufferURL: url})
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(url + "/health")
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, 5*time.Second, 10*time.Millisecond)
+	return url
+}
+
+// interruptedUpload uploads the first n bytes of the CAR of src to a new upload session
+func interruptedUpload(t *testing.T, bufferURL string, src *dagSource, n int64) string {
+	ctx := context.Background()
+	upload, err := buffer.NewChunkedUpload(ctx, bufferURL, 4096)
+	require.NoError(t, err)
+	stream := newCarStream(ctx, src, 1<<30)
+	defer stream.Close()
+	require.NoError(t, upload.Upload(ctx, io.LimitReader(stream, n)))
+	return upload.ID()
+}
+
+func TestResumeEncryptedUpload(t *testing.T) {
+	ctx := context.Background()
+	bufferURL := startBufferService(t)
+	keyringPath := filepath.Join(t.TempDir(), "keyring.json")
+	data := make([]byte, 5*encChunkSize)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+	filePath := filepath.Join(t.TempDir(), "data.bin")
+	require.NoError(t, os.WriteFile(filePath, data, 0644))
+
+	// resume offers the file the way a new offer-file run does
+	resume := func(uploadID string, resumed bool) (*carStream, string, error) {
+		src, err := newDagSource(filePath, SymlinksPreserve)
+		require.NoError(t, err)
+		_, _, err = encryptSource(src, keyringPath, resumed)
+		if err != nil {
+			return nil, "", err
+		}
+		backend, err := buffer.NewBackend("local", &config.Config{}, nil, buffer.BackendOptions{BufferURL: bufferURL, ChunkSize: 4096, UploadID: uploadID})
+		require.NoError(t, err)
+		stream := newCarStream(ctx, src, 1<<30)
+		defer stream.Close()
+		location, err := backend.Put(ctx, "offer.car", stream, stream.CommP)
+		return stream, location, err
+	}
+
+	// A run is interrupted part way through its upload
+	src, err := newDagSource(filePath, SymlinksPreserve)
+	require.NoError(t, err)
+	kr, srcID, err := encryptSource(src, keyringPath, false)
+	require.NoError(t, err)
+	uploadID := interruptedUpload(t, bufferURL, src, 3*encChunkSize)
+
+	// The next run resumes it with the salt seed kept in the keyring
+	stream, location, err := resume(uploadID, true)
+	require.NoError(t, err)
+	root, err := stream.Root()
+	require.NoError(t, err)
+
+	resp, err := http.Get(location)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	carPath := filepath.Join(t.TempDir(), "offer.car")
+	f, err := os.Create(carPath)
+	require.NoError(t, err)
+	_, err = io.Copy(f, resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	out := filepath.Join(t.TempDir(), "out")
+	require.NoError(t, extractCar(ctx, carPath, root, out, kr))
+	got, err := os.ReadFile(filepath.Join(out, "data.bin"))
+	require.NoError(t, err)
+	require.Equal(t, data, got)
+
+	// The seed is dropped once the offer is made, the upload cannot be resumed again
+	require.NoError(t, kr.dropSeed(srcID))
+	_, _, err = resume(uploadID, true)
+	require.ErrorContains(t, err, "no encryption seed")
+
+	// Resuming with another seed sends different data, which the buffer rejects
+	src, err = newDagSource(filePath, SymlinksPreserve)
+	require.NoError(t, err)
+	_, _, err = encryptSource(src, keyringPath, false)
+	require.NoError(t, err)
+	uploadID = interruptedUpload(t, bufferURL, src, 3*encChunkSize)
+	_, _, err = resume(uploadID, false)
+	require.ErrorContains(t, err, "CommP mismatch")
+}
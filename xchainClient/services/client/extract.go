@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-unixfsnode"
+	"github.com/ipfs/go-unixfsnode/data"
+	"github.com/ipfs/go-unixfsnode/file"
+	"github.com/ipld/go-car/v2/storage"
+	dagpb "github.com/ipld/go-codec-dagpb"
+	"github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	basicnode "github.com/ipld/go-ipld-prime/node/basic"
+)
+
+// extractCar unpacks the UnixFS DAG below root in the CAR at carPath into dir.
+// Files encrypted by offer-file are decrypted with the keys in kr, when kr
+// is nil they are written as they are stored.
+func extractCar(ctx context.Context, carPath string, root cid.Cid, dir string, kr *keyring) error {
+	f, err := os.Open(carPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	store, err := storage.OpenReadable(f)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", carPath, err)
+	}
+	ls := cidlink.DefaultLinkSystem()
+	ls.SetReadStorage(store)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	x := &extractor{ctx: ctx, ls: &ls, kr: kr}
+	return x.extractDir(cidlink.Link{Cid: root}, dir)
+}
+
+type extractor struct {
+	ctx context.Context
+	ls  *ipld.LinkSystem
+	kr  *keyring
+}
+
+// extractDir writes the entries of the directory at l into dir
+func (x *extractor) extractDir(l ipld.Link, dir string) error {
+	nd, err := x.ls.Load(ipld.LinkContext{Ctx: x.ctx}, l, dagpb.Type.PBNode)
+	if err != nil {
+		return err
+	}
+	dirNode, err := unixfsnode.Reify(ipld.LinkContext{Ctx: x.ctx}, nd, x.ls)
+	if err != nil {
+		return err
+	}
+	if dirNode.Kind() != ipld.Kind_Map {
+		return fmt.Errorf("%s is not a directory", l)
+	}
+	it := dirNode.MapIterator()
+	for !it.Done() {
+		k, v, err := it.Next()
+		if err != nil {
+			return err
+		}
+		name, err := k.AsString()
+		if err != nil {
+			return err
+		}
+		// Names come from the network, never let them point outside of dir
+		if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+			return fmt.Errorf("invalid entry name %q in %s", name, l)
+		}
+		child, err := v.AsLink()
+		if err != nil {
+			return err
+		}
+		if err := x.extract(child, filepath.Join(dir, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extract writes the file, directory or symlink at l to p
+func (x *extractor) extract(l ipld.Link, p string) error {
+	lctx := ipld.LinkContext{Ctx: x.ctx}
+	if l.(cidlink.Link).Cid.Prefix().Codec == cid.Raw {
+		nd, err := x.ls.Load(lctx, l, basicnode.Prototype.Bytes)
+		if err != nil {
+			return err
+		}
+		return x.writeFile(nd, p)
+	}
+
+	nd, err := x.ls.Load(lctx, l, dagpb.Type.PBNode)
+	if err != nil {
+		return err
+	}
+	pbNode := nd.(dagpb.PBNode)
+	if !pbNode.FieldData().Exists() {
+		return fmt.Errorf("%s is not a UnixFS node", l)
+	}
+	ufsData, err := data.DecodeUnixFSData(pbNode.Data.Must().Bytes())
+	if err != nil {
+		return err
+	}
+	switch ufsData.FieldDataType().Int() {
+	case data.Data_Directory, data.Data_HAMTShard:
+		if err := os.Mkdir(p, 0755); err != nil {
+			return err
+		}
+		return x.extractDir(l, p)
+	case data.Data_File, data.Data_Raw:
+		return x.writeFile(nd, p)
+	case data.Data_Symlink:
+		return os.Symlink(string(ufsData.FieldData().Must().Bytes()), p)
+	default:
+		return fmt.Errorf("unsupported UnixFS node type %d at %s", ufsData.FieldDataType().Int(), p)
+	}
+}
+
+// writeFile writes the content of a UnixFS file node to p, decrypting it when it is encrypted
+func (x *extractor) writeFile(nd ipld.Node, p string) error {
+	ufsFile, err := file.NewUnixFSFile(x.ctx, nd, x.ls)
+	if err != nil {
+		return err
+	}
+	r, err := ufsFile.AsLargeBytes()
+	if err != nil {
+		return err
+	}
+	var content io.Reader = r
+	if x.kr != nil {
+		dr, err := newDecryptReader(x.kr, r)
+		switch {
+		case err == nil:
+			content = dr
+		case errors.Is(err, errNotEncrypted):
+			if _, err := r.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("failed to decrypt %s: %w", p, err)
+		}
+	}
+
+	f, err := os.OpenFile(p, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, content); err != nil {
+		f.Close()
+		os.Remove(p)
+		return fmt.Errorf("failed to write %s: %w", p, err)
+	}
+	return f.Close()
+}
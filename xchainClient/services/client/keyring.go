@@ -0,0 +1,167 @@
+package client
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+// DefaultKeyringPath is where the encryption keys of offered files are kept
+const DefaultKeyringPath = "~/.xchain/keyring.json"
+
+const keyIDSize = 8
+
+// keyID names a key in encrypted files, it is a hash of the key so it does not reveal it
+type keyID [keyIDSize]byte
+
+func (id keyID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+type keyringKey struct {
+	id     keyID
+	secret []byte
+}
+
+func newKeyringKey(secret []byte) *keyringKey {
+	k := &keyringKey{secret: secret}
+	sum := sha256.Sum256(secret)
+	copy(k.id[:], sum[:])
+	return k
+}
+
+// keyring is a local file of the keys files were encrypted with.
+// Losing it means losing access to the encrypted data, it should be backed up.
+type keyring struct {
+	path    string
+	Default string            `json:"default"`
+	Keys    map[string]string `json:"keys"`            // base64 keys by id
+	Seeds   map[string]string `json:"seeds,omitempty"` // base64 salt seeds of offers not made yet, by source id
+}
+
+// openKeyring loads the keyring at path, an empty keyring is returned if it does not exist yet
+func openKeyring(path string) (*keyring, error) {
+	if path == "" {
+		path = DefaultKeyringPath
+	}
+	path, err := homedir.Expand(path)
+	if err != nil {
+		return nil, err
+	}
+	kr := &keyring{path: path, Keys: make(map[string]string), Seeds: make(map[string]string)}
+	bs, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return kr, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(bs, kr); err != nil {
+		return nil, fmt.Errorf("failed to parse keyring %s: %w", path, err)
+	}
+	if kr.Keys == nil {
+		kr.Keys = make(map[string]string)
+	}
+	if kr.Seeds == nil {
+		kr.Seeds = make(map[string]string)
+	}
+	return kr, nil
+}
+
+// defaultKey returns the key new files are encrypted with, it is created on first use
+func (kr *keyring) defaultKey() (*keyringKey, error) {
+	if kr.Default != "" {
+		var id keyID
+		if _, err := hex.Decode(id[:], []byte(kr.Default)); err != nil || len(kr.Default) != 2*keyIDSize {
+			return nil, fmt.Errorf("invalid default key id %q in %s", kr.Default, kr.path)
+		}
+		return kr.get(id)
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	key := newKeyringKey(secret)
+	kr.Keys[key.id.String()] = base64.StdEncoding.EncodeToString(secret)
+	kr.Default = key.id.String()
+	if err := kr.save(); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// get returns the key with the given id
+func (kr *keyring) get(id keyID) (*keyringKey, error) {
+	encoded, ok := kr.Keys[id.String()]
+	if !ok {
+		return nil, fmt.Errorf("key %s is not in keyring %s", id, kr.path)
+	}
+	secret, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key %s in %s: %w", id, kr.path, err)
+	}
+	key := newKeyringKey(secret)
+	if key.id != id {
+		return nil, fmt.Errorf("key %s in %s does not match its id", id, kr.path)
+	}
+	return key, nil
+}
+
+// offerSeed returns the salt seed to encrypt the files of source with. A new
+// seed is kept until dropSeed is called, so that an interrupted upload can be
+// resumed with the same one.
+func (kr *keyring) offerSeed(source string, resume bool) ([]byte, error) {
+	if resume {
+		encoded, ok := kr.Seeds[source]
+		if !ok {
+			return nil, fmt.Errorf("no encryption seed for this input in %s, an encrypted upload can only be resumed with the keyring it was started with", kr.path)
+		}
+		seed, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption seed %s in %s: %w", source, kr.path, err)
+		}
+		return seed, nil
+	}
+	seed, err := newSeed()
+	if err != nil {
+		return nil, err
+	}
+	kr.Seeds[source] = base64.StdEncoding.EncodeToString(seed)
+	if err := kr.save(); err != nil {
+		return nil, err
+	}
+	return seed, nil
+}
+
+// dropSeed forgets the salt seed of source once all of its offers are made
+func (kr *keyring) dropSeed(source string) error {
+	if _, ok := kr.Seeds[source]; !ok {
+		return nil
+	}
+	delete(kr.Seeds, source)
+	return kr.save()
+}
+
+// save writes the keyring readable by its owner only
+func (kr *keyring) save() error {
+	if err := os.MkdirAll(filepath.Dir(kr.path), 0700); err != nil {
+		return err
+	}
+	bs, err := json.MarshalIndent(kr, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := kr.path + ".tmp"
+	if err := os.WriteFile(tmp, bs, 0600); err != nil {
+		return fmt.Errorf("failed to write keyring: %w", err)
+	}
+	return os.Rename(tmp, kr.path)
+}
@@ -0,0 +1,211 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"strings"
+
+	"github.com/FIL-Builders/xchainClient/contracts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// NativeToken is the token address of offers paid in the chain's native currency
+var NativeToken = common.Address{}
+
+// nativeDecimals is the number of decimals of the native currency of EVM chains
+const nativeDecimals = 18
+
+// Payment is what an offer pays and in which token
+type Payment struct {
+	Token  common.Address
+	Amount *big.Int // in the smallest unit of the token
+}
+
+// Native reports whether the payment is made in the native currency through msg.value
+func (p *Payment) Native() bool {
+	return p.Token == NativeToken
+}
+
+// parseToken accepts a token address, or "native" for the native currency
+func parseToken(token string) (common.Address, error) {
+	if strings.EqualFold(token, "native") {
+		return NativeToken, nil
+	}
+	if !common.IsHexAddress(token) {
+		return common.Address{}, fmt.Errorf("invalid token address %q", token)
+	}
+	return common.HexToAddress(token), nil
+}
+
+// ParseAmount converts a decimal amount such as "1.5" into the smallest unit of a
+// token with the given number of decimals
+func ParseAmount(amount string, decimals uint8) (*big.Int, error) {
+	whole, frac, _ := strings.Cut(strings.TrimSpace(amount), ".")
+	if whole+frac == "" || strings.HasPrefix(whole, "-") || strings.HasPrefix(whole, "+") {
+		return nil, fmt.Errorf("invalid amount %q", amount)
+	}
+	if len(frac) > int(decimals) {
+		return nil, fmt.Errorf("amount %q has more than %d decimals", amount, decimals)
+	}
+	digits := whole + frac + strings.Repeat("0", int(decimals)-len(frac))
+	value, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount %q", amount)
+	}
+	return value, nil
+}
+
+// FormatAmount is the inverse of ParseAmount
+func FormatAmount(value *big.Int, decimals uint8) string {
+	if decimals == 0 {
+		return value.String()
+	}
+	s := value.String()
+	if len(s) <= int(decimals) {
+		s = strings.Repeat("0", int(decimals)-len(s)+1) + s
+	}
+	whole, frac := s[:len(s)-int(decimals)], strings.TrimRight(s[len(s)-int(decimals):], "0")
+	if frac == "" {
+		return whole
+	}
+	return whole + "." + frac
+}
+
+// payments resolves token amounts and makes sure the OnRamp can collect them
+type payments struct {
+	o         *offerer
+	baseUnits bool // amounts are already in the smallest unit of the token
+}
+
+func (o *offerer) payments(baseUnits bool) *payments {
+	return &payments{o: o, baseUnits: baseUnits}
+}
+
+// parse turns a token and a human readable amount into a Payment
+func (p *payments) parse(ctx context.Context, token string, amount string) (*Payment, error) {
+	tokenAddr, err := parseToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if p.baseUnits {
+		value, err := ParseAmount(amount, 0)
+		if err != nil {
+			return nil, err
+		}
+		return &Payment{Token: tokenAddr, Amount: value}, nil
+	}
+
+	decimals, err := p.o.tokenDecimals(ctx, tokenAddr)
+	if err != nil {
+		return nil, err
+	}
+	value, err := ParseAmount(amount, decimals)
+	if err != nil {
+		return nil, err
+	}
+	return &Payment{Token: tokenAddr, Amount: value}, nil
+}
+
+// tokenDecimals looks up the decimals of a token once
+func (c *onrampConn) tokenDecimals(ctx context.Context, token common.Address) (uint8, error) {
+	if token == NativeToken {
+		return nativeDecimals, nil
+	}
+	if decimals, ok := c.decimals[token]; ok {
+		return decimals, nil
+	}
+	erc20, err := c.erc20(token)
+	if err != nil {
+		return 0, err
+	}
+	decimals, err := erc20.Decimals(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get decimals of token %s: %w", token.Hex(), err)
+	}
+	c.decimals[token] = decimals
+	return decimals, nil
+}
+
+// ensureFunds checks the sender can pay total of each token and approves the
+// OnRamp to spend any ERC-20 total it is not yet allowed to
+func (p *payments) ensureFunds(ctx context.Context, totals map[common.Address]*big.Int) error {
+	for token, total := range totals {
+		if total.Sign() == 0 {
+			continue
+		}
+		if token == NativeToken {
+			balance, err := p.o.client.PendingBalanceAt(ctx, p.o.auth.From)
+			if err != nil {
+				return fmt.Errorf("failed to get balance: %w", err)
+			}
+			if balance.Cmp(total) < 0 {
+				return fmt.Errorf("balance %s is less than the %s offered", balance, total)
+			}
+			continue
+		}
+		if err := p.ensureAllowance(ctx, token, total); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *payments) ensureAllowance(ctx context.Context, token common.Address, total *big.Int) error {
+	erc20, err := p.o.erc20(token)
+	if err != nil {
+		return err
+	}
+	opts := &bind.CallOpts{Context: ctx, From: p.o.auth.From}
+
+	balance, err := erc20.BalanceOf(opts, p.o.auth.From)
+	if err != nil {
+		return fmt.Errorf("failed to get balance of token %s: %w", token.Hex(), err)
+	}
+	if balance.Cmp(total) < 0 {
+		return fmt.Errorf("token %s balance %s is less than the %s offered", token.Hex(), balance, total)
+	}
+
+	allowance, err := erc20.Allowance(opts, p.o.auth.From, p.o.onrampAddr)
+	if err != nil {
+		return fmt.Errorf("failed to get allowance of token %s: %w", token.Hex(), err)
+	}
+	if allowance.Cmp(total) >= 0 {
+		return nil
+	}
+
+	slog.Info("Approving OnRamp", "onramp", p.o.onrampAddr.Hex(), "amount", total, "token", token.Hex(), "allowance", allowance)
+	txOpts := *p.o.auth
+	txOpts.Context = ctx
+	tx, err := erc20.Approve(&txOpts, p.o.onrampAddr, total)
+	if err != nil {
+		return fmt.Errorf("failed to approve token %s: %w", token.Hex(), err)
+	}
+	receipt, err := bind.WaitMined(ctx, p.o.client, tx)
+	if err != nil {
+		return fmt.Errorf("failed to wait for approve tx %s: %w", tx.Hash().Hex(), err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("approve transaction %s reverted", tx.Hash().Hex())
+	}
+	return nil
+}
+
+func (c *onrampConn) erc20(token common.Address) (*contracts.ERC20, error) {
+	erc20, err := contracts.NewERC20(token, c.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind token %s: %w", token.Hex(), err)
+	}
+	return erc20, nil
+}
+
+// addTotal adds the payment to the running total of its token
+func addTotal(totals map[common.Address]*big.Int, p *Payment) {
+	if totals[p.Token] == nil {
+		totals[p.Token] = new(big.Int)
+	}
+	totals[p.Token].Add(totals[p.Token], p.Amount)
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAmount(t *testing.T) {
+	for _, tc := range []struct {
+		amount   string
+		decimals uint8
+		want     string
+	}{
+		{"1", 18, "1000000000000000000"},
+		{"1.5", 18, "1500000000000000000"},
+		{"0.000001", 6, "1"},
+		{".25", 2, "25"},
+		{"1000", 0, "1000"},
+		{"42.", 3, "42000"},
+	} {
+		value, err := ParseAmount(tc.amount, tc.decimals)
+		require.NoError(t, err, tc.amount)
+		require.Equal(t, tc.want, value.String(), tc.amount)
+	}
+
+	for _, amount := range []string{"", ".", "-1", "+1", "1.2.3", "1e18", "0x10", "abc"} {
+		_, err := ParseAmount(amount, 18)
+		require.Error(t, err, amount)
+	}
+	_, err := ParseAmount("0.0000001", 6)
+	require.ErrorContains(t, err, "more than 6 decimals")
+}
+
+func TestFormatAmount(t *testing.T) {
+	require.Equal(t, "1.5", FormatAmount(big.NewInt(1500000), 6))
+	require.Equal(t, "0.000001", FormatAmount(big.NewInt(1), 6))
+	require.Equal(t, "2", FormatAmount(big.NewInt(2000000), 6))
+	require.Equal(t, "1000", FormatAmount(big.NewInt(1000), 0))
+	require.Equal(t, "0", FormatAmount(big.NewInt(0), 18))
+}
+
+func TestParseToken(t *testing.T) {
+	token, err := parseToken("native")
+	require.NoError(t, err)
+	require.Equal(t, NativeToken, token)
+
+	token, err = parseToken("0x6b175474e89094c44da98b954eedeac495271d0f")
+	require.NoError(t, err)
+	require.NotEqual(t, NativeToken, token)
+
+	_, err = parseToken("dai")
+	require.Error(t, err)
+}
@@ -0,0 +1,320 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"math/bits"
+	"strings"
+
+	"github.com/FIL-Builders/xchainClient/contracts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/filecoin-project/go-data-segment/datasegment"
+	"github.com/filecoin-project/go-data-segment/merkletree"
+	commcid "github.com/filecoin-project/go-fil-commcid"
+	filabi "github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+	"github.com/urfave/cli/v2"
+)
+
+// inclusionCheck is a claim that a piece is part of an aggregate, backed by a merkle proof
+type inclusionCheck struct {
+	offerID   uint64 // 0 when the proof was given on the command line
+	piece     cid.Cid
+	pieceSize uint64 // padded, 0 if unknown
+	aggregate cid.Cid
+	proof     merkletree.ProofData
+	// indexProof proves the piece's entry in the data segment index of the aggregate, nil if not given
+	indexProof *merkletree.ProofData
+}
+
+func (c *inclusionCheck) String() string {
+	if c.offerID != 0 {
+		return fmt.Sprintf("offer %d (%s)", c.offerID, c.piece)
+	}
+	return c.piece.String()
+}
+
+func VerifyProofAction(cctx *cli.Context) error {
+	var checks []*inclusionCheck
+	if cctx.IsSet("tx") || cctx.IsSet("aggregation") {
+		_, conn, err := dialFromFlags(cctx)
+		if err != nil {
+			return err
+		}
+		txHash := common.HexToHash(cctx.String("tx"))
+		if cctx.IsSet("aggregation") {
+			if txHash, err = conn.aggregationTx(cctx.Context, cctx.Uint64("aggregation"), cctx.Uint64("from-block")); err != nil {
+				return err
+			}
+		}
+		if checks, err = conn.committedProofs(cctx.Context, txHash); err != nil {
+			return err
+		}
+		if cctx.IsSet("offer") {
+			checks = filterOffer(checks, cctx.Uint64("offer"))
+			if len(checks) == 0 {
+				return fmt.Errorf("offer %d is not part of the aggregate committed in %s", cctx.Uint64("offer"), txHash)
+			}
+		}
+	} else {
+		check, err := inclusionCheckFromFlags(cctx)
+		if err != nil {
+			return err
+		}
+		checks = append(checks, check)
+	}
+
+	failed := 0
+	for _, c := range checks {
+		offset, err := verifyInclusion(c, cctx.Uint64("aggregate-size"))
+		if err != nil {
+			failed++
+			fmt.Fprintf(cctx.App.Writer, "FAIL %s: %v\n", c, err)
+			continue
+		}
+		switch {
+		case c.indexProof != nil:
+			fmt.Fprintf(cctx.App.Writer, "OK   %s is included in %s at offset %d and listed in its index\n", c, c.aggregate, offset)
+		case c.pieceSize != 0:
+			fmt.Fprintf(cctx.App.Writer, "OK   %s is included in %s at offset %d\n", c, c.aggregate, offset)
+		default:
+			fmt.Fprintf(cctx.App.Writer, "OK   %s is included in %s\n", c, c.aggregate)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d inclusion proofs failed", failed, len(checks))
+	}
+	return nil
+}
+
+// verifyInclusion checks the proof the way OnRamp.sol's verifyInclusion does: the
+// root computed from the piece commitment and the proof path has to be the
+// aggregate commitment. When the piece size is known the size of the aggregate
+// implied by the proof depth is checked as well and the piece offset returned.
+// With an index proof the piece also has to be listed in the data segment index.
+func verifyInclusion(c *inclusionCheck, aggregateSize uint64) (uint64, error) {
+	leaf, err := commcid.CIDToPieceCommitmentV1(c.piece)
+	if err != nil {
+		return 0, fmt.Errorf("invalid piece CID %s: %w", c.piece, err)
+	}
+	root, err := commcid.CIDToPieceCommitmentV1(c.aggregate)
+	if err != nil {
+		return 0, fmt.Errorf("invalid aggregate CID %s: %w", c.aggregate, err)
+	}
+	computed, err := c.proof.ComputeRoot((*merkletree.Node)(leaf))
+	if err != nil {
+		return 0, err
+	}
+	if *computed != *(*merkletree.Node)(root) {
+		computedCid, err := commcid.PieceCommitmentV1ToCID(computed[:])
+		if err != nil {
+			return 0, err
+		}
+		return 0, fmt.Errorf("proof leads to %s instead of the aggregate", computedCid)
+	}
+
+	if c.pieceSize == 0 {
+		if aggregateSize != 0 {
+			return 0, fmt.Errorf("the piece size is needed to check the aggregate size")
+		}
+		if c.indexProof != nil {
+			return 0, fmt.Errorf("the piece size is needed to check the index proof")
+		}
+		return 0, nil
+	}
+	if bits.OnesCount64(c.pieceSize) != 1 {
+		return 0, fmt.Errorf("piece size %d is not a power of two", c.pieceSize)
+	}
+	if bits.LeadingZeros64(c.pieceSize) <= c.proof.Depth() {
+		return 0, fmt.Errorf("proof of depth %d is too deep for a piece of %d bytes", c.proof.Depth(), c.pieceSize)
+	}
+	if implied := c.pieceSize << c.proof.Depth(); aggregateSize != 0 && implied != aggregateSize {
+		return 0, fmt.Errorf("proof is for an aggregate of %d bytes, not %d", implied, aggregateSize)
+	}
+	if c.indexProof != nil {
+		ip := datasegment.InclusionProof{ProofSubtree: c.proof, ProofIndex: *c.indexProof}
+		aux, err := ip.ComputeExpectedAuxData(datasegment.InclusionVerifierData{CommPc: c.piece, SizePc: filabi.PaddedPieceSize(c.pieceSize)})
+		if err != nil {
+			return 0, fmt.Errorf("invalid index proof: %w", err)
+		}
+		if !aux.CommPa.Equals(c.aggregate) {
+			return 0, fmt.Errorf("index proof leads to %s instead of the aggregate", aux.CommPa)
+		}
+	}
+	return c.proof.Index * c.pieceSize, nil
+}
+
+// inclusionCheckFromFlags reads a proof given on the command line
+func inclusionCheckFromFlags(cctx *cli.Context) (*inclusionCheck, error) {
+	for _, name := range []string{"piece", "aggregate"} {
+		if cctx.String(name) == "" {
+			return nil, fmt.Errorf("--%s is required unless --tx or --aggregation is given", name)
+		}
+	}
+	piece, err := cid.Decode(cctx.String("piece"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid piece CID: %w", err)
+	}
+	aggregate, err := cid.Decode(cctx.String("aggregate"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid aggregate CID: %w", err)
+	}
+	path, err := parseProofPath(cctx.String("path"))
+	if err != nil {
+		return nil, err
+	}
+	check := &inclusionCheck{
+		piece:     piece,
+		pieceSize: cctx.Uint64("piece-size"),
+		aggregate: aggregate,
+		proof:     merkletree.ProofData{Index: cctx.Uint64("index"), Path: path},
+	}
+	if cctx.IsSet("entry-path") {
+		entryPath, err := parseProofPath(cctx.String("entry-path"))
+		if err != nil {
+			return nil, err
+		}
+		check.indexProof = &merkletree.ProofData{Index: cctx.Uint64("entry-index"), Path: entryPath}
+	}
+	return check, nil
+}
+
+// parseProofPath parses comma separated 32 byte hex nodes, leaf side first
+func parseProofPath(s string) ([]merkletree.Node, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	parts := strings.Split(s, ",")
+	path := make([]merkletree.Node, len(parts))
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		node, err := hex.DecodeString(strings.TrimPrefix(part, "0x"))
+		if err != nil || len(node) != merkletree.NodeSize {
+			return nil, fmt.Errorf("invalid proof node %q, expected 32 hex encoded bytes", part)
+		}
+		copy(path[i][:], node)
+	}
+	return path, nil
+}
+
+// aggregationTx finds the commitAggregate transaction of an aggregation
+func (c *onrampConn) aggregationTx(ctx context.Context, aggID uint64, fromBlock uint64) (common.Hash, error) {
+	it, err := c.onramp.FilterAggregationCommitted(&bind.FilterOpts{Start: fromBlock, Context: ctx})
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to query AggregationCommitted events: %w", err)
+	}
+	defer it.Close()
+	for it.Next() {
+		if it.Event.AggId == aggID {
+			return it.Event.Raw.TxHash, nil
+		}
+	}
+	if err := it.Error(); err != nil {
+		return common.Hash{}, fmt.Errorf("failed to query AggregationCommitted events: %w", err)
+	}
+	return common.Hash{}, fmt.Errorf("no AggregationCommitted event for aggregation %d since block %d", aggID, fromBlock)
+}
+
+// committedProofs reads the inclusion proofs sent to commitAggregate in txHash
+// and pairs them with the commitments of the offers they are for
+func (c *onrampConn) committedProofs(ctx context.Context, txHash common.Hash) ([]*inclusionCheck, error) {
+	tx, pending, err := c.client.TransactionByHash(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction %s: %w", txHash, err)
+	}
+	if pending {
+		return nil, fmt.Errorf("transaction %s is still pending", txHash)
+	}
+	if tx.To() == nil || *tx.To() != c.onrampAddr {
+		return nil, fmt.Errorf("transaction %s was not sent to the OnRamp at %s", txHash, c.onrampAddr)
+	}
+	receipt, err := c.client.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get receipt of %s: %w", txHash, err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		slog.Warn("Transaction reverted, its proofs were not accepted by the OnRamp", "tx", txHash)
+	}
+
+	commP, ids, proofs, indexProofs, err := unpackCommitAggregate(tx.Data())
+	if err != nil {
+		return nil, fmt.Errorf("transaction %s: %w", txHash, err)
+	}
+	aggregate, err := cid.Cast(commP)
+	if err != nil {
+		return nil, fmt.Errorf("transaction %s has an invalid aggregate CommP: %w", txHash, err)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	checks := make([]*inclusionCheck, len(ids))
+	for i, id := range ids {
+		offer, err := c.onramp.Offers(opts, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get offer %d: %w", id, err)
+		}
+		piece, err := cid.Cast(offer.CommP)
+		if err != nil {
+			return nil, fmt.Errorf("offer %d has an invalid CommP: %w", id, err)
+		}
+		indexProof := merkleProof(indexProofs[i])
+		checks[i] = &inclusionCheck{
+			offerID:    id,
+			piece:      piece,
+			pieceSize:  offer.Size,
+			aggregate:  aggregate,
+			proof:      merkleProof(proofs[i]),
+			indexProof: &indexProof,
+		}
+	}
+	return checks, nil
+}
+
+// unpackCommitAggregate decodes the arguments of a commitAggregate call
+func unpackCommitAggregate(data []byte) (commP []byte, ids []uint64, proofs, indexProofs []contracts.PODSIVerifierProofData, err error) {
+	parsed, err := contracts.OnRampMetaData.GetAbi()
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	if len(data) < 4 {
+		return nil, nil, nil, nil, fmt.Errorf("not a contract call")
+	}
+	method, err := parsed.MethodById(data[:4])
+	if err != nil || method.Name != "commitAggregate" {
+		return nil, nil, nil, nil, fmt.Errorf("not a commitAggregate call")
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("failed to decode commitAggregate call: %w", err)
+	}
+	commP = *abi.ConvertType(args[0], new([]byte)).(*[]byte)
+	ids = *abi.ConvertType(args[1], new([]uint64)).(*[]uint64)
+	proofs = *abi.ConvertType(args[2], new([]contracts.PODSIVerifierProofData)).(*[]contracts.PODSIVerifierProofData)
+	indexProofs = *abi.ConvertType(args[3], new([]contracts.PODSIVerifierProofData)).(*[]contracts.PODSIVerifierProofData)
+	if len(ids) != len(proofs) || len(ids) != len(indexProofs) {
+		return nil, nil, nil, nil, fmt.Errorf("commitAggregate call has %d offers but %d inclusion and %d index proofs", len(ids), len(proofs), len(indexProofs))
+	}
+	return commP, ids, proofs, indexProofs, nil
+}
+
+// merkleProof converts OnRamp.sol's `ProofData` struct back into a merkle proof
+func merkleProof(p contracts.PODSIVerifierProofData) merkletree.ProofData {
+	path := make([]merkletree.Node, len(p.Path))
+	for i, node := range p.Path {
+		path[i] = node
+	}
+	return merkletree.ProofData{Index: p.Index, Path: path}
+}
+
+func filterOffer(checks []*inclusionCheck, offerID uint64) []*inclusionCheck {
+	var filtered []*inclusionCheck
+	for _, c := range checks {
+		if c.offerID == offerID {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
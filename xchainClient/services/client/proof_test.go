@@ -0,0 +1,135 @@
+package client
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/FIL-Builders/xchainClient/contracts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/filecoin-project/go-data-segment/datasegment"
+	"github.com/filecoin-project/go-data-segment/merkletree"
+	filabi "github.com/filecoin-project/go-state-types/abi"
+	"github.com/stretchr/testify/require"
+)
+
+// testAggregate aggregates two offered CARs and returns the inclusion check of the second
+func testAggregate(t *testing.T) (*inclusionCheck, filabi.PaddedPieceSize) {
+	first, _ := offeredCar(t, bytes.Repeat([]byte("first"), 1<<12))
+	second, _ := offeredCar(t, bytes.Repeat([]byte("second"), 1<<13))
+	dealSize := filabi.PaddedPieceSize(1 << 20)
+	pieces := []filabi.PieceInfo{
+		{Size: filabi.PaddedPieceSize(first.size), PieceCID: first.commP},
+		{Size: filabi.PaddedPieceSize(second.size), PieceCID: second.commP},
+	}
+	agg, err := datasegment.NewAggregate(dealSize, pieces)
+	require.NoError(t, err)
+	aggCid, err := agg.PieceCID()
+	require.NoError(t, err)
+	podsi, err := agg.ProofForPieceInfo(pieces[1])
+	require.NoError(t, err)
+	return &inclusionCheck{
+		offerID:    2,
+		piece:      second.commP,
+		pieceSize:  second.size,
+		aggregate:  aggCid,
+		proof:      podsi.ProofSubtree,
+		indexProof: &podsi.ProofIndex,
+	}, dealSize
+}
+
+func TestVerifyInclusion(t *testing.T) {
+	check, dealSize := testAggregate(t)
+	offset, err := verifyInclusion(check, uint64(dealSize))
+	require.NoError(t, err)
+	require.Equal(t, check.proof.Index*check.pieceSize, offset)
+	require.NotZero(t, offset, "the second piece follows the first")
+
+	_, err = verifyInclusion(check, uint64(dealSize)*2)
+	require.ErrorContains(t, err, "aggregate of")
+
+	moved := *check
+	moved.proof.Index++
+	_, err = verifyInclusion(&moved, 0)
+	require.ErrorContains(t, err, "instead of the aggregate")
+
+	tampered := *check
+	tampered.proof.Path = append([]merkletree.Node(nil), check.proof.Path...)
+	tampered.proof.Path[0][0] ^= 1
+	_, err = verifyInclusion(&tampered, 0)
+	require.Error(t, err)
+
+	// The index proof has to point at the entry of this piece
+	otherEntry := *check
+	otherEntry.indexProof = &merkletree.ProofData{Index: check.indexProof.Index - 1, Path: check.indexProof.Path}
+	_, err = verifyInclusion(&otherEntry, 0)
+	require.ErrorContains(t, err, "invalid index proof")
+
+	// Without an index proof only the subtree is checked
+	subtreeOnly := *check
+	subtreeOnly.indexProof = nil
+	_, err = verifyInclusion(&subtreeOnly, uint64(dealSize))
+	require.NoError(t, err)
+
+	unsized := subtreeOnly
+	unsized.pieceSize = 0
+	_, err = verifyInclusion(&unsized, 0)
+	require.NoError(t, err)
+	unsized.indexProof = check.indexProof
+	_, err = verifyInclusion(&unsized, 0)
+	require.ErrorContains(t, err, "piece size is needed")
+}
+
+func contractProof(p merkletree.ProofData) contracts.PODSIVerifierProofData {
+	proof := contracts.PODSIVerifierProofData{Index: p.Index}
+	for _, node := range p.Path {
+		proof.Path = append(proof.Path, node)
+	}
+	return proof
+}
+
+func TestUnpackCommitAggregate(t *testing.T) {
+	check, _ := testAggregate(t)
+	parsed, err := contracts.OnRampMetaData.GetAbi()
+	require.NoError(t, err)
+	data, err := parsed.Pack("commitAggregate", check.aggregate.Bytes(), []uint64{2},
+		[]contracts.PODSIVerifierProofData{contractProof(check.proof)},
+		[]contracts.PODSIVerifierProofData{contractProof(*check.indexProof)},
+		common.HexToAddress("0x01"))
+	require.NoError(t, err)
+
+	commP, ids, proofs, indexProofs, err := unpackCommitAggregate(data)
+	require.NoError(t, err)
+	require.Equal(t, check.aggregate.Bytes(), commP)
+	require.Equal(t, []uint64{2}, ids)
+	require.Len(t, proofs, 1)
+	require.Equal(t, check.proof, merkleProof(proofs[0]))
+	require.Len(t, indexProofs, 1)
+	require.Equal(t, *check.indexProof, merkleProof(indexProofs[0]))
+
+	data, err = parsed.Pack("getOfferStatus", uint64(1))
+	require.NoError(t, err)
+	_, _, _, _, err = unpackCommitAggregate(data)
+	require.ErrorContains(t, err, "not a commitAggregate call")
+}
+
+func TestParseProofPath(t *testing.T) {
+	check, _ := testAggregate(t)
+	nodes := make([]string, len(check.proof.Path))
+	for i, node := range check.proof.Path {
+		nodes[i] = "0x" + hex.EncodeToString(node[:])
+	}
+	path, err := parseProofPath(strings.Join(nodes, ", "))
+	require.NoError(t, err)
+	require.Equal(t, check.proof.Path, path)
+
+	path, err = parseProofPath("")
+	require.NoError(t, err)
+	require.Empty(t, path)
+
+	_, err = parseProofPath("0x1234")
+	require.Error(t, err)
+	_, err = parseProofPath(strings.Repeat("zz", 32))
+	require.Error(t, err)
+}
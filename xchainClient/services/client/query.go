@@ -0,0 +1,266 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/FIL-Builders/xchainClient/config"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ipfs/go-cid"
+	"github.com/urfave/cli/v2"
+)
+
+// OfferInfo is an offer as stored by the OnRamp
+type OfferInfo struct {
+	ID       uint64 `json:"id"`
+	CommP    string `json:"commP"`
+	Cid      string `json:"cid"`
+	Size     uint64 `json:"size"`
+	Location string `json:"location"`
+	Token    string `json:"token"`
+	Amount   string `json:"amount"`
+	Status   string `json:"status"`
+	DealID   uint64 `json:"dealId,omitempty"`
+}
+
+// AggregationInfo is an aggregation as stored by the OnRamp
+type AggregationInfo struct {
+	ID            uint64   `json:"id"`
+	PayoutAddress string   `json:"payoutAddress"`
+	Proven        bool     `json:"proven"`
+	DealID        uint64   `json:"dealId,omitempty"`
+	OfferIDs      []uint64 `json:"offerIds"`
+}
+
+func ListOffersAction(cctx *cli.Context) error {
+	cfg, conn, err := dialFromFlags(cctx)
+	if err != nil {
+		return err
+	}
+	clientAddr := cctx.String("address")
+	if clientAddr == "" {
+		clientAddr = cfg.ClientAddr
+	}
+	if !common.IsHexAddress(clientAddr) {
+		return fmt.Errorf("invalid client address %q", clientAddr)
+	}
+
+	ids, err := conn.onramp.GetClientOffers(&bind.CallOpts{Context: cctx.Context}, common.HexToAddress(clientAddr))
+	if err != nil {
+		return fmt.Errorf("failed to get offers of %s: %w", clientAddr, err)
+	}
+	return conn.printOffers(cctx, ids)
+}
+
+func PendingOffersAction(cctx *cli.Context) error {
+	_, conn, err := dialFromFlags(cctx)
+	if err != nil {
+		return err
+	}
+	ids, err := conn.onramp.GetPendingOffers(&bind.CallOpts{Context: cctx.Context})
+	if err != nil {
+		return fmt.Errorf("failed to get pending offers: %w", err)
+	}
+	return conn.printOffers(cctx, ids)
+}
+
+func OfferStatusAction(cctx *cli.Context) error {
+	if cctx.Args().Len() != 1 {
+		return fmt.Errorf("Usage: <offer-id>")
+	}
+	id, err := strconv.ParseUint(cctx.Args().First(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid offer id %q: %w", cctx.Args().First(), err)
+	}
+	_, conn, err := dialFromFlags(cctx)
+	if err != nil {
+		return err
+	}
+	info, err := conn.offerInfo(cctx.Context, id, cctx.Bool("base-units"))
+	if err != nil {
+		return err
+	}
+	if cctx.Bool("json") {
+		return printJSON(cctx.App.Writer, info)
+	}
+	return printFields(cctx.App.Writer, [][2]string{
+		{"ID", strconv.FormatUint(info.ID, 10)},
+		{"Status", info.Status},
+		{"CommP", info.CommP},
+		{"CID", info.Cid},
+		{"Size", strconv.FormatUint(info.Size, 10)},
+		{"Location", info.Location},
+		{"Amount", info.Amount},
+		{"Token", info.Token},
+		{"Deal ID", formatDealID(info.DealID)},
+	})
+}
+
+func AggregationAction(cctx *cli.Context) error {
+	if cctx.Args().Len() != 1 {
+		return fmt.Errorf("Usage: <aggregation-id>")
+	}
+	id, err := strconv.ParseUint(cctx.Args().First(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid aggregation id %q: %w", cctx.Args().First(), err)
+	}
+	_, conn, err := dialFromFlags(cctx)
+	if err != nil {
+		return err
+	}
+	info, err := conn.aggregationInfo(cctx.Context, id)
+	if err != nil {
+		return err
+	}
+	if cctx.Bool("json") {
+		return printJSON(cctx.App.Writer, info)
+	}
+	offerIDs := make([]string, len(info.OfferIDs))
+	for i, id := range info.OfferIDs {
+		offerIDs[i] = strconv.FormatUint(id, 10)
+	}
+	return printFields(cctx.App.Writer, [][2]string{
+		{"ID", strconv.FormatUint(info.ID, 10)},
+		{"Proven", strconv.FormatBool(info.Proven)},
+		{"Deal ID", formatDealID(info.DealID)},
+		{"Payout Address", info.PayoutAddress},
+		{"Offers", strings.Join(offerIDs, ", ")},
+	})
+}
+
+func dialFromFlags(cctx *cli.Context) (*config.Config, *onrampConn, error) {
+	cfg, err := config.LoadConfig(cctx.String("config"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %v", err)
+	}
+	chainName := cctx.String("chain")
+	srcCfg, err := config.GetSourceConfig(cfg, chainName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid chain name '%s': %v", chainName, err)
+	}
+	conn, err := dialOnRamp(srcCfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cfg, conn, nil
+}
+
+func (c *onrampConn) offerInfo(ctx context.Context, id uint64, baseUnits bool) (*OfferInfo, error) {
+	opts := &bind.CallOpts{Context: ctx}
+	// getOfferDetails predates the root CID, so read the offer from the offers getter
+	offer, err := c.onramp.Offers(opts, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get offer %d: %w", id, err)
+	}
+	if offer.Size == 0 {
+		return nil, fmt.Errorf("offer %d does not exist", id)
+	}
+	deal, err := c.onramp.GetOfferDealId(opts, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deal of offer %d: %w", id, err)
+	}
+
+	info := &OfferInfo{
+		ID:       id,
+		Cid:      offer.Cid,
+		Size:     offer.Size,
+		Location: offer.Location,
+		Token:    offer.Token.Hex(),
+		Amount:   offer.Amount.String(),
+		Status:   OfferStatus(offer.Status).String(),
+	}
+	if _, commPCid, err := cid.CidFromBytes(offer.CommP); err == nil {
+		info.CommP = commPCid.String()
+	} else {
+		info.CommP = common.Bytes2Hex(offer.CommP)
+	}
+	if offer.Token == NativeToken {
+		info.Token = "native"
+	}
+	if !baseUnits {
+		decimals, err := c.tokenDecimals(ctx, offer.Token)
+		if err != nil {
+			return nil, err
+		}
+		info.Amount = FormatAmount(offer.Amount, decimals)
+	}
+	if deal.Exists {
+		info.DealID = deal.DealId
+	}
+	return info, nil
+}
+
+func (c *onrampConn) aggregationInfo(ctx context.Context, id uint64) (*AggregationInfo, error) {
+	opts := &bind.CallOpts{Context: ctx}
+	details, err := c.onramp.GetAggregationDetails(opts, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get aggregation %d: %w", id, err)
+	}
+	if details.OfferCount == 0 {
+		return nil, fmt.Errorf("aggregation %d does not exist", id)
+	}
+	info := &AggregationInfo{ID: id, PayoutAddress: details.PayoutAddress.Hex(), Proven: details.IsProven}
+	if info.OfferIDs, err = c.onramp.GetAggregationOffers(opts, id); err != nil {
+		return nil, fmt.Errorf("failed to get offers of aggregation %d: %w", id, err)
+	}
+	if info.DealID, err = c.onramp.AggregationDealIds(opts, id); err != nil {
+		return nil, fmt.Errorf("failed to get deal of aggregation %d: %w", id, err)
+	}
+	return info, nil
+}
+
+// printOffers prints the details of the offers as a table or JSON
+func (c *onrampConn) printOffers(cctx *cli.Context, ids []uint64) error {
+	offers := make([]*OfferInfo, 0, len(ids))
+	for _, id := range ids {
+		info, err := c.offerInfo(cctx.Context, id, cctx.Bool("base-units"))
+		if err != nil {
+			return err
+		}
+		offers = append(offers, info)
+	}
+	if cctx.Bool("json") {
+		return printJSON(cctx.App.Writer, offers)
+	}
+	return printOfferTable(cctx.App.Writer, offers)
+}
+
+func printOfferTable(w io.Writer, offers []*OfferInfo) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tSTATUS\tSIZE\tCOMMP\tCID\tAMOUNT\tTOKEN\tDEAL")
+	for _, o := range offers {
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%s\t%s\t%s\t%s\n", o.ID, o.Status, o.Size, o.CommP, o.Cid, o.Amount, o.Token, formatDealID(o.DealID))
+	}
+	return tw.Flush()
+}
+
+func formatDealID(dealID uint64) string {
+	if dealID == 0 {
+		return "-"
+	}
+	return strconv.FormatUint(dealID, 10)
+}
+
+func printFields(w io.Writer, fields [][2]string) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, f := range fields {
+		fmt.Fprintf(tw, "%s:\t%s\n", f[0], f[1])
+	}
+	return tw.Flush()
+}
+
+func printJSON(w io.Writer, v interface{}) error {
+	if w == nil {
+		w = os.Stdout
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
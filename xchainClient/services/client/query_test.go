@@ -0,0 +1,37 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintOfferTable(t *testing.T) {
+	offers := []*OfferInfo{
+		{ID: 1, Status: "proven", Size: 1024, CommP: "baga6ea4seaq", Cid: "bafy1", Amount: "1.5", Token: "native", DealID: 42},
+		{ID: 12, Status: "pending", Size: 2048, CommP: "baga6ea4seaqx", Cid: "bafy2", Amount: "0", Token: "0xabc"},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, printOfferTable(&buf, offers))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	require.Equal(t, []string{"ID", "STATUS", "SIZE", "COMMP", "CID", "AMOUNT", "TOKEN", "DEAL"}, strings.Fields(lines[0]))
+	require.Equal(t, []string{"1", "proven", "1024", "baga6ea4seaq", "bafy1", "1.5", "native", "42"}, strings.Fields(lines[1]))
+	require.Equal(t, []string{"12", "pending", "2048", "baga6ea4seaqx", "bafy2", "0", "0xabc", "-"}, strings.Fields(lines[2]))
+	// Columns are aligned
+	require.Equal(t, strings.Index(lines[0], "STATUS"), strings.Index(lines[1], "proven"))
+}
+
+func TestPrintJSONOmitsMissingDeal(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, printJSON(&buf, &OfferInfo{ID: 3, Status: "aggregated"}))
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Equal(t, "aggregated", decoded["status"])
+	require.NotContains(t, decoded, "dealId")
+}
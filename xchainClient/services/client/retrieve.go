@@ -0,0 +1,405 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/FIL-Builders/xchainClient/config"
+	"github.com/FIL-Builders/xchainClient/logging"
+	"github.com/FIL-Builders/xchainClient/services/aggregator"
+	"github.com/FIL-Builders/xchainClient/services/buffer"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/filecoin-project/go-data-segment/datasegment"
+	commcid "github.com/filecoin-project/go-fil-commcid"
+	commp "github.com/filecoin-project/go-fil-commp-hashhash"
+	filabi "github.com/filecoin-project/go-state-types/abi"
+	lotustypes "github.com/filecoin-project/lotus/chain/types"
+	"github.com/ipfs/go-cid"
+	carv2 "github.com/ipld/go-car/v2"
+	"github.com/urfave/cli/v2"
+)
+
+// Places an offer can be retrieved from, tried in this order by default
+const (
+	SourceBuffer   = "buffer"
+	SourceProvider = "provider"
+	SourceGateway  = "gateway"
+)
+
+// retrieval is what is needed to fetch and verify the CAR of an offer
+type retrieval struct {
+	offerID  uint64
+	commP    cid.Cid
+	size     uint64 // padded piece size
+	root     cid.Cid
+	location string
+	dealID   uint64
+}
+
+func RetrieveAction(cctx *cli.Context) error {
+	if cctx.Args().Len() != 1 {
+		return fmt.Errorf("Usage: <offer-id>")
+	}
+	id, err := strconv.ParseUint(cctx.Args().First(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid offer id %q: %w", cctx.Args().First(), err)
+	}
+	cfg, conn, err := dialFromFlags(cctx)
+	if err != nil {
+		return err
+	}
+	r, err := conn.retrieval(cctx.Context, id)
+	if err != nil {
+		return err
+	}
+
+	output := cctx.String("output")
+	if output == "" {
+		output = r.root.String() + ".car"
+	}
+	fetchers := map[string]func(ctx context.Context, w *os.File) error{
+		SourceBuffer: r.fromBuffer,
+		SourceProvider: func(ctx context.Context, w *os.File) error {
+			return r.fromProvider(ctx, cfg, cctx.String("provider-url"), w)
+		},
+		SourceGateway: func(ctx context.Context, w *os.File) error {
+			return r.fromGateway(ctx, cctx.String("gateway"), w)
+		},
+	}
+
+	sources := strings.Split(cctx.String("sources"), ",")
+	for _, source := range sources {
+		fetch, ok := fetchers[strings.TrimSpace(source)]
+		if !ok {
+			return fmt.Errorf("unknown retrieval source %q, expected %s, %s or %s", source, SourceBuffer, SourceProvider, SourceGateway)
+		}
+		if err := retrieveTo(cctx.Context, output, fetch); err != nil {
+			slog.Warn("Retrieval failed", logging.OfferID, id, "source", source, "err", err)
+			continue
+		}
+		slog.Info("Retrieved offer", logging.OfferID, id, "source", source)
+		fmt.Println(output)
+		if dir := cctx.String("extract"); dir != "" {
+			kr, err := openKeyring(cctx.String("keyring"))
+			if err != nil {
+				return err
+			}
+			if err := extractCar(cctx.Context, output, r.root, dir, kr); err != nil {
+				return fmt.Errorf("failed to extract %s: %w", output, err)
+			}
+			slog.Info("Extracted offer", logging.OfferID, id, "dir", dir)
+		}
+		return nil
+	}
+	return fmt.Errorf("failed to retrieve offer %d from %s", id, strings.Join(sources, ", "))
+}
+
+// retrieveTo only creates output once fetch has written and verified all of it
+func retrieveTo(ctx context.Context, output string, fetch func(ctx context.Context, w *os.File) error) error {
+	tmp := output + ".part"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	if err := fetch(ctx, f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, output)
+}
+
+// retrieval looks up where to find an offer and how to verify it
+func (c *onrampConn) retrieval(ctx context.Context, id uint64) (*retrieval, error) {
+	opts := &bind.CallOpts{Context: ctx}
+	offer, err := c.onramp.Offers(opts, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get offer %d: %w", id, err)
+	}
+	if offer.Size == 0 {
+		return nil, fmt.Errorf("offer %d does not exist", id)
+	}
+	commP, err := cid.Cast(offer.CommP)
+	if err != nil {
+		return nil, fmt.Errorf("offer %d has an invalid CommP: %w", id, err)
+	}
+	root, err := cid.Decode(offer.Cid)
+	if err != nil {
+		return nil, fmt.Errorf("offer %d has an invalid root CID %q: %w", id, offer.Cid, err)
+	}
+	deal, err := c.onramp.GetOfferDealId(opts, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deal of offer %d: %w", id, err)
+	}
+	return &retrieval{
+		offerID:  id,
+		commP:    commP,
+		size:     offer.Size,
+		root:     root,
+		location: offer.Location,
+		dealID:   deal.DealId,
+	}, nil
+}
+
+// fromBuffer downloads the CAR from the buffer urls of the offer
+func (r *retrieval) fromBuffer(ctx context.Context, w *os.File) error {
+	locations := buffer.ParseLocations(r.location)
+	if len(locations) == 0 {
+		return fmt.Errorf("offer has no buffer location")
+	}
+	var errs []string
+	for _, location := range locations {
+		err := r.fetchCar(ctx, location, w)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, fmt.Sprintf("%s: %v", location, err))
+		if err := rewind(w); err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("%s", strings.Join(errs, "; "))
+}
+
+func (r *retrieval) fetchCar(ctx context.Context, location string, w io.Writer) error {
+	body, err := httpGet(ctx, location, nil)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	_, err = copyVerified(w, body, r.commP, r.size)
+	return err
+}
+
+// fromProvider downloads the offer from the aggregate piece stored by the
+// storage provider, using Boost HTTP retrieval and the data segment index
+func (r *retrieval) fromProvider(ctx context.Context, cfg *config.Config, providerURL string, w *os.File) error {
+	if r.dealID == 0 {
+		return fmt.Errorf("offer has no deal yet")
+	}
+	if providerURL == "" {
+		return fmt.Errorf("no --provider-url to retrieve from")
+	}
+	lotus, closer, err := aggregator.NewLotusDaemonAPIClientV0(ctx, cfg.Destination.LotusAPI, 0, "")
+	if err != nil {
+		return fmt.Errorf("failed to connect to lotus at %s: %w", cfg.Destination.LotusAPI, err)
+	}
+	defer closer()
+	deal, err := lotus.StateMarketStorageDeal(ctx, filabi.DealID(r.dealID), lotustypes.EmptyTSK)
+	if err != nil {
+		return fmt.Errorf("failed to get deal %d: %w", r.dealID, err)
+	}
+	pieceURL := strings.TrimSuffix(providerURL, "/") + "/piece/" + deal.Proposal.PieceCID.String()
+	return r.fetchSegment(ctx, pieceURL, deal.Proposal.PieceSize, w)
+}
+
+// fetchSegment reads the data segment index at the end of the aggregate piece
+// and downloads only the segment of the offer
+func (r *retrieval) fetchSegment(ctx context.Context, pieceURL string, pieceSize filabi.PaddedPieceSize, w *os.File) error {
+	indexStart := datasegment.DataSegmentIndexStartOffset(pieceSize)
+	indexEnd := uint64(pieceSize.Unpadded())
+	body, err := httpGetRange(ctx, pieceURL, indexStart, indexEnd-indexStart)
+	if err != nil {
+		return err
+	}
+	index, err := datasegment.ParseDataSegmentIndex(body)
+	body.Close()
+	if err != nil {
+		return fmt.Errorf("failed to parse data segment index: %w", err)
+	}
+	segment, err := findSegment(index, r.commP, r.size)
+	if err != nil {
+		return err
+	}
+
+	body, err = httpGetRange(ctx, pieceURL, segment.UnpaddedOffest(), segment.UnpaddedLength())
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	if _, err := copyVerified(w, body, r.commP, r.size); err != nil {
+		return err
+	}
+
+	// The segment is the CAR followed by the zeros padding it to the piece size
+	if _, err := w.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	n, err := carLength(w)
+	if err != nil {
+		return err
+	}
+	return w.Truncate(n)
+}
+
+// fromGateway downloads the DAG as a CAR from a trustless IPFS gateway. Gateways
+// encode the CAR themselves so its CommP cannot match the offer; every block is
+// checked against its CID instead.
+func (r *retrieval) fromGateway(ctx context.Context, gateway string, w *os.File) error {
+	if gateway == "" {
+		return fmt.Errorf("no --gateway to retrieve from")
+	}
+	u := strings.TrimSuffix(gateway, "/") + "/ipfs/" + r.root.String() + "?" + url.Values{"format": {"car"}, "dag-scope": {"all"}}.Encode()
+	body, err := httpGet(ctx, u, http.Header{"Accept": {"application/vnd.ipld.car"}})
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	slog.Info("CommP is not checked for gateway retrievals, verifying blocks against their CIDs", logging.OfferID, r.offerID)
+	return copyVerifiedBlocks(w, body, r.root)
+}
+
+// copyVerified copies src to dst and checks the CommP and padded piece size of what was copied
+func copyVerified(dst io.Writer, src io.Reader, commP cid.Cid, size uint64) (int64, error) {
+	cp := &commp.Calc{}
+	n, err := io.Copy(io.MultiWriter(dst, cp), src)
+	if err != nil {
+		return n, err
+	}
+	rawCommP, paddedSize, err := cp.Digest()
+	if err != nil {
+		return n, fmt.Errorf("failed to compute CommP: %w", err)
+	}
+	got, err := commcid.DataCommitmentV1ToCID(rawCommP)
+	if err != nil {
+		return n, err
+	}
+	if !got.Equals(commP) || paddedSize != size {
+		return n, fmt.Errorf("CommP mismatch: got %s with size %d, expected %s with size %d", got, paddedSize, commP, size)
+	}
+	return n, nil
+}
+
+// copyVerifiedBlocks copies a CARv1 from src to dst, checking its root and that
+// every block matches its CID
+func copyVerifiedBlocks(dst io.Writer, src io.Reader, root cid.Cid) error {
+	br, err := carv2.NewBlockReader(io.TeeReader(src, dst))
+	if err != nil {
+		return fmt.Errorf("invalid CAR: %w", err)
+	}
+	if len(br.Roots) != 1 || !br.Roots[0].Equals(root) {
+		return fmt.Errorf("CAR roots %v do not match %s", br.Roots, root)
+	}
+	for {
+		_, err := br.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid CAR: %w", err)
+		}
+	}
+}
+
+// findSegment finds the data segment of a piece in an aggregate's index
+func findSegment(index datasegment.IndexData, commP cid.Cid, size uint64) (*datasegment.SegmentDesc, error) {
+	entries, err := index.ValidEntries()
+	if err != nil {
+		return nil, fmt.Errorf("invalid data segment index: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.PieceCID().Equals(commP) && entry.Size == size {
+			return &entry, nil
+		}
+	}
+	return nil, fmt.Errorf("piece %s is not in the aggregate", commP)
+}
+
+// carLength returns the length of the CARv1 at the start of r, ignoring any
+// zero padding after it
+func carLength(r io.Reader) (int64, error) {
+	br := bufio.NewReader(r)
+	var n int64
+	for first := true; ; first = false {
+		length, err := binary.ReadUvarint(br)
+		if err == io.EOF && !first {
+			return n, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("invalid CAR: %w", err)
+		}
+		if length == 0 {
+			if first {
+				return 0, fmt.Errorf("invalid CAR: empty header")
+			}
+			return n, nil
+		}
+		discarded, err := br.Discard(int(length))
+		if err != nil {
+			return 0, fmt.Errorf("invalid CAR: truncated section: %w", err)
+		}
+		n += int64(uvarintSize(length) + discarded)
+	}
+}
+
+func uvarintSize(v uint64) int {
+	var buf [binary.MaxVarintLen64]byte
+	return binary.PutUvarint(buf[:], v)
+}
+
+func rewind(f *os.File) error {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	return f.Truncate(0)
+}
+
+func httpGet(ctx context.Context, u string, header http.Header) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("GET %s: %s", u, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// httpGetRange fetches length bytes of u from offset, servers ignoring the
+// range are read up to offset instead
+func httpGetRange(ctx context.Context, u string, offset, length uint64) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+	case http.StatusOK:
+		if _, err := io.CopyN(io.Discard, resp.Body, int64(offset)); err != nil {
+			resp.Body.Close()
+			return nil, fmt.Errorf("GET %s: %w", u, err)
+		}
+	default:
+		resp.Body.Close()
+		return nil, fmt.Errorf("GET %s: %s", u, resp.Status)
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(resp.Body, int64(length)), resp.Body}, nil
+}
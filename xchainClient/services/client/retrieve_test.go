@@ -0,0 +1,172 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-data-segment/datasegment"
+	filabi "github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+	carv2 "github.com/ipld/go-car/v2"
+	"github.com/ipld/go-car/v2/storage"
+	"github.com/stretchr/testify/require"
+)
+
+// offeredCar builds the CAR of data the way offer-file does
+func offeredCar(t *testing.T, data []byte) (*retrieval, []byte) {
+	filePath := filepath.Join(t.TempDir(), "data.bin")
+	require.NoError(t, os.WriteFile(filePath, data, 0644))
+	src, err := newDagSource(filePath, SymlinksPreserve)
+	require.NoError(t, err)
+	stream := newCarStream(context.Background(), src, 1<<30)
+	carBytes, err := io.ReadAll(stream)
+	require.NoError(t, err)
+	root, err := stream.Root()
+	require.NoError(t, err)
+	commPStr, size, err := stream.Digest()
+	require.NoError(t, err)
+	commP, err := cid.Decode(commPStr)
+	require.NoError(t, err)
+	return &retrieval{offerID: 1, commP: commP, size: size, root: root}, carBytes
+}
+
+func TestRetrieveFromBuffer(t *testing.T) {
+	r, carBytes := offeredCar(t, bytes.Repeat([]byte("buffer"), 1<<12))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/good":
+			w.Write(carBytes)
+		case "/corrupt":
+			w.Write(append([]byte{}, carBytes[:len(carBytes)-1]...))
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+	defer srv.Close()
+
+	// Mirrors are tried in order until one serves the offered CommP
+	r.location = srv.URL + "/missing " + srv.URL + "/corrupt " + srv.URL + "/good"
+	output := filepath.Join(t.TempDir(), "out.car")
+	require.NoError(t, retrieveTo(context.Background(), output, r.fromBuffer))
+	got, err := os.ReadFile(output)
+	require.NoError(t, err)
+	require.Equal(t, carBytes, got)
+
+	r.location = srv.URL + "/corrupt"
+	err = retrieveTo(context.Background(), output+"2", r.fromBuffer)
+	require.ErrorContains(t, err, "CommP mismatch")
+	require.NoFileExists(t, output+"2")
+	require.NoFileExists(t, output+"2.part")
+}
+
+func TestRetrieveSegmentFromAggregate(t *testing.T) {
+	first, firstCar := offeredCar(t, bytes.Repeat([]byte("first"), 1<<12))
+	second, secondCar := offeredCar(t, bytes.Repeat([]byte("second"), 1<<13))
+
+	dealSize := filabi.PaddedPieceSize(1 << 20)
+	agg, err := datasegment.NewAggregate(dealSize, []filabi.PieceInfo{
+		{Size: filabi.PaddedPieceSize(first.size), PieceCID: first.commP},
+		{Size: filabi.PaddedPieceSize(second.size), PieceCID: second.commP},
+	})
+	require.NoError(t, err)
+	aggReader, err := agg.AggregateObjectReader([]io.Reader{bytes.NewReader(firstCar), bytes.NewReader(secondCar)})
+	require.NoError(t, err)
+	aggBytes, err := io.ReadAll(aggReader)
+	require.NoError(t, err)
+	require.Len(t, aggBytes, int(dealSize.Unpadded()))
+
+	for name, handler := range map[string]http.HandlerFunc{
+		"range": func(w http.ResponseWriter, req *http.Request) {
+			http.ServeContent(w, req, "piece", time.Time{}, bytes.NewReader(aggBytes))
+		},
+		"no range": func(w http.ResponseWriter, req *http.Request) {
+			w.Write(aggBytes)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(handler)
+			defer srv.Close()
+
+			output := filepath.Join(t.TempDir(), "out.car")
+			err := retrieveTo(context.Background(), output, func(ctx context.Context, w *os.File) error {
+				return second.fetchSegment(ctx, srv.URL, dealSize, w)
+			})
+			require.NoError(t, err)
+			got, err := os.ReadFile(output)
+			require.NoError(t, err)
+			require.Equal(t, secondCar, got, "padding after the CAR is trimmed")
+		})
+	}
+}
+
+// gatewayCar encodes the blocks of an offered CAR again with its root in the
+// header, like a gateway serving the DAG would
+func gatewayCar(t *testing.T, carBytes []byte, root cid.Cid) []byte {
+	t.Helper()
+	br, err := carv2.NewBlockReader(bytes.NewReader(carBytes))
+	require.NoError(t, err)
+	var out bytes.Buffer
+	w, err := storage.NewWritable(&out, []cid.Cid{root}, carv2.WriteAsCarV1(true))
+	require.NoError(t, err)
+	for {
+		blk, err := br.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		require.NoError(t, w.Put(context.Background(), blk.Cid().KeyString(), blk.RawData()))
+	}
+	require.NoError(t, w.Finalize())
+	return out.Bytes()
+}
+
+func TestRetrieveFromGatewayChecksBlocks(t *testing.T) {
+	r, offered := offeredCar(t, bytes.Repeat([]byte("gateway"), 1<<12))
+	carBytes := gatewayCar(t, offered, r.root)
+	corrupt := append([]byte{}, carBytes...)
+	corrupt[len(corrupt)-1] ^= 0xff
+
+	var serve []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/ipfs/"+r.root.String() || req.URL.Query().Get("format") != "car" {
+			http.NotFound(w, req)
+			return
+		}
+		w.Write(serve)
+	}))
+	defer srv.Close()
+
+	output := filepath.Join(t.TempDir(), "out.car")
+	serve = carBytes
+	require.NoError(t, retrieveTo(context.Background(), output, func(ctx context.Context, w *os.File) error {
+		return r.fromGateway(ctx, srv.URL, w)
+	}))
+
+	serve = corrupt
+	err := retrieveTo(context.Background(), output, func(ctx context.Context, w *os.File) error {
+		return r.fromGateway(ctx, srv.URL, w)
+	})
+	require.Error(t, err)
+}
+
+func TestCarLength(t *testing.T) {
+	_, carBytes := offeredCar(t, []byte("small"))
+	n, err := carLength(bytes.NewReader(carBytes))
+	require.NoError(t, err)
+	require.Equal(t, int64(len(carBytes)), n)
+
+	padded := append(append([]byte{}, carBytes...), make([]byte, 1024)...)
+	n, err = carLength(bytes.NewReader(padded))
+	require.NoError(t, err)
+	require.Equal(t, int64(len(carBytes)), n)
+
+	_, err = carLength(bytes.NewReader(carBytes[:len(carBytes)-1]))
+	require.Error(t, err)
+}
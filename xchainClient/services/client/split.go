@@ -0,0 +1,165 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/FIL-Builders/xchainClient/config"
+	filabi "github.com/filecoin-project/go-state-types/abi"
+)
+
+// ManifestVersion is bumped whenever the manifest format changes
+const ManifestVersion = 1
+
+// Manifest links the offers a file was split into so that it can be
+// reassembled by concatenating the parts in order
+type Manifest struct {
+	Version      int            `json:"version"`
+	Name         string         `json:"name"`
+	Size         int64          `json:"size"`
+	MaxPieceSize uint64         `json:"maxPieceSize"`
+	Parts        []ManifestPart `json:"parts"`
+}
+
+// ManifestPart is a byte range of the file and the offer holding it
+type ManifestPart struct {
+	Index  int   `json:"index"`
+	Offset int64 `json:"offset"`
+	Length int64 `json:"length"`
+	OfferResult
+}
+
+// resolveMaxPieceSize returns the largest padded piece size a single offer may have.
+// By default this is half of TargetAggSize, the largest piece that can be aggregated
+// together with the data segment index.
+func resolveMaxPieceSize(cfg *config.Config, override uint64) (uint64, error) {
+	maxPieceSize := override
+	if maxPieceSize == 0 {
+		maxPieceSize = uint64(cfg.TargetAggSize) / 2
+	}
+	if err := filabi.PaddedPieceSize(maxPieceSize).Validate(); err != nil {
+		return 0, fmt.Errorf("invalid max piece size %d: %w", maxPieceSize, err)
+	}
+	return maxPieceSize, nil
+}
+
+// maxCarSize is the largest CAR that fits in a piece of maxPieceSize
+func maxCarSize(maxPieceSize uint64) uint64 {
+	return uint64(filabi.PaddedPieceSize(maxPieceSize).Unpadded())
+}
+
+// split returns the sources to offer so that each CAR stays below maxCarSize.
+// Only a single regular file can be split, it is cut into byte ranges leaving
+// room for the CAR framing and UnixFS nodes around the data.
+func (s *dagSource) split(maxCarSize uint64) ([]*dagSource, error) {
+	if len(s.paths) != 1 {
+		return []*dagSource{s}, nil
+	}
+	fi, err := os.Stat(s.paths[0])
+	if err != nil {
+		return nil, err
+	}
+	partSize := int64(maxCarSize - maxCarSize/64)
+	if !fi.Mode().IsRegular() || fi.Size() <= partSize {
+		return []*dagSource{s}, nil
+	}
+
+	count := int((fi.Size() + partSize - 1) / partSize)
+	parts := make([]*dagSource, 0, count)
+	for i := 0; i < count; i++ {
+		offset := int64(i) * partSize
+		length := partSize
+		if offset+length > fi.Size() {
+			length = fi.Size() - offset
+		}
+		parts = append(parts, &dagSource{
+			paths:    s.paths,
+			symlinks: s.symlinks,
+			part:     &filePart{index: i, count: count, offset: offset, length: length},
+			enc:      s.enc,
+		})
+	}
+	return parts, nil
+}
+
+// newManifest describes the split of the file behind parts, offers are added as they are made
+func newManifest(parts []*dagSource, maxPieceSize uint64) (*Manifest, error) {
+	fi, err := os.Stat(parts[0].paths[0])
+	if err != nil {
+		return nil, err
+	}
+	return &Manifest{
+		Version:      ManifestVersion,
+		Name:         filepath.Base(parts[0].paths[0]),
+		Size:         fi.Size(),
+		MaxPieceSize: maxPieceSize,
+	}, nil
+}
+
+// resumeManifest reads the manifest of the interrupted run that offered the
+// file described by fresh and keeps the offers of the parts before first
+func resumeManifest(path string, fresh *Manifest, first int) (*Manifest, error) {
+	m, err := ReadManifest(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the manifest of the interrupted run: %w", err)
+	}
+	if m.Name != fresh.Name || m.Size != fresh.Size || m.MaxPieceSize != fresh.MaxPieceSize {
+		return nil, fmt.Errorf("manifest %s is for %s of %d bytes in pieces of %d bytes, not %s of %d bytes in pieces of %d bytes",
+			path, m.Name, m.Size, m.MaxPieceSize, fresh.Name, fresh.Size, fresh.MaxPieceSize)
+	}
+	kept := m.Parts[:0]
+	for _, p := range m.Parts {
+		if p.Index < first {
+			kept = append(kept, p)
+		}
+	}
+	if len(kept) != first {
+		return nil, fmt.Errorf("manifest %s holds %d of the %d parts before part %d", path, len(kept), first, first+1)
+	}
+	m.Parts = kept
+	return m, nil
+}
+
+// add records the offer made for a part of the file
+func (m *Manifest) add(src *dagSource, result *OfferResult) {
+	part := ManifestPart{OfferResult: *result}
+	if src.part != nil {
+		part.Index = src.part.index
+		part.Offset = src.part.offset
+		part.Length = src.part.length
+	} else {
+		part.Length = m.Size
+	}
+	m.Parts = append(m.Parts, part)
+}
+
+// Write saves the manifest to path, replacing the previous version atomically
+func (m *Manifest) Write(path string) error {
+	bs, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, bs, 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// ReadManifest loads a manifest written by offer-file
+func ReadManifest(path string) (*Manifest, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(bs, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", path, err)
+	}
+	if m.Version != ManifestVersion {
+		return nil, fmt.Errorf("unsupported manifest version %d", m.Version)
+	}
+	return &m, nil
+}
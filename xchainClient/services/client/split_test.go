@@ -0,0 +1,166 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/FIL-Builders/xchainClient/config"
+	"github.com/FIL-Builders/xchainClient/services/buffer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitOversizeFile(t *testing.T) {
+	maxPieceSize, err := resolveMaxPieceSize(&config.Config{TargetAggSize: 1 << 20}, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(512<<10), maxPieceSize)
+	_, err = resolveMaxPieceSize(&config.Config{}, 1000)
+	require.Error(t, err)
+
+	content := make([]byte, 2<<20)
+	_, err = rand.Read(content)
+	require.NoError(t, err)
+	filePath := filepath.Join(t.TempDir(), "large.bin")
+	require.NoError(t, os.WriteFile(filePath, content, 0644))
+
+	src, err := newDagSource(filePath, SymlinksPreserve)
+	require.NoError(t, err)
+	parts, err := src.split(maxCarSize(maxPieceSize))
+	require.NoError(t, err)
+	require.Len(t, parts, 5)
+
+	// Every part fits in a piece and together they hold the whole file
+	var reassembled bytes.Buffer
+	roots := make(map[string]bool)
+	for i, part := range parts {
+		require.Equal(t, i, part.part.index)
+		stream := newCarStream(context.Background(), part, maxCarSize(maxPieceSize))
+		_, err := io.Copy(io.Discard, stream)
+		require.NoError(t, err)
+		root, err := stream.Root()
+		require.NoError(t, err)
+		roots[root.String()] = true
+
+		f, err := os.Open(filePath)
+		require.NoError(t, err)
+		_, err = io.Copy(&reassembled, io.NewSectionReader(f, part.part.offset, part.part.length))
+		require.NoError(t, err)
+		f.Close()
+	}
+	require.Len(t, roots, len(parts))
+	require.Equal(t, content, reassembled.Bytes())
+
+	// Small files are offered whole
+	single, err := src.split(maxCarSize(4 << 20))
+	require.NoError(t, err)
+	require.Equal(t, []*dagSource{src}, single)
+}
+
+func TestManifestRoundTrip(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "large.bin")
+	require.NoError(t, os.WriteFile(filePath, make([]byte, 1000), 0644))
+	src, err := newDagSource(filePath, SymlinksPreserve)
+	require.NoError(t, err)
+	parts, err := src.split(600)
+	require.NoError(t, err)
+	require.Len(t, parts, 2)
+
+	manifest, err := newManifest(parts, 1024)
+	require.NoError(t, err)
+	for i, part := range parts {
+		manifest.add(part, &OfferResult{OfferID: uint64(10 + i)})
+	}
+	manifestPath := filepath.Join(t.TempDir(), "large.bin.manifest.json")
+	require.NoError(t, manifest.Write(manifestPath))
+
+	read, err := ReadManifest(manifestPath)
+	require.NoError(t, err)
+	require.Equal(t, manifest, read)
+	require.Equal(t, "large.bin", read.Name)
+	require.Equal(t, uint64(11), read.Parts[1].OfferID)
+	require.Equal(t, read.Parts[0].Length, read.Parts[1].Offset)
+}
+
+func TestResumeManifest(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "large.bin")
+	require.NoError(t, os.WriteFile(filePath, make([]byte, 1000), 0644))
+	src, err := newDagSource(filePath, SymlinksPreserve)
+	require.NoError(t, err)
+	parts, err := src.split(400)
+	require.NoError(t, err)
+	require.Len(t, parts, 3)
+
+	// The interrupted run offered the first part and failed on the second
+	manifest, err := newManifest(parts, 1024)
+	require.NoError(t, err)
+	manifest.add(parts[0], &OfferResult{OfferID: 10})
+	manifestPath := filepath.Join(t.TempDir(), "large.bin.manifest.json")
+	require.NoError(t, manifest.Write(manifestPath))
+
+	fresh, err := newManifest(parts, 1024)
+	require.NoError(t, err)
+	resumed, err := resumeManifest(manifestPath, fresh, 1)
+	require.NoError(t, err)
+	require.Equal(t, manifest, resumed)
+
+	_, err = resumeManifest(manifestPath, fresh, 2)
+	require.ErrorContains(t, err, "holds 1 of the 2 parts before part 3")
+
+	other, err := newManifest(parts, 2048)
+	require.NoError(t, err)
+	_, err = resumeManifest(manifestPath, other, 1)
+	require.ErrorContains(t, err, "in pieces of 1024 bytes")
+
+	_, err = resumeManifest(filepath.Join(t.TempDir(), "missing.json"), fresh, 1)
+	require.ErrorContains(t, err, "failed to read the manifest")
+}
+
+func TestFirstPart(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		part     int
+		count    int
+		uploadID string
+		first    int
+		err      string
+	}{
+		{name: "single", count: 1},
+		{name: "single resumed", count: 1, uploadID: "session"},
+		{name: "split", count: 3},
+		{name: "split resumed", part: 2, count: 3, uploadID: "session", first: 1},
+		{name: "split restarted at part", part: 3, count: 3, first: 2},
+		{name: "split resumed without part", count: 3, uploadID: "session", err: "give the --part"},
+		{name: "part of single", part: 1, count: 1, err: "only be used when the input is split"},
+		{name: "part out of range", part: 4, count: 3, err: "between 1 and 3"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			first, err := firstPart(tc.part, tc.count, tc.uploadID)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.first, first)
+		})
+	}
+}
+
+func TestResumeHint(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "large.bin")
+	require.NoError(t, os.WriteFile(filePath, make([]byte, 1000), 0644))
+	src, err := newDagSource(filePath, SymlinksPreserve)
+	require.NoError(t, err)
+	parts, err := src.split(600)
+	require.NoError(t, err)
+
+	uploadErr := fmt.Errorf("failed to upload: %w", &buffer.UploadError{ID: "session", Err: io.ErrUnexpectedEOF})
+	require.Equal(t, ", resume with --upload-id session", resumeHint(src, uploadErr))
+	require.Equal(t, ", resume with --part 2 --upload-id session", resumeHint(parts[1], uploadErr))
+	// Errors that are not from an upload session cannot be resumed
+	require.Empty(t, resumeHint(parts[1], io.ErrUnexpectedEOF))
+}
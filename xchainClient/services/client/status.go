@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/FIL-Builders/xchainClient/logging"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+// OfferStatus mirrors OnRamp.sol's `OfferStatus` enum
+type OfferStatus uint8
+
+const (
+	OfferPending OfferStatus = iota
+	OfferAggregated
+	OfferProven
+)
+
+func (s OfferStatus) String() string {
+	switch s {
+	case OfferPending:
+		return "pending"
+	case OfferAggregated:
+		return "aggregated"
+	case OfferProven:
+		return "proven"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(s))
+	}
+}
+
+// offerStatus calls getOfferStatus on the OnRamp
+func (c *onrampConn) offerStatus(ctx context.Context, offerID uint64) (OfferStatus, bool, error) {
+	out, err := c.onramp.GetOfferStatus(&bind.CallOpts{Context: ctx}, offerID)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get status of offer %d: %w", offerID, err)
+	}
+	return OfferStatus(out.Status), out.Exists, nil
+}
+
+// waitProven polls the status of the offers until all of them are proven
+func (c *onrampConn) waitProven(ctx context.Context, offerIDs []uint64, interval time.Duration) error {
+	last := make(map[uint64]OfferStatus, len(offerIDs))
+	remaining := append([]uint64(nil), offerIDs...)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		var next []uint64
+		for _, id := range remaining {
+			status, exists, err := c.offerStatus(ctx, id)
+			if err != nil {
+				// Transient RPC failures should not end a long wait
+				slog.Warn("Failed to get offer status, retrying", "err", err, "interval", interval)
+				next = append(next, id)
+				continue
+			}
+			if !exists {
+				return fmt.Errorf("offer %d does not exist", id)
+			}
+			if prev, seen := last[id]; !seen || prev != status {
+				slog.Info("Offer status", logging.OfferID, id, "status", status)
+				last[id] = status
+			}
+			if status != OfferProven {
+				next = append(next, id)
+			}
+		}
+		remaining = next
+		if len(remaining) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped waiting for offers %v: %w", remaining, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-unixfsnode/data/builder"
+	dagpb "github.com/ipld/go-codec-dagpb"
+	"github.com/ipld/go-ipld-prime"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+)
+
+// Symlink handling modes for symlinks found inside offered directories.
+// Symlinks given directly as inputs are always followed.
+const (
+	SymlinksPreserve = "preserve" // store the symlink itself as a UnixFS symlink node
+	SymlinksFollow   = "follow"   // store the file or directory the symlink points to
+	SymlinksSkip     = "skip"     // leave symlinks out of the DAG
+)
+
+// dagSource is the set of local paths that make up one offered UnixFS DAG
+type dagSource struct {
+	paths    []string
+	symlinks string
+	part     *filePart  // only this byte range of a single file is offered
+	enc      *encryptor // files are encrypted before they are chunked when set
+}
+
+// filePart is one of the pieces an oversize file is split into
+type filePart struct {
+	index  int
+	count  int
+	offset int64
+	length int64
+}
+
+// newDagSource expands pattern, a file, directory or glob, into the paths to offer
+func newDagSource(pattern string, symlinks string) (*dagSource, error) {
+	switch symlinks {
+	case SymlinksPreserve, SymlinksFollow, SymlinksSkip:
+	default:
+		return nil, fmt.Errorf("unknown symlink mode %q, expected %s, %s or %s", symlinks, SymlinksPreserve, SymlinksFollow, SymlinksSkip)
+	}
+
+	paths := []string{pattern}
+	if strings.ContainsAny(pattern, "*?[") {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid glob pattern %q: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no files match %q", pattern)
+		}
+		paths = matches
+	}
+
+	// Entries are named after the base name of each path, they have to be unique
+	names := make(map[string]string, len(paths))
+	for _, p := range paths {
+		if _, err := os.Stat(p); err != nil {
+			return nil, err
+		}
+		name := filepath.Base(p)
+		if other, ok := names[name]; ok {
+			return nil, fmt.Errorf("%s and %s would both be stored as %q", other, p, name)
+		}
+		names[name] = p
+	}
+	sort.Strings(paths)
+	return &dagSource{paths: paths, symlinks: symlinks}, nil
+}
+
+// id identifies the input paths of s across runs
+func (s *dagSource) id() (string, error) {
+	h := sha256.New()
+	for _, p := range s.paths {
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			return "", err
+		}
+		h.Write([]byte(abs))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (s *dagSource) String() string {
+	if s.part != nil {
+		return fmt.Sprintf("%s (part %d of %d)", s.paths[0], s.part.index+1, s.part.count)
+	}
+	return strings.Join(s.paths, ", ")
+}
+
+// build writes the UnixFS DAG through ls and returns its root.
+// A single directory is the root itself, a single file is wrapped in a one
+// entry directory and several paths become the entries of a new directory.
+// Directories too large for a single block are HAMT sharded.
+func (s *dagSource) build(ls *ipld.LinkSystem) (cid.Cid, error) {
+	var root ipld.Link
+	if len(s.paths) == 1 {
+		fi, err := os.Stat(s.paths[0])
+		if err != nil {
+			return cid.Undef, err
+		}
+		switch {
+		case s.part != nil:
+			root, err = s.wrapPart(ls, s.paths[0], s.part)
+		case fi.IsDir():
+			root, _, err = s.buildPath(ls, s.paths[0], nil)
+		default:
+			root, err = s.wrapFile(ls, s.paths[0], fi.Size())
+		}
+		if err != nil {
+			return cid.Undef, err
+		}
+	} else {
+		entries := make([]dagpb.PBLink, 0, len(s.paths))
+		for _, p := range s.paths {
+			l, size, err := s.buildPath(ls, p, nil)
+			if err != nil {
+				return cid.Undef, err
+			}
+			entry, err := builder.BuildUnixFSDirectoryEntry(filepath.Base(p), int64(size), l)
+			if err != nil {
+				return cid.Undef, err
+			}
+			entries = append(entries, entry)
+		}
+		var err error
+		root, _, err = builder.BuildUnixFSDirectory(entries, ls)
+		if err != nil {
+			return cid.Undef, err
+		}
+	}
+
+	rcl, ok := root.(cidlink.Link)
+	if !ok {
+		return cid.Undef, fmt.Errorf("could not interpret root as CID link")
+	}
+	return rcl.Cid, nil
+}
+
+// wrapFile builds the DAG for a single file inside a one entry directory
+func (s *dagSource) wrapFile(ls *ipld.LinkSystem, filePath string, fileSize int64) (ipld.Link, error) {
+	fp, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer fp.Close()
+	r, err := s.content(filePath, fp)
+	if err != nil {
+		return nil, err
+	}
+	l, _, err := builder.BuildUnixFSFile(r, "", ls)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure size is set correctly
+	if s.enc != nil {
+		fileSize = encryptedSize(fileSize)
+	}
+	entry, err := builder.BuildUnixFSDirectoryEntry(filepath.Base(filePath), fileSize, l)
+	if err != nil {
+		return nil, err
+	}
+	root, _, err := builder.BuildUnixFSDirectory([]dagpb.PBLink{entry}, ls)
+	return root, err
+}
+
+// wrapPart builds the DAG for a part of a file inside a one entry directory,
+// the entry is named after the file with the part number appended
+func (s *dagSource) wrapPart(ls *ipld.LinkSystem, filePath string, part *filePart) (ipld.Link, error) {
+	fp, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer fp.Close()
+	r, err := s.content(fmt.Sprintf("%s@%d", filePath, part.offset), io.NewSectionReader(fp, part.offset, part.length))
+	if err != nil {
+		return nil, err
+	}
+	l, _, err := builder.BuildUnixFSFile(r, "", ls)
+	if err != nil {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("%s.part%04d", filepath.Base(filePath), part.index)
+	size := part.length
+	if s.enc != nil {
+		size = encryptedSize(size)
+	}
+	entry, err := builder.BuildUnixFSDirectoryEntry(name, size, l)
+	if err != nil {
+		return nil, err
+	}
+	root, _, err := builder.BuildUnixFSDirectory([]dagpb.PBLink{entry}, ls)
+	return root, err
+}
+
+// buildPath builds the DAG for p and returns its link and cumulative size.
+// Inputs are followed when they are symlinks, anything below them is handled
+// according to the symlink mode. parents holds the resolved directories above
+// p to catch symlink cycles.
+func (s *dagSource) buildPath(ls *ipld.LinkSystem, p string, parents []string) (ipld.Link, uint64, error) {
+	info, err := os.Lstat(p)
+	if err != nil {
+		return nil, 0, err
+	}
+	if info.Mode().Type() == fs.ModeSymlink && parents != nil && s.symlinks == SymlinksPreserve {
+		target, err := os.Readlink(p)
+		if err != nil {
+			return nil, 0, err
+		}
+		return builder.BuildUnixFSSymlink(target, ls)
+	}
+	if info.Mode().Type() == fs.ModeSymlink {
+		if info, err = os.Stat(p); err != nil {
+			return nil, 0, fmt.Errorf("broken symlink %s: %w", p, err)
+		}
+	}
+
+	switch {
+	case info.IsDir():
+		resolved, err := filepath.EvalSymlinks(p)
+		if err != nil {
+			return nil, 0, err
+		}
+		resolved, err = filepath.Abs(resolved)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, parent := range parents {
+			if parent == resolved {
+				return nil, 0, fmt.Errorf("symlink cycle at %s", p)
+			}
+		}
+		parents = append(parents, resolved)
+
+		dirEntries, err := os.ReadDir(p)
+		if err != nil {
+			return nil, 0, err
+		}
+		entries := make([]dagpb.PBLink, 0, len(dirEntries))
+		for _, e := range dirEntries {
+			if e.Type() == fs.ModeSymlink && s.symlinks == SymlinksSkip {
+				continue
+			}
+			l, size, err := s.buildPath(ls, filepath.Join(p, e.Name()), parents)
+			if err != nil {
+				return nil, 0, err
+			}
+			entry, err := builder.BuildUnixFSDirectoryEntry(e.Name(), int64(size), l)
+			if err != nil {
+				return nil, 0, err
+			}
+			entries = append(entries, entry)
+		}
+		// BuildUnixFSDirectory switches to a HAMT shard once the entries outgrow a block
+		return builder.BuildUnixFSDirectory(entries, ls)
+	case info.Mode().IsRegular():
+		fp, err := os.Open(p)
+		if err != nil {
+			return nil, 0, err
+		}
+		defer fp.Close()
+		r, err := s.content(p, fp)
+		if err != nil {
+			return nil, 0, err
+		}
+		return builder.BuildUnixFSFile(r, "", ls)
+	default:
+		return nil, 0, fmt.Errorf("cannot encode non regular file: %s", p)
+	}
+}
+
+// content returns what is stored for the file read by r, name identifies
+// the file so that it is encrypted the same way each time the DAG is built
+func (s *dagSource) content(name string, r io.Reader) (io.Reader, error) {
+	if s.enc == nil {
+		return r, nil
+	}
+	return s.enc.reader(name, r)
+}
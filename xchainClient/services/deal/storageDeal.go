@@ -1,50 +1,413 @@
 package deal
 
 import (
+	"bytes"
 	"context"
-	"log"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/FIL-Builders/xchainClient/config"
+	"github.com/FIL-Builders/xchainClient/contracts"
+	"github.com/FIL-Builders/xchainClient/logging"
+	"github.com/FIL-Builders/xchainClient/metrics"
+	"github.com/FIL-Builders/xchainClient/services/aggregator"
+	"github.com/FIL-Builders/xchainClient/utils"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/filecoin-project/go-address"
+	filabi "github.com/filecoin-project/go-state-types/abi"
+	builtintypes "github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/lotus/api"
+	lotustypes "github.com/filecoin-project/lotus/chain/types"
+	"github.com/ipfs/go-cid"
+)
+
+const (
+	// relayInterval is how often the relayer looks for new deals, about one epoch
+	relayInterval = 30 * time.Second
+	// relayGraceEpochs is how long the attestation sent by dealNotify has to
+	// reach the OnRamp before a deal is relayed, and the wait between relays
+	relayGraceEpochs = 240
+	// dealLookbackEpochs is how far back deals are looked for on start
+	dealLookbackEpochs = 2880
+	// maxLogRange is the largest block range of one eth_getLogs call, Lotus
+	// refuses ranges over 2880 epochs by default
+	maxLogRange = 2000
+	// statusDealActivated is DealActivated of the Prover's Status enum
+	statusDealActivated = 2
 )
 
-// SmartContractDeal continuously runs logic until the context is canceled
+// notifiedDeal is a deal published with the Prover as client, as announced by
+// its DealNotify event
+type notifiedDeal struct {
+	dealID   uint64
+	commP    []byte
+	notified uint64 // epoch of the DealNotify event
+	relayed  uint64 // epoch of the last relay, 0 if never relayed
+}
+
+// due tells whether the attestation of d should be sent at epoch head, grace
+// epochs after the deal was published
+func (d *notifiedDeal) due(head, grace uint64) bool {
+	if head < d.notified+grace {
+		return false
+	}
+	return d.relayed == 0 || head >= d.relayed+relayGraceEpochs
+}
+
+type relayer struct {
+	lotus    aggregator.LotusDaemonAPIClientV0 // Lotus API for market deal state
+	fevm     *ethclient.Client                 // Lotus eth API for Prover logs and transactions
+	prover   *contracts.ProverAxelar           // Prover binding on Filecoin
+	proverID address.Address                   // ID address of the Prover, the client of its deals
+	client   *ethclient.Client                 // source chain client
+	onramp   *contracts.OnRamp                 // OnRamp binding on the source chain
+	oracle   *contracts.TrustedRelayerOracle   // trusted relayer oracle on the source chain, nil to relay through the Prover
+	auth     *bind.TransactOpts                // auth for relay transactions
+	chainID  string                            // source chain ID as encoded in deal labels
+	next     uint64                            // next block to look for DealNotify events in
+	deals    map[string]*notifiedDeal          // deals awaiting proof, by commP
+	logger   *slog.Logger                      // logger with the chain field set
+	grace    time.Duration                     // how long relays in flight have to finish on shutdown
+	cleanup  func()                            // cleanup function to call on shutdown
+}
+
+// SmartContractDeal relays the deals made by the Prover contract for the
+// aggregates of srcCfg's OnRamp until the context is canceled.
+//
+// The Prover attests a deal to the source chain when it is published. Deals
+// whose aggregate is still not proven on the OnRamp relayGraceEpochs later,
+// because the cross chain message was lost or ran out of gas, are sent again
+// with the Prover's relayDeal.
 func SmartContractDeal(ctx context.Context, cfg *config.Config, srcCfg *config.SourceChainConfig) error {
-	log.Println("Starting SmartContractDeal process...")
+	slog.Info("Starting deal relayer", logging.Chain, srcCfg.ChainID)
+	r, err := newRelayer(ctx, cfg, srcCfg, false)
+	if err != nil {
+		return err
+	}
+	return r.run(ctx)
+}
+
+// TrustedRelayerDeal proves the deals made by the Prover contract for the
+// aggregates of srcCfg's OnRamp itself until the context is canceled, for
+// source chains without a bridge to Filecoin. Once a deal is active its
+// DataAttestation is sent to the TrustedRelayerOracle at srcCfg.OracleAddress,
+// which has to trust the address of the configured key.
+func TrustedRelayerDeal(ctx context.Context, cfg *config.Config, srcCfg *config.SourceChainConfig) error {
+	slog.Info("Starting trusted relayer", logging.Chain, srcCfg.ChainID)
+	r, err := newRelayer(ctx, cfg, srcCfg, true)
+	if err != nil {
+		return err
+	}
+	return r.run(ctx)
+}
+
+// run polls for deals to relay until the context is canceled
+func (r *relayer) run(ctx context.Context) error {
+	defer r.cleanup()
 
-	// Example: Perform a periodic task in a loop until the context is canceled
-	ticker := time.NewTicker(5 * time.Second) // Adjust the interval as needed
+	ticker := time.NewTicker(relayInterval)
 	defer ticker.Stop()
+	// A poll under way when shutting down gets the grace period to see its
+	// relay transactions mined
+	work, cancel := utils.DrainContext(ctx, r.grace)
+	defer cancel()
 
 	for {
+		if err := r.poll(work); err != nil {
+			r.logger.Error("Failed to relay deals", "err", err)
+		}
 		select {
 		case <-ctx.Done():
-			// Handle graceful shutdown
-			log.Println("SmartContractDeal process is shutting down...")
+			r.logger.Info("Deal relayer is shutting down")
 			return nil
 		case <-ticker.C:
-			// Example logic: Interact with a smart contract or process data
-			err := processSmartContractLogic(cfg, srcCfg)
-			if err != nil {
-				log.Printf("Error in SmartContractDeal process: %v", err)
-			} else {
-				log.Println("SmartContractDeal task completed successfully")
+		}
+	}
+}
+
+func newRelayer(ctx context.Context, cfg *config.Config, srcCfg *config.SourceChainConfig, direct bool) (*relayer, error) {
+	lAPI, closer, err := aggregator.NewLotusDaemonAPIClientV0(ctx, cfg.Destination.LotusAPI, 0, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to lotus at %s: %w", cfg.Destination.LotusAPI, err)
+	}
+	r, err := connectRelayer(ctx, cfg, srcCfg, lAPI, direct)
+	if err != nil {
+		closer()
+		return nil, err
+	}
+	r.cleanup = func() {
+		closer()
+		r.fevm.Close()
+		r.client.Close()
+	}
+	return r, nil
+}
+
+func connectRelayer(ctx context.Context, cfg *config.Config, srcCfg *config.SourceChainConfig, lAPI aggregator.LotusDaemonAPIClientV0, direct bool) (*relayer, error) {
+	fevmURL := lotusEthURL(cfg.Destination.LotusAPI)
+	fevm, err := ethclient.Dial(fevmURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the Filecoin eth API at %s: %w", fevmURL, err)
+	}
+	proverAddr := common.HexToAddress(cfg.Destination.ProverAddr)
+	prover, err := contracts.NewProverAxelar(proverAddr, fevm)
+	if err != nil {
+		return nil, err
+	}
+	proverF4, err := address.NewDelegatedAddress(builtintypes.EthereumAddressManagerActorID, proverAddr[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate prover address (%s) into a "+
+			"Filecoin f4 address: %w", proverAddr.Hex(), err)
+	}
+	proverID, err := lAPI.StateLookupID(ctx, proverF4, lotustypes.EmptyTSK)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up prover %s: %w", proverF4, err)
+	}
+
+	client, err := ethclient.Dial(srcCfg.Api)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Ethereum client for source chain at %s: %w", srcCfg.Api, err)
+	}
+	onramp, err := contracts.NewOnRamp(common.HexToAddress(srcCfg.OnRampAddress), client)
+	if err != nil {
+		return nil, err
+	}
+	chainID, err := utils.EncodeChainIDAsString(big.NewInt(int64(srcCfg.ChainID)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode chainID: %w", err)
+	}
+
+	// Transactions go to the oracle on the source chain when proving deals
+	// directly, and to the Prover on Filecoin otherwise
+	var oracle *contracts.TrustedRelayerOracle
+	authChainID := cfg.Destination.ChainID
+	if direct {
+		if !common.IsHexAddress(srcCfg.OracleAddress) {
+			return nil, fmt.Errorf("invalid trusted relayer oracle address %q", srcCfg.OracleAddress)
+		}
+		oracle, err = contracts.NewTrustedRelayerOracle(common.HexToAddress(srcCfg.OracleAddress), client)
+		if err != nil {
+			return nil, err
+		}
+		authChainID = srcCfg.ChainID
+	}
+	auth, err := utils.LoadPrivateKey(cfg, authChainID)
+	if err != nil {
+		return nil, err
+	}
+	if direct {
+		trusted, err := oracle.Relayers(&bind.CallOpts{Context: ctx}, auth.From)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check relayer %s with oracle: %w", auth.From, err)
+		}
+		if !trusted {
+			return nil, fmt.Errorf("%s is not a trusted relayer of oracle %s", auth.From, srcCfg.OracleAddress)
+		}
+	}
+
+	head, err := fevm.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain head: %w", err)
+	}
+	var next uint64
+	if head > dealLookbackEpochs {
+		next = head - dealLookbackEpochs
+	}
+	logger := slog.Default().With(logging.Chain, chainID)
+	logger.Info("Relaying deals", "prover", proverAddr, "proverID", proverID, "fromEpoch", next)
+
+	return &relayer{
+		lotus:    lAPI,
+		fevm:     fevm,
+		prover:   prover,
+		proverID: proverID,
+		client:   client,
+		onramp:   onramp,
+		oracle:   oracle,
+		auth:     auth,
+		chainID:  chainID,
+		next:     next,
+		deals:    make(map[string]*notifiedDeal),
+		logger:   logger,
+		grace:    cfg.GracePeriod(),
+	}, nil
+}
+
+var hasRPCSuffix = regexp.MustCompile(`\/rpc\/v[01]\/?\z`)
+
+// lotusEthURL returns the endpoint of the eth API of the Lotus node at url,
+// which Lotus only serves on its v1 API
+func lotusEthURL(url string) string {
+	url = hasRPCSuffix.ReplaceAllString(url, "")
+	return strings.TrimSuffix(url, "/") + "/rpc/v1"
+}
+
+// poll collects the deals published since the last poll and relays those due
+func (r *relayer) poll(ctx context.Context) error {
+	head, err := r.fevm.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get chain head: %w", err)
+	}
+	if err := r.collectDeals(ctx, head); err != nil {
+		return err
+	}
+	for key, d := range r.deals {
+		done, err := r.relay(ctx, d, head)
+		if err != nil {
+			r.logger.Warn("Failed to relay deal", logging.DealID, d.dealID, logging.AggCommP, formatCommP(d.commP), "err", err)
+		}
+		if done {
+			delete(r.deals, key)
+		}
+	}
+	return nil
+}
+
+// collectDeals reads the DealNotify events of the Prover up to head and keeps
+// the deals labelled with the source chain
+func (r *relayer) collectDeals(ctx context.Context, head uint64) error {
+	for r.next <= head {
+		end := min(r.next+maxLogRange-1, head)
+		it, err := r.prover.FilterDealNotify(&bind.FilterOpts{Start: r.next, End: &end, Context: ctx})
+		if err != nil {
+			return fmt.Errorf("failed to filter DealNotify events from %d to %d: %w", r.next, end, err)
+		}
+		for it.Next() {
+			if string(it.Event.ChainId) != r.chainID {
+				continue
+			}
+			r.logger.Info("Deal published", logging.DealID, it.Event.DealId, logging.AggCommP, formatCommP(it.Event.CommP), "epoch", it.Event.Raw.BlockNumber)
+			r.deals[string(it.Event.CommP)] = &notifiedDeal{
+				dealID:   it.Event.DealId,
+				commP:    it.Event.CommP,
+				notified: it.Event.Raw.BlockNumber,
 			}
 		}
+		err = it.Error()
+		it.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read DealNotify events: %w", err)
+		}
+		r.next = end + 1
 	}
+	return nil
 }
 
-// processSmartContractLogic handles the core logic of the SmartContractDeal process
-func processSmartContractLogic(cfg *config.Config, srcCfg *config.SourceChainConfig) error {
-	// Example placeholder logic:
-	// This is where you could interact with a smart contract, fetch data, or perform computations.
+// relay sends the attestation of d when its aggregate is not proven on the
+// OnRamp in time. It reports done once the deal needs no more attention.
+func (r *relayer) relay(ctx context.Context, d *notifiedDeal, head uint64) (bool, error) {
+	deal, err := r.lotus.StateMarketStorageDeal(ctx, filabi.DealID(d.dealID), lotustypes.EmptyTSK)
+	if err != nil {
+		// Deals that are never activated are removed from the market
+		return head >= d.notified+dealLookbackEpochs, fmt.Errorf("failed to get deal: %w", err)
+	}
+	if err := checkDeal(d, deal, r.proverID); err != nil {
+		return true, err
+	}
 
-	log.Printf("Processing smart contract logic with chain: %s and config: %+v", srcCfg.OnRampAddress, cfg)
+	opts := &bind.CallOpts{Context: ctx}
+	aggID, err := r.onramp.CommPToAggregateID(opts, d.commP)
+	if err != nil {
+		return false, fmt.Errorf("failed to get aggregate: %w", err)
+	}
+	if aggID == 0 {
+		return true, fmt.Errorf("piece is not an aggregate committed to the OnRamp")
+	}
+	proven, err := r.onramp.ProvenAggregations(opts, aggID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get proof status of aggregate %d: %w", aggID, err)
+	}
+	if proven {
+		r.logger.Info("Aggregate proven", "aggregationID", aggID, logging.DealID, d.dealID)
+		return true, nil
+	}
+	if r.oracle != nil {
+		return false, r.attest(ctx, d, deal, aggID, head)
+	}
+	if !d.due(head, relayGraceEpochs) {
+		return false, nil
+	}
 
-	// TODO: Implement actual logic to interact with a smart contract
-	// - Call an Ethereum or Filecoin smart contract
-	// - Process events or data
-	// - Execute business logic
+	// The relayer pays the Axelar gas of the message it sends
+	fee, err := r.prover.AXELARGASFEE(opts)
+	if err != nil {
+		return false, fmt.Errorf("failed to get relay gas fee: %w", err)
+	}
+	auth := *r.auth
+	auth.Value = fee
+	tx, err := r.prover.RelayDeal(&auth, d.commP)
+	if err != nil {
+		return false, fmt.Errorf("failed to relay: %w", err)
+	}
+	d.relayed = head
+	r.logger.Info("Relaying deal", logging.DealID, d.dealID, "aggregationID", aggID, "tx", tx.Hash())
+	metrics.DealsRelayed.WithLabelValues(r.chainID, "prover").Inc()
+	receipt, err := bind.WaitMined(ctx, r.fevm, tx)
+	if err != nil {
+		return false, fmt.Errorf("failed to wait for relay tx %s: %w", tx.Hash(), err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return false, fmt.Errorf("relay tx %s failed", tx.Hash())
+	}
+	return false, nil
+}
 
-	return nil // Return an error if something fails
+// attest proves an active deal on the OnRamp through the trusted relayer oracle
+func (r *relayer) attest(ctx context.Context, d *notifiedDeal, deal *api.MarketDeal, aggID uint64, head uint64) error {
+	if deal.State.SectorStartEpoch <= 0 || !d.due(head, 0) {
+		return nil
+	}
+	tx, err := r.oracle.RelayAttestation(r.auth, attestation(d, deal))
+	if err != nil {
+		return fmt.Errorf("failed to relay attestation: %w", err)
+	}
+	d.relayed = head
+	r.logger.Info("Proving aggregate", "aggregationID", aggID, logging.DealID, d.dealID, "tx", tx.Hash())
+	metrics.DealsRelayed.WithLabelValues(r.chainID, "oracle").Inc()
+	receipt, err := bind.WaitMined(ctx, r.client, tx)
+	if err != nil {
+		return fmt.Errorf("failed to wait for attestation tx %s: %w", tx.Hash(), err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("attestation tx %s failed", tx.Hash())
+	}
+	return nil
+}
+
+// attestation is the DataAttestation of an active deal, as the Prover would
+// send it once the deal is activated
+func attestation(d *notifiedDeal, deal *api.MarketDeal) contracts.DataAttestation {
+	return contracts.DataAttestation{
+		CommP:    d.commP,
+		Duration: int64(deal.Proposal.EndEpoch - deal.Proposal.StartEpoch),
+		DealID:   d.dealID,
+		Status:   big.NewInt(statusDealActivated),
+	}
+}
+
+// checkDeal checks that the market deal is the one the Prover announced and
+// that it can still prove its aggregate
+func checkDeal(d *notifiedDeal, deal *api.MarketDeal, proverID address.Address) error {
+	if deal.Proposal.Client != proverID {
+		return fmt.Errorf("client is %s, not the prover %s", deal.Proposal.Client, proverID)
+	}
+	_, commP, err := cid.CidFromBytes(d.commP)
+	if err != nil {
+		return fmt.Errorf("invalid piece CID: %w", err)
+	}
+	if !bytes.Equal(deal.Proposal.PieceCID.Bytes(), commP.Bytes()) {
+		return fmt.Errorf("deal is for piece %s, not %s", deal.Proposal.PieceCID, commP)
+	}
+	if deal.State.SlashEpoch != -1 {
+		return fmt.Errorf("deal was slashed at epoch %d", deal.State.SlashEpoch)
+	}
+	return nil
 }
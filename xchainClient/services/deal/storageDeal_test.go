@@ -0,0 +1,76 @@
+package deal
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	commcid "github.com/filecoin-project/go-fil-commcid"
+	"github.com/filecoin-project/go-state-types/builtin/v9/market"
+	"github.com/filecoin-project/lotus/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckDeal(t *testing.T) {
+	prover, err := address.NewIDAddress(1234)
+	require.NoError(t, err)
+	other, err := address.NewIDAddress(5678)
+	require.NoError(t, err)
+	piece, err := commcid.PieceCommitmentV1ToCID(make([]byte, 32))
+	require.NoError(t, err)
+	otherComm := make([]byte, 32)
+	otherComm[0] = 1
+	otherPiece, err := commcid.PieceCommitmentV1ToCID(otherComm)
+	require.NoError(t, err)
+
+	d := &notifiedDeal{dealID: 7, commP: piece.Bytes()}
+	deal := func(client address.Address) *api.MarketDeal {
+		return &api.MarketDeal{
+			Proposal: market.DealProposal{PieceCID: piece, Client: client},
+			State:    api.MarketDealState{SectorStartEpoch: -1, LastUpdatedEpoch: -1, SlashEpoch: -1},
+		}
+	}
+	require.NoError(t, checkDeal(d, deal(prover), prover))
+	require.ErrorContains(t, checkDeal(d, deal(other), prover), "not the prover")
+
+	slashed := deal(prover)
+	slashed.State.SlashEpoch = 100
+	require.ErrorContains(t, checkDeal(d, slashed, prover), "slashed")
+
+	moved := &notifiedDeal{dealID: 7, commP: otherPiece.Bytes()}
+	require.ErrorContains(t, checkDeal(moved, deal(prover), prover), "not "+otherPiece.String())
+}
+
+func TestRelayDue(t *testing.T) {
+	d := &notifiedDeal{notified: 1000}
+	require.False(t, d.due(1000, relayGraceEpochs))
+	require.False(t, d.due(1000+relayGraceEpochs-1, relayGraceEpochs))
+	require.True(t, d.due(1000+relayGraceEpochs, relayGraceEpochs))
+	require.True(t, d.due(1000, 0))
+
+	// Relays of the same deal are spaced out as well
+	d.relayed = 1000 + relayGraceEpochs
+	require.False(t, d.due(d.relayed+1, relayGraceEpochs))
+	require.False(t, d.due(d.relayed+1, 0))
+	require.True(t, d.due(d.relayed+relayGraceEpochs, relayGraceEpochs))
+}
+
+func TestAttestation(t *testing.T) {
+	d := &notifiedDeal{dealID: 7, commP: []byte{1, 2, 3}}
+	deal := &api.MarketDeal{Proposal: market.DealProposal{StartEpoch: 1000, EndEpoch: 519400}}
+	att := attestation(d, deal)
+	require.Equal(t, d.commP, att.CommP)
+	require.Equal(t, int64(518400), att.Duration)
+	require.Equal(t, uint64(7), att.DealID)
+	require.Equal(t, int64(statusDealActivated), att.Status.Int64())
+}
+
+func TestLotusEthURL(t *testing.T) {
+	for url, want := range map[string]string{
+		"https://api.calibration.node.glif.io":  "https://api.calibration.node.glif.io/rpc/v1",
+		"https://api.calibration.node.glif.io/": "https://api.calibration.node.glif.io/rpc/v1",
+		"http://127.0.0.1:1234/rpc/v0":          "http://127.0.0.1:1234/rpc/v1",
+		"ws://127.0.0.1:1234/rpc/v1/":           "ws://127.0.0.1:1234/rpc/v1",
+	} {
+		require.Equal(t, want, lotusEthURL(url), url)
+	}
+}
@@ -0,0 +1,546 @@
+package deal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/FIL-Builders/xchainClient/config"
+	"github.com/FIL-Builders/xchainClient/contracts"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	fbig "github.com/filecoin-project/go-state-types/big"
+	lotustypes "github.com/filecoin-project/lotus/chain/types"
+	"github.com/ipfs/go-cid"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	// sourceLogRange is the largest block range of one eth_getLogs call on the source chain
+	sourceLogRange = 5000
+	// sourceLookbackBlocks is how far back source chain events are looked for by default
+	sourceLookbackBlocks = 50000
+)
+
+// Attestation status of a piece
+const (
+	StatusPending = "pending" // sent recently, not proven yet
+	StatusStuck   = "stuck"   // sent longer ago than expected, not proven yet
+	StatusUnpaid  = "unpaid"  // the last message paid no gas, Axelar will not deliver it
+	StatusFailed  = "failed"  // delivery cannot prove the piece on the OnRamp
+	StatusProven  = "proven"  // the OnRamp recorded the proof
+)
+
+// axelarEvents are the events of the Axelar gateway and gas service emitted
+// when the Prover sends an attestation
+var axelarEvents = mustParseABI(`[
+	{"anonymous":false,"name":"ContractCall","type":"event","inputs":[
+		{"indexed":true,"name":"sender","type":"address"},
+		{"indexed":false,"name":"destinationChain","type":"string"},
+		{"indexed":false,"name":"destinationContractAddress","type":"string"},
+		{"indexed":true,"name":"payloadHash","type":"bytes32"},
+		{"indexed":false,"name":"payload","type":"bytes"}]},
+	{"anonymous":false,"name":"NativeGasPaidForContractCall","type":"event","inputs":[
+		{"indexed":true,"name":"sourceAddress","type":"address"},
+		{"indexed":false,"name":"destinationChain","type":"string"},
+		{"indexed":false,"name":"destinationAddress","type":"string"},
+		{"indexed":true,"name":"payloadHash","type":"bytes32"},
+		{"indexed":false,"name":"gasFeeAmount","type":"uint256"},
+		{"indexed":false,"name":"refundAddress","type":"address"}]}
+]`)
+
+// attestationPayload is the ABI encoding of a DataAttestation sent across chains
+var attestationPayload = abi.Arguments{{Type: mustNewType("tuple", []abi.ArgumentMarshaling{
+	{Name: "commP", Type: "bytes"},
+	{Name: "duration", Type: "int64"},
+	{Name: "dealID", Type: "uint64"},
+	{Name: "status", Type: "uint256"},
+})}}
+
+func mustParseABI(s string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(s))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+func mustNewType(t string, components []abi.ArgumentMarshaling) abi.Type {
+	typ, err := abi.NewType(t, "", components)
+	if err != nil {
+		panic(err)
+	}
+	return typ
+}
+
+// Attestation follows the cross chain messages the Prover sent for a piece
+// to the source chain, and whether the OnRamp received them
+type Attestation struct {
+	CommP      string    `json:"commP"`
+	DealID     uint64    `json:"dealId"`
+	Messages   int       `json:"messages"` // attestations sent for the piece
+	LastTx     string    `json:"lastTx"`   // Filecoin transaction of the last attestation
+	LastSent   time.Time `json:"lastSent"`
+	Age        string    `json:"age"` // since the last attestation was sent
+	GasPaid    string    `json:"gasPaid"`
+	LastGas    string    `json:"lastGas"` // gas paid for the last attestation
+	ReceivedTx string    `json:"receivedTx,omitempty"`
+	ProvenTx   string    `json:"provenTx,omitempty"`
+	Status     string    `json:"status"`
+	Reason     string    `json:"reason,omitempty"`
+
+	commP     []byte
+	gasPaid   *big.Int
+	lastGas   *big.Int
+	committed bool // the piece is an aggregate committed to the OnRamp
+	proven    bool
+}
+
+// update sets the status, age and gas of a at time now
+func (a *Attestation) update(now time.Time, stuckAfter time.Duration) {
+	age := now.Sub(a.LastSent)
+	a.Age = age.Round(time.Second).String()
+	a.GasPaid = formatFIL(a.gasPaid)
+	a.LastGas = formatFIL(a.lastGas)
+	a.Reason = ""
+	switch {
+	case a.proven:
+		a.Status = StatusProven
+	case !a.committed:
+		a.Status = StatusFailed
+		a.Reason = "piece is not an aggregate committed to the OnRamp"
+	case a.lastGas.Sign() == 0:
+		a.Status = StatusUnpaid
+		a.Reason = "no gas paid to the Axelar gas service"
+	case age >= stuckAfter:
+		a.Status = StatusStuck
+		a.Reason = fmt.Sprintf("not proven %s after it was sent", age.Round(time.Second))
+	default:
+		a.Status = StatusPending
+	}
+}
+
+func formatFIL(v *big.Int) string {
+	if v == nil {
+		v = new(big.Int)
+	}
+	return lotustypes.FIL(fbig.NewFromGo(v)).String()
+}
+
+// crossChainCall is an attestation the Prover sent through the Axelar gateway
+type crossChainCall struct {
+	attestation contracts.DataAttestation
+	gasPaid     *big.Int
+}
+
+// crossChainCalls decodes the attestations the Prover sent in a transaction
+// and the gas paid for each of them from its receipt
+func crossChainCalls(receipt *types.Receipt, prover common.Address) ([]*crossChainCall, error) {
+	contractCall := axelarEvents.Events["ContractCall"]
+	gasPaid := axelarEvents.Events["NativeGasPaidForContractCall"]
+	calls := make(map[common.Hash]*crossChainCall)
+	var order []common.Hash
+	gas := make(map[common.Hash]*big.Int)
+	for _, l := range receipt.Logs {
+		if len(l.Topics) != 3 || common.BytesToAddress(l.Topics[1].Bytes()) != prover {
+			continue
+		}
+		payloadHash := l.Topics[2]
+		switch l.Topics[0] {
+		case contractCall.ID:
+			fields := make(map[string]interface{})
+			if err := axelarEvents.UnpackIntoMap(fields, contractCall.Name, l.Data); err != nil {
+				return nil, fmt.Errorf("failed to unpack ContractCall: %w", err)
+			}
+			att, err := decodeAttestation(fields["payload"].([]byte))
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := calls[payloadHash]; !ok {
+				order = append(order, payloadHash)
+			}
+			calls[payloadHash] = &crossChainCall{attestation: *att}
+		case gasPaid.ID:
+			fields := make(map[string]interface{})
+			if err := axelarEvents.UnpackIntoMap(fields, gasPaid.Name, l.Data); err != nil {
+				return nil, fmt.Errorf("failed to unpack NativeGasPaidForContractCall: %w", err)
+			}
+			if gas[payloadHash] == nil {
+				gas[payloadHash] = new(big.Int)
+			}
+			gas[payloadHash].Add(gas[payloadHash], fields["gasFeeAmount"].(*big.Int))
+		}
+	}
+
+	result := make([]*crossChainCall, 0, len(order))
+	for _, h := range order {
+		c := calls[h]
+		c.gasPaid = gas[h]
+		if c.gasPaid == nil {
+			c.gasPaid = new(big.Int)
+		}
+		result = append(result, c)
+	}
+	return result, nil
+}
+
+// decodeAttestation decodes the DataAttestation payload of a cross chain call
+func decodeAttestation(payload []byte) (*contracts.DataAttestation, error) {
+	values, err := attestationPayload.Unpack(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode attestation: %w", err)
+	}
+	att := abi.ConvertType(values[0], new(contracts.DataAttestation)).(*contracts.DataAttestation)
+	return att, nil
+}
+
+type tracker struct {
+	fevm       *ethclient.Client       // Lotus eth API
+	prover     *contracts.ProverAxelar // Prover binding on Filecoin
+	proverAddr common.Address          // Prover address
+	chainName  string                  // Axelar name of the source chain
+	oracleAddr common.Address          // Axelar oracle on the source chain
+	client     *ethclient.Client       // source chain client
+	onramp     *contracts.OnRamp       // OnRamp binding on the source chain
+	oracle     *contracts.AxelarBridge // oracle binding on the source chain
+	next       uint64                  // next Filecoin block to look for attestations in
+	srcNext    uint64                  // next source chain block to look for deliveries in
+	pieces     map[string]*Attestation // attestations by commP
+	times      map[uint64]time.Time    // Filecoin block timestamps
+}
+
+func newTracker(ctx context.Context, cfg *config.Config, srcCfg *config.SourceChainConfig, fromBlock, srcFromBlock uint64) (*tracker, error) {
+	fevmURL := lotusEthURL(cfg.Destination.LotusAPI)
+	fevm, err := ethclient.Dial(fevmURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the Filecoin eth API at %s: %w", fevmURL, err)
+	}
+	proverAddr := common.HexToAddress(cfg.Destination.ProverAddr)
+	prover, err := contracts.NewProverAxelar(proverAddr, fevm)
+	if err != nil {
+		return nil, err
+	}
+	chainName, oracleAddr, err := prover.GetSourceChain(&bind.CallOpts{Context: ctx}, big.NewInt(int64(srcCfg.ChainID)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the source chain %d of prover %s: %w", srcCfg.ChainID, proverAddr, err)
+	}
+
+	client, err := ethclient.Dial(srcCfg.Api)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Ethereum client for source chain at %s: %w", srcCfg.Api, err)
+	}
+	onramp, err := contracts.NewOnRamp(common.HexToAddress(srcCfg.OnRampAddress), client)
+	if err != nil {
+		return nil, err
+	}
+	oracle, err := contracts.NewAxelarBridge(oracleAddr, client)
+	if err != nil {
+		return nil, err
+	}
+
+	if fromBlock == 0 {
+		head, err := fevm.BlockNumber(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get chain head: %w", err)
+		}
+		if head > dealLookbackEpochs {
+			fromBlock = head - dealLookbackEpochs
+		}
+	}
+	if srcFromBlock == 0 {
+		head, err := client.BlockNumber(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get source chain head: %w", err)
+		}
+		if head > sourceLookbackBlocks {
+			srcFromBlock = head - sourceLookbackBlocks
+		}
+	}
+
+	return &tracker{
+		fevm:       fevm,
+		prover:     prover,
+		proverAddr: proverAddr,
+		chainName:  chainName,
+		oracleAddr: oracleAddr,
+		client:     client,
+		onramp:     onramp,
+		oracle:     oracle,
+		next:       fromBlock,
+		srcNext:    srcFromBlock,
+		pieces:     make(map[string]*Attestation),
+		times:      make(map[uint64]time.Time),
+	}, nil
+}
+
+func (t *tracker) close() {
+	t.fevm.Close()
+	t.client.Close()
+}
+
+// update reads the attestations sent and delivered since the last update and
+// checks the OnRamp for the pieces not proven yet
+func (t *tracker) update(ctx context.Context) error {
+	head, err := t.fevm.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get chain head: %w", err)
+	}
+	for t.next <= head {
+		end := min(t.next+maxLogRange-1, head)
+		if err := t.collectSent(ctx, t.next, end); err != nil {
+			return err
+		}
+		t.next = end + 1
+	}
+
+	srcHead, err := t.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get source chain head: %w", err)
+	}
+	for t.srcNext <= srcHead {
+		end := min(t.srcNext+sourceLogRange-1, srcHead)
+		if err := t.collectDelivered(ctx, t.srcNext, end); err != nil {
+			return err
+		}
+		t.srcNext = end + 1
+	}
+
+	opts := &bind.CallOpts{Context: ctx}
+	for _, a := range t.pieces {
+		if a.proven {
+			continue
+		}
+		aggID, err := t.onramp.CommPToAggregateID(opts, a.commP)
+		if err != nil {
+			return fmt.Errorf("failed to get aggregate of %s: %w", a.CommP, err)
+		}
+		a.committed = aggID != 0
+		if !a.committed {
+			continue
+		}
+		// Deliveries older than the scanned blocks only show in the OnRamp state
+		if a.proven, err = t.onramp.ProvenAggregations(opts, aggID); err != nil {
+			return fmt.Errorf("failed to get proof status of aggregate %d: %w", aggID, err)
+		}
+	}
+	return nil
+}
+
+// collectSent reads the attestations the Prover sent to the source chain's
+// oracle between Filecoin blocks start and end
+func (t *tracker) collectSent(ctx context.Context, start, end uint64) error {
+	it, err := t.prover.FilterXChainProveDataStored(&bind.FilterOpts{Start: start, End: &end, Context: ctx})
+	if err != nil {
+		return fmt.Errorf("failed to filter XChainProveDataStored events from %d to %d: %w", start, end, err)
+	}
+	defer it.Close()
+	txs := make(map[common.Hash]bool)
+	for it.Next() {
+		ev := it.Event
+		if ev.DestinationChain != t.chainName || !strings.EqualFold(ev.DestinationAddress, t.oracleAddr.Hex()) {
+			continue
+		}
+		if txs[ev.Raw.TxHash] {
+			continue
+		}
+		txs[ev.Raw.TxHash] = true
+		if err := t.recordSent(ctx, ev.Raw); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return fmt.Errorf("failed to read XChainProveDataStored events: %w", err)
+	}
+	return nil
+}
+
+func (t *tracker) recordSent(ctx context.Context, l types.Log) error {
+	receipt, err := t.fevm.TransactionReceipt(ctx, l.TxHash)
+	if err != nil {
+		return fmt.Errorf("failed to get receipt of %s: %w", l.TxHash, err)
+	}
+	calls, err := crossChainCalls(receipt, t.proverAddr)
+	if err != nil {
+		return fmt.Errorf("tx %s: %w", l.TxHash, err)
+	}
+	sent, err := t.blockTime(ctx, l.BlockNumber)
+	if err != nil {
+		return err
+	}
+	for _, c := range calls {
+		key := string(c.attestation.CommP)
+		a, ok := t.pieces[key]
+		if !ok {
+			a = &Attestation{CommP: formatCommP(c.attestation.CommP), commP: c.attestation.CommP, gasPaid: new(big.Int)}
+			t.pieces[key] = a
+		}
+		a.DealID = c.attestation.DealID
+		a.Messages++
+		a.LastTx = l.TxHash.Hex()
+		a.LastSent = sent
+		a.lastGas = c.gasPaid
+		a.gasPaid.Add(a.gasPaid, c.gasPaid)
+	}
+	return nil
+}
+
+func (t *tracker) blockTime(ctx context.Context, n uint64) (time.Time, error) {
+	if ts, ok := t.times[n]; ok {
+		return ts, nil
+	}
+	h, err := t.fevm.HeaderByNumber(ctx, new(big.Int).SetUint64(n))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get block %d: %w", n, err)
+	}
+	ts := time.Unix(int64(h.Time), 0)
+	t.times[n] = ts
+	return ts, nil
+}
+
+// collectDelivered reads the attestations received by the oracle and proven
+// on the OnRamp between source chain blocks start and end
+func (t *tracker) collectDelivered(ctx context.Context, start, end uint64) error {
+	opts := &bind.FilterOpts{Start: start, End: &end, Context: ctx}
+	received, err := t.oracle.FilterReceivedAttestation(opts, nil)
+	if err != nil {
+		return fmt.Errorf("failed to filter ReceivedAttestation events from %d to %d: %w", start, end, err)
+	}
+	for received.Next() {
+		if a, ok := t.pieces[string(received.Event.CommP)]; ok {
+			a.ReceivedTx = received.Event.Raw.TxHash.Hex()
+		}
+	}
+	err = received.Error()
+	received.Close()
+	if err != nil {
+		return fmt.Errorf("failed to read ReceivedAttestation events: %w", err)
+	}
+
+	proven, err := t.onramp.FilterProveDataStored(opts)
+	if err != nil {
+		return fmt.Errorf("failed to filter ProveDataStored events from %d to %d: %w", start, end, err)
+	}
+	for proven.Next() {
+		if a, ok := t.pieces[string(proven.Event.CommP)]; ok {
+			a.ProvenTx = proven.Event.Raw.TxHash.Hex()
+			a.committed = true
+			a.proven = true
+		}
+	}
+	err = proven.Error()
+	proven.Close()
+	if err != nil {
+		return fmt.Errorf("failed to read ProveDataStored events: %w", err)
+	}
+	return nil
+}
+
+// report returns the attestations ordered by the time they were last sent
+func (t *tracker) report(now time.Time, stuckAfter time.Duration) []*Attestation {
+	atts := make([]*Attestation, 0, len(t.pieces))
+	for _, a := range t.pieces {
+		a.update(now, stuckAfter)
+		atts = append(atts, a)
+	}
+	sort.Slice(atts, func(i, j int) bool { return atts[i].LastSent.Before(atts[j].LastSent) })
+	return atts
+}
+
+func formatCommP(commP []byte) string {
+	if _, c, err := cid.CidFromBytes(commP); err == nil {
+		return c.String()
+	}
+	return common.Bytes2Hex(commP)
+}
+
+// TrackAttestationsAction reports the attestations the Prover sent to the
+// source chain through Axelar and flags those that are stuck or failed
+func TrackAttestationsAction(cctx *cli.Context) error {
+	cfg, err := config.LoadConfig(cctx.String("config"))
+	if err != nil {
+		return fmt.Errorf("failed to load config: %v", err)
+	}
+	chainName := cctx.String("chain")
+	srcCfg, err := config.GetSourceConfig(cfg, chainName)
+	if err != nil {
+		return fmt.Errorf("invalid chain name '%s': %v", chainName, err)
+	}
+	t, err := newTracker(cctx.Context, cfg, srcCfg, cctx.Uint64("from-block"), cctx.Uint64("source-from-block"))
+	if err != nil {
+		return err
+	}
+	defer t.close()
+
+	stuckAfter := cctx.Duration("stuck-after")
+	watch := cctx.Duration("watch")
+	for {
+		if err := t.update(cctx.Context); err != nil {
+			return err
+		}
+		atts := t.report(time.Now(), stuckAfter)
+		if !cctx.Bool("all") {
+			atts = unproven(atts)
+		}
+		if cctx.Bool("json") {
+			enc := json.NewEncoder(cctx.App.Writer)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(atts); err != nil {
+				return err
+			}
+		} else if err := printAttestations(cctx.App.Writer, atts); err != nil {
+			return err
+		}
+
+		if watch == 0 {
+			if bad := countBad(atts); bad > 0 {
+				return fmt.Errorf("%d attestations are stuck or failed", bad)
+			}
+			return nil
+		}
+		select {
+		case <-cctx.Context.Done():
+			return nil
+		case <-time.After(watch):
+		}
+	}
+}
+
+func unproven(atts []*Attestation) []*Attestation {
+	var out []*Attestation
+	for _, a := range atts {
+		if a.Status != StatusProven {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+func countBad(atts []*Attestation) int {
+	bad := 0
+	for _, a := range atts {
+		switch a.Status {
+		case StatusStuck, StatusUnpaid, StatusFailed:
+			bad++
+		}
+	}
+	return bad
+}
+
+func printAttestations(w io.Writer, atts []*Attestation) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "COMMP\tDEAL\tSTATUS\tAGE\tMESSAGES\tLAST GAS\tGAS PAID\tLAST TX\tREASON")
+	for _, a := range atts {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%d\t%s\t%s\t%s\t%s\n", a.CommP, a.DealID, a.Status, a.Age, a.Messages, a.LastGas, a.GasPaid, a.LastTx, a.Reason)
+	}
+	return tw.Flush()
+}
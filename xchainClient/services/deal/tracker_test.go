@@ -0,0 +1,79 @@
+package deal
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/FIL-Builders/xchainClient/contracts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// axelarLog builds the log of an Axelar event emitted for a call of the Prover
+func axelarLog(t *testing.T, event string, prover common.Address, payload []byte, args ...interface{}) *types.Log {
+	ev := axelarEvents.Events[event]
+	data, err := ev.Inputs.NonIndexed().Pack(args...)
+	require.NoError(t, err)
+	return &types.Log{
+		Topics: []common.Hash{ev.ID, common.BytesToHash(prover.Bytes()), crypto.Keccak256Hash(payload)},
+		Data:   data,
+	}
+}
+
+func TestCrossChainCalls(t *testing.T) {
+	prover := common.HexToAddress("0x084622e6970BBcBA510454C6145313c2993ED9E4")
+	att := contracts.DataAttestation{CommP: []byte{1, 2, 3}, Duration: 518400, DealID: 42, Status: big.NewInt(1)}
+	payload, err := attestationPayload.Pack(att)
+	require.NoError(t, err)
+
+	gas := big.NewInt(1e17)
+	receipt := &types.Receipt{Logs: []*types.Log{
+		axelarLog(t, "NativeGasPaidForContractCall", prover, payload, "Avalanche", "0xoracle", gas, prover),
+		axelarLog(t, "ContractCall", prover, payload, "Avalanche", "0xoracle", payload),
+		// calls of other senders are not the Prover's
+		axelarLog(t, "ContractCall", common.HexToAddress("0x01"), payload, "Avalanche", "0xoracle", payload),
+	}}
+	calls, err := crossChainCalls(receipt, prover)
+	require.NoError(t, err)
+	require.Len(t, calls, 1)
+	require.Equal(t, att, calls[0].attestation)
+	require.Equal(t, gas, calls[0].gasPaid)
+
+	// Without gas the call is still reported
+	receipt.Logs = receipt.Logs[1:2]
+	calls, err = crossChainCalls(receipt, prover)
+	require.NoError(t, err)
+	require.Len(t, calls, 1)
+	require.Zero(t, calls[0].gasPaid.Sign())
+}
+
+func TestAttestationStatus(t *testing.T) {
+	sent := time.Unix(1700000000, 0)
+	a := &Attestation{LastSent: sent, gasPaid: big.NewInt(2e17), lastGas: big.NewInt(1e17), committed: true}
+
+	a.update(sent.Add(time.Minute), 30*time.Minute)
+	require.Equal(t, StatusPending, a.Status)
+	require.Equal(t, "1m0s", a.Age)
+	require.Equal(t, "0.2 FIL", a.GasPaid)
+
+	a.update(sent.Add(time.Hour), 30*time.Minute)
+	require.Equal(t, StatusStuck, a.Status)
+	require.Contains(t, a.Reason, "1h0m0s")
+
+	a.lastGas = new(big.Int)
+	a.update(sent.Add(time.Minute), 30*time.Minute)
+	require.Equal(t, StatusUnpaid, a.Status)
+
+	a.committed = false
+	a.update(sent.Add(time.Minute), 30*time.Minute)
+	require.Equal(t, StatusFailed, a.Status)
+
+	a.proven = true
+	a.update(sent.Add(time.Hour), 30*time.Minute)
+	require.Equal(t, StatusProven, a.Status)
+	require.Empty(t, a.Reason)
+	require.Equal(t, 1, countBad([]*Attestation{a, {Status: StatusStuck}, {Status: StatusPending}}))
+}
@@ -1,10 +1,13 @@
 package utils
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"math/big"
 	"os"
+	"time"
 
 	"github.com/FIL-Builders/xchainClient/config"
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -13,23 +16,6 @@ import (
 	"github.com/mitchellh/go-homedir"
 )
 
-// Load contract abi at the given path
-func LoadAbi(path string) (*abi.ABI, error) {
-	path, err := homedir.Expand(path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get absolute path: %w", err)
-	}
-	f, err := os.Open(path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open abi file: %w", err)
-	}
-	parsedABI, err := abi.JSON(f)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse abi: %w", err)
-	}
-	return &parsedABI, nil
-}
-
 func EncodeChainID(chainID *big.Int) ([]byte, error) {
 	// Define the ABI arguments
 	uint256Type, err := abi.NewType("uint256", "", nil)
@@ -94,13 +80,26 @@ func LoadPrivateKey(cfg *config.Config, chainId int) (*bind.TransactOpts, error)
 	}
 
 	a, err := ks.Import(keyJSON, passphrase, passphrase)
-	fmt.Println("Signer address is: ", a.Address)
 	if err != nil {
 		return nil, fmt.Errorf("failed to import key %s: %w", cfg.ClientAddr, err)
 	}
+	slog.Debug("Loaded signer", "address", a.Address)
 	if err := ks.Unlock(a, passphrase); err != nil {
 		return nil, fmt.Errorf("failed to unlock keystore: %w", err)
 	}
 
 	return bind.NewKeyStoreTransactorWithChainID(ks, a, big.NewInt(int64(chainId)))
 }
+
+// DrainContext returns a context that is canceled grace after ctx is done. Work
+// already started when ctx is canceled uses it to finish before shutting down.
+func DrainContext(ctx context.Context, grace time.Duration) (context.Context, context.CancelFunc) {
+	drain, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(ctx, func() {
+		time.AfterFunc(grace, cancel)
+	})
+	return drain, func() {
+		stop()
+		cancel()
+	}
+}
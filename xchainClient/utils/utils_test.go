@@ -7,6 +7,7 @@ import (
 	"log"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/FIL-Builders/xchainClient/config"
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -89,3 +90,17 @@ func decodeChainID(data []byte) (*big.Int, error) {
 
 	return chainID, nil
 }
+
+func TestDrainContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	drain, stop := DrainContext(ctx, 50*time.Millisecond)
+	defer stop()
+
+	cancel()
+	assert.NoError(t, drain.Err(), "work keeps going during the grace period")
+	select {
+	case <-drain.Done():
+	case <-time.After(time.Second):
+		t.Fatal("drain context not canceled after the grace period")
+	}
+}